@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/itchio/wizardry/wizardry/wizinterpreter"
+	"github.com/itchio/wizardry/wizardry/wizparser"
+	"github.com/itchio/wizardry/wizardry/wizutil"
+	"github.com/pkg/errors"
+)
+
+func doCarve() error {
+	magdir := *carveArgs.magdir
+
+	NoLogf := func(format string, args ...interface{}) {}
+
+	Logf := func(format string, args ...interface{}) {
+		fmt.Println(fmt.Sprintf(format, args...))
+	}
+
+	pctx := &wizparser.ParseContext{
+		Logf: NoLogf,
+	}
+
+	if *appArgs.debugParser {
+		pctx.Logf = Logf
+	}
+
+	book := make(wizparser.Spellbook)
+	err := pctx.ParseAll(magdir, book)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	target := *carveArgs.target
+	targetReader, err := os.Open(target)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	defer targetReader.Close()
+
+	stat, err := targetReader.Stat()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	ictx := &wizinterpreter.InterpretContext{
+		Logf: NoLogf,
+		Book: book,
+	}
+
+	if *appArgs.debugInterpreter {
+		ictx.Logf = Logf
+	}
+
+	sr := wizutil.NewSliceReader(targetReader, 0, stat.Size())
+
+	results, err := ictx.Carve(sr, *carveArgs.stride)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, result := range results {
+		fmt.Printf("0x%x: %s\n", result.Offset, wizutil.MergeStrings(result.Strings))
+	}
+
+	return nil
+}
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
+	"os"
 
 	"github.com/itchio/wizardry/wizardry/wizcompiler"
 	"github.com/itchio/wizardry/wizardry/wizparser"
@@ -31,10 +34,32 @@ func doCompile() error {
 		return errors.WithStack(err)
 	}
 
-	err = wizcompiler.Compile(book, *compileArgs.output, *compileArgs.chatty, *compileArgs.emitComments, *compileArgs.pkg)
+	output := *compileArgs.output
+	fmt.Println("Generating into:", output)
+
+	var buf bytes.Buffer
+	stats, err := wizcompiler.CompileTo(&buf, book, wizcompiler.CompileOptions{
+		Package:      *compileArgs.pkg,
+		Chatty:       *compileArgs.chatty,
+		EmitComments: *compileArgs.emitComments,
+		MIME:         *compileArgs.mime,
+		Linear:       *compileArgs.linear,
+	})
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
+	err = ioutil.WriteFile(output, buf.Bytes(), 0644)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	fmt.Printf("Compiled %d rules into %d functions in %s\n", stats.Rules, stats.Pages, stats.Duration)
+	if stats.Dispatched > 0 {
+		fmt.Printf("Dispatched %d top-level rules on their first byte\n", stats.Dispatched)
+	}
+	fmt.Printf("Generated code is %.2f KiB\n", float64(stats.Size)/1024.0)
+	stats.WriteReport(os.Stdout)
+
 	return nil
 }
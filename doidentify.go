@@ -3,7 +3,10 @@ package main
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
+	"github.com/itchio/wizardry/wizardry/wizcache"
 	"github.com/itchio/wizardry/wizardry/wizinterpreter"
 	"github.com/itchio/wizardry/wizardry/wizparser"
 	"github.com/itchio/wizardry/wizardry/wizutil"
@@ -44,8 +47,9 @@ func doIdentify() error {
 	stat, _ := targetReader.Stat()
 
 	ictx := &wizinterpreter.InterpretContext{
-		Logf: NoLogf,
-		Book: book,
+		Logf:           NoLogf,
+		Book:           book,
+		RankCandidates: *identifyArgs.candidates,
 	}
 
 	if *appArgs.debugInterpreter {
@@ -54,12 +58,37 @@ func doIdentify() error {
 
 	sr := wizutil.NewSliceReader(targetReader, 0, stat.Size())
 
-	result, err := ictx.Identify(sr)
+	identify := ictx.IdentifyResult
+	if *identifyArgs.cacheDir != "" {
+		store, err := wizcache.NewDirStore(*identifyArgs.cacheDir)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		identify = wizcache.New(ictx, store).IdentifyResult
+	}
+
+	result, err := identify(sr)
 	if err != nil {
-		panic(err)
+		return errors.WithStack(err)
 	}
 
-	fmt.Printf("%s: %s\n", target, wizutil.MergeStrings(result))
+	fmt.Printf("%s: %s\n", target, wizutil.MergeStrings(result.Strings))
+
+	if *identifyArgs.fields {
+		var names []string
+		for name := range result.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			fmt.Printf("  %s: %s\n", name, strings.Join(result.Fields[name], ", "))
+		}
+	}
+
+	for _, candidate := range result.Candidates {
+		fmt.Printf("  [%d] %s (strength %d, %d levels, %d bytes)\n", candidate.Score, wizutil.MergeStrings(candidate.Strings), candidate.Strength, candidate.Levels, candidate.Bytes)
+	}
 
 	return nil
 }
@@ -12,6 +12,7 @@ var (
 
 	compileCmd  = app.Command("compile", "Compile a set of magic files into one .go file")
 	identifyCmd = app.Command("identify", "Use a magic file to identify a target file")
+	carveCmd    = app.Command("carve", "Look for files embedded at any offset of a target file")
 )
 
 var appArgs = struct {
@@ -23,11 +24,27 @@ var appArgs = struct {
 }
 
 var identifyArgs = struct {
-	magdir *string
-	target *string
+	magdir     *string
+	target     *string
+	fields     *bool
+	candidates *bool
+	cacheDir   *string
 }{
 	identifyCmd.Arg("magdir", "the folder of magic files to compile").Required().String(),
 	identifyCmd.Arg("target", "path of the the file to identify").Required().String(),
+	identifyCmd.Flag("fields", "also print the fields captured by rules").Bool(),
+	identifyCmd.Flag("candidates", "also print every candidate, best first, with its score").Bool(),
+	identifyCmd.Flag("cache-dir", "remember results in this folder, to skip evaluation on the next run").String(),
+}
+
+var carveArgs = struct {
+	magdir *string
+	target *string
+	stride *int64
+}{
+	carveCmd.Arg("magdir", "the folder of magic files to use").Required().String(),
+	carveCmd.Arg("target", "path of the file to scan").Required().String(),
+	carveCmd.Flag("stride", "only look at offsets that are a multiple of this").Default("1").Int64(),
 }
 
 var compileArgs = struct {
@@ -36,12 +53,16 @@ var compileArgs = struct {
 	chatty       *bool
 	emitComments *bool
 	pkg          *string
+	mime         *bool
+	linear       *bool
 }{
 	compileCmd.Arg("magdir", "the folder of magic files to compile").Required().String(),
 	compileCmd.Flag("output", "the go file to generate").Short('o').Required().String(),
 	compileCmd.Flag("chatty", "generate prints on every rule match").Bool(),
 	compileCmd.Flag("emit-comments", "generate comments in the code").Bool(),
 	compileCmd.Flag("package", "go package to generate").Default("main").String(),
+	compileCmd.Flag("mime", "report MIME types and extensions, and generate a table of them").Bool(),
+	compileCmd.Flag("linear", "evaluate every top-level rule, without dispatching on the first byte").Bool(),
 }
 
 func main() {
@@ -59,6 +80,8 @@ func main() {
 		must(doCompile())
 	case identifyCmd.FullCommand():
 		must(doIdentify())
+	case carveCmd.FullCommand():
+		must(doCarve())
 	}
 }
 
@@ -50,13 +50,23 @@ type StringFinder struct {
 	// rightmost "abc" (at position 6) is a prefix of the whole pattern, so
 	// goodSuffixSkip[3] == shift+len(suffix) == 6+5 == 11.
 	goodSuffixSkip []int64
+
+	// flags can make letters in the pattern match both cases
+	flags StringTestFlags
 }
 
 // MakeStringFinder prepares a finder for a given pattern
 func MakeStringFinder(pattern string) *StringFinder {
+	return MakeStringFinderWith(pattern, 0)
+}
+
+// MakeStringFinderWith is like MakeStringFinder, with flags. Only the
+// case-insensitivity flags are taken into account.
+func MakeStringFinderWith(pattern string, flags StringTestFlags) *StringFinder {
 	f := &StringFinder{
 		pattern:        pattern,
 		goodSuffixSkip: make([]int64, len(pattern)),
+		flags:          flags & (LowerMatchesBoth | UpperMatchesBoth),
 	}
 	// last is the index of the last character in the pattern.
 	last := len(pattern) - 1
@@ -71,6 +81,18 @@ func MakeStringFinder(pattern string) *StringFinder {
 	// that it is not in the last position.
 	for i := 0; i < last; i++ {
 		f.badCharSkip[pattern[i]] = int64(last - i)
+		if other, ok := f.otherCase(pattern[i]); ok {
+			f.badCharSkip[other] = int64(last - i)
+		}
+	}
+
+	if f.flags != 0 {
+		// The suffix table relies on bytes of the pattern only matching
+		// themselves, which isn't true anymore: fall back to shifting by one.
+		for i := last; i >= 0; i-- {
+			f.goodSuffixSkip[i] = int64(last - i + 1)
+		}
+		return f
 	}
 
 	// Build good suffix table.
@@ -96,6 +118,26 @@ func MakeStringFinder(pattern string) *StringFinder {
 	return f
 }
 
+// otherCase returns the other byte a pattern byte matches, if any
+func (f *StringFinder) otherCase(p byte) (byte, bool) {
+	if f.flags&LowerMatchesBoth > 0 && wizutil.IsLowerLetter(p) {
+		return wizutil.ToUpper(p), true
+	}
+	if f.flags&UpperMatchesBoth > 0 && wizutil.IsUpperLetter(p) {
+		return wizutil.ToLower(p), true
+	}
+	return 0, false
+}
+
+// matches returns true if byte c of the text matches byte p of the pattern
+func (f *StringFinder) matches(c byte, p byte) bool {
+	if c == p {
+		return true
+	}
+	other, ok := f.otherCase(p)
+	return ok && c == other
+}
+
 func longestCommonSuffix(a, b string) (i int) {
 	for ; i < len(a) && i < len(b); i++ {
 		if a[len(a)-1-i] != b[len(b)-1-i] {
@@ -133,7 +175,7 @@ func (f *StringFinder) next(sr *wizutil.SliceReader) int64 {
 				return -1
 			}
 
-			if byte(c) != f.pattern[j] {
+			if !f.matches(byte(c), f.pattern[j]) {
 				// mismatch, must skip
 				break
 			}
@@ -0,0 +1,11 @@
+package wizardry
+
+// CustomResult is what a custom kind reports after testing the target
+type CustomResult struct {
+	// Matched is true if the test succeeded
+	Matched bool
+	// Value is what the rule's description prints, if it has a format verb
+	Value interface{}
+	// Length is how many bytes the match spans, relative rules continue after it
+	Length int64
+}
@@ -0,0 +1,176 @@
+package wizardry
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/itchio/wizardry/wizardry/wizutil"
+)
+
+// derTagNames are the names magic files use for universal ASN.1 tags
+var derTagNames = []string{
+	"eoc", "bool", "int", "bit_str", "octet_str",
+	"null", "obj_id", "obj_desc", "ext", "real",
+	"enum", "embed", "utf8_str", "rel_oid", "time",
+	"res2", "seq", "set", "num_str", "prt_str",
+	"t61_str", "vid_str", "ia5_str", "utc_time", "gen_time",
+	"gr_str", "vis_str", "gen_str", "univ_str", "char_str",
+	"bmp_str", "date", "tod", "datetime", "duration",
+	"oid-iri", "rel-oid-iri",
+}
+
+// derStringTags are printed as text rather than hex
+var derStringTags = map[string]bool{
+	"utf8_str": true, "num_str": true, "prt_str": true, "t61_str": true,
+	"vid_str": true, "ia5_str": true, "utc_time": true, "gen_time": true,
+	"gr_str": true, "vis_str": true, "gen_str": true, "univ_str": true,
+	"char_str": true, "bmp_str": true,
+}
+
+// maxDERHeader is enough for a tag and a length of up to 4 bytes each
+const maxDERHeader = 12
+
+// DERTest checks the DER-encoded ASN.1 element at given index against a
+// test like "seq", "int1" (with a content length) or "obj_id9=2a864886f70d010701"
+// (with a value). A test of "x" matches any well-formed element.
+//
+// It returns the offset of the element's contents, relative to targetIndex,
+// or -1 if it didn't match - and its value, if the test had one.
+func DERTest(sr *wizutil.SliceReader, targetIndex int64, test string) (int64, string) {
+	if targetIndex < 0 {
+		return -1, ""
+	}
+
+	header := make([]byte, maxDERHeader)
+	n, _ := sr.ReadAt(header, targetIndex)
+	if int64(n) > sr.Size()-targetIndex {
+		n = int(sr.Size() - targetIndex)
+	}
+	if n <= 0 {
+		return -1, ""
+	}
+	header = header[:n]
+
+	tag, offs, ok := derTag(header)
+	if !ok {
+		return -1, ""
+	}
+	contentLen, offs, ok := derLength(header, offs)
+	if !ok {
+		return -1, ""
+	}
+
+	if test == "x" {
+		return offs, ""
+	}
+
+	name := derTagName(tag)
+	if !strings.HasPrefix(test, name) {
+		return -1, ""
+	}
+	test = test[len(name):]
+
+	wanted := ""
+	hasValue := false
+	if eq := strings.IndexByte(test, '='); eq >= 0 {
+		test, wanted, hasValue = test[:eq], test[eq+1:], true
+	}
+
+	if test != "" {
+		expectedLen, err := strconv.ParseInt(test, 10, 64)
+		if err != nil || expectedLen != contentLen {
+			return -1, ""
+		}
+	}
+
+	if !hasValue {
+		return offs, ""
+	}
+
+	// the length comes from the target, don't trust it with an allocation
+	if contentLen > sr.Size()-targetIndex-offs {
+		return -1, ""
+	}
+
+	content := make([]byte, contentLen)
+	n, _ = sr.ReadAt(content, targetIndex+offs)
+	if int64(n) < contentLen {
+		return -1, ""
+	}
+
+	value := derValue(name, content)
+	if wanted != "x" && wanted != value {
+		return -1, ""
+	}
+	return offs, value
+}
+
+// derTag decodes the tag number at the start of buf, ignoring its class
+// and whether it's constructed
+func derTag(buf []byte) (uint64, int64, bool) {
+	if len(buf) < 1 {
+		return 0, 0, false
+	}
+
+	tag := uint64(buf[0] & 0x1f)
+	offs := int64(1)
+	if tag != 0x1f {
+		return tag, offs, true
+	}
+
+	// high tag number form: 7 bits at a time, until the high bit is clear
+	tag = 0
+	for {
+		if offs >= int64(len(buf)) || offs > 5 {
+			return 0, 0, false
+		}
+		c := buf[offs]
+		offs++
+		tag = tag<<7 | uint64(c&0x7f)
+		if c&0x80 == 0 {
+			return tag, offs, true
+		}
+	}
+}
+
+// derLength decodes the length found at offs in buf
+func derLength(buf []byte, offs int64) (int64, int64, bool) {
+	if offs >= int64(len(buf)) {
+		return 0, 0, false
+	}
+
+	c := buf[offs]
+	offs++
+	if c&0x80 == 0 {
+		return int64(c), offs, true
+	}
+
+	// long form - indefinite lengths aren't allowed in DER
+	digits := int64(c & 0x7f)
+	if digits == 0 || digits > 4 || offs+digits > int64(len(buf)) {
+		return 0, 0, false
+	}
+
+	length := int64(0)
+	for i := int64(0); i < digits; i++ {
+		length = length<<8 | int64(buf[offs+i])
+	}
+	return length, offs + digits, true
+}
+
+func derTagName(tag uint64) string {
+	if tag < uint64(len(derTagNames)) {
+		return derTagNames[tag]
+	}
+	return fmt.Sprintf("%#x", tag)
+}
+
+// derValue returns the printable form of an element's contents:
+// text for string types, lowercase hex for everything else
+func derValue(name string, content []byte) string {
+	if derStringTags[name] {
+		return string(content)
+	}
+	return fmt.Sprintf("%x", content)
+}
@@ -0,0 +1,70 @@
+package exe
+
+// Arch is a processor architecture, named like GOARCH
+type Arch string
+
+const (
+	// ArchUnknown is used when the architecture isn't one of the below
+	ArchUnknown Arch = ""
+	// Arch386 is 32-bit x86
+	Arch386 Arch = "386"
+	// ArchAMD64 is 64-bit x86
+	ArchAMD64 Arch = "amd64"
+	// ArchARM is 32-bit ARM, including Thumb
+	ArchARM Arch = "arm"
+	// ArchARM64 is 64-bit ARM
+	ArchARM64 Arch = "arm64"
+	// ArchPPC is 32-bit PowerPC
+	ArchPPC Arch = "ppc"
+	// ArchPPC64 is 64-bit PowerPC
+	ArchPPC64 Arch = "ppc64"
+	// ArchMIPS is MIPS, of any width
+	ArchMIPS Arch = "mips"
+	// ArchSPARC is SPARC, of any width
+	ArchSPARC Arch = "sparc"
+	// ArchIA64 is Itanium
+	ArchIA64 Arch = "ia64"
+)
+
+// how the elf, mach and msdos magic files name architectures
+var archNames = map[string]Arch{
+	// elf
+	"Intel 80386":                  Arch386,
+	"Intel 80486":                  Arch386,
+	"x86-64":                       ArchAMD64,
+	"ARM":                          ArchARM,
+	"ARM aarch64":                  ArchARM64,
+	"PowerPC or cisco 4500":        ArchPPC,
+	"64-bit PowerPC or cisco 7500": ArchPPC64,
+	"MIPS":                         ArchMIPS,
+	"MIPS (deprecated)":            ArchMIPS,
+	"SPARC":                        ArchSPARC,
+	"SPARC32PLUS":                  ArchSPARC,
+	"SPARC V9":                     ArchSPARC,
+	"IA-64":                        ArchIA64,
+
+	// mach-o
+	"i386":   Arch386,
+	"x86_64": ArchAMD64,
+	"arm":    ArchARM,
+	"arm64":  ArchARM64,
+	"ppc":    ArchPPC,
+	"ppc64":  ArchPPC64,
+	"mips":   ArchMIPS,
+	"sparc":  ArchSPARC,
+
+	// pe
+	"ARM Thumb":     ArchARM,
+	"ARMv7 Thumb":   ArchARM,
+	"Aarch64":       ArchARM64,
+	"PowerPC":       ArchPPC,
+	"Intel Itanium": ArchIA64,
+	"MIPS R4000":    ArchMIPS,
+	"MIPS R10000":   ArchMIPS,
+}
+
+// ParseArch maps an architecture as described by the magic rules
+// (like "x86-64", "Intel 80386" or "x86_64") to an Arch
+func ParseArch(rawArch string) Arch {
+	return archNames[rawArch]
+}
@@ -0,0 +1,165 @@
+// Package exe identifies executables (ELF, Mach-O, PE and MS-DOS) using the
+// magic rules bundled with wizardry, and returns what was found as typed
+// information rather than prose.
+package exe
+
+//go:generate go run github.com/itchio/wizardry compile ../../Magdir --output internal/magic/magic.go --package magic
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/itchio/wizardry/wizardry"
+	"github.com/itchio/wizardry/wizardry/exe/internal/magic"
+	"github.com/itchio/wizardry/wizardry/wizutil"
+)
+
+// ErrNotExecutable is returned when the target isn't a known kind of executable
+var ErrNotExecutable = errors.New("not an executable")
+
+// Format is the container format of an executable
+type Format string
+
+const (
+	// FormatELF is used on Linux and most unices
+	FormatELF Format = "elf"
+	// FormatMachO is used on macOS, including universal (fat) binaries
+	FormatMachO Format = "mach-o"
+	// FormatPE is used by Windows executables and DLLs
+	FormatPE Format = "pe"
+	// FormatMSDOS is a plain MZ executable
+	FormatMSDOS Format = "msdos"
+	// FormatNE is a 16-bit Windows or OS/2 executable
+	FormatNE Format = "ne"
+	// FormatLX is a 32-bit OS/2 executable
+	FormatLX Format = "lx"
+)
+
+// Subsystem tells whether a PE executable opens a console
+type Subsystem string
+
+const (
+	// SubsystemUnknown is used for formats other than PE, and subsystems
+	// other than GUI and console
+	SubsystemUnknown Subsystem = ""
+	// SubsystemGUI executables don't open a console
+	SubsystemGUI Subsystem = "gui"
+	// SubsystemConsole executables open a console if they're not run from one
+	SubsystemConsole Subsystem = "console"
+)
+
+// ExecutableInfo describes an executable
+type ExecutableInfo struct {
+	Format Format
+	// Arch is the architecture the executable runs on, ArchUnknown for
+	// universal binaries and unrecognized architectures
+	Arch Arch
+	// RawArch is the architecture as described by the magic rules
+	RawArch string
+	// Bits is 32 or 64, 0 if unknown
+	Bits int
+	// ByteOrder is nil if unknown
+	ByteOrder binary.ByteOrder
+	// Universal is true for Mach-O fat binaries, see Slices
+	Universal bool
+	// Slices lists the architectures found in a universal binary
+	Slices []Slice
+	// Subsystem is only known for PE executables
+	Subsystem Subsystem
+	// Description is what file(1) would print
+	Description string
+}
+
+// Slice is one of the executables contained in a universal binary
+type Slice struct {
+	Arch    Arch
+	RawArch string
+}
+
+// Identify returns information about the executable in r, or ErrNotExecutable
+func Identify(r io.ReaderAt, size int64) (*ExecutableInfo, error) {
+	return IdentifyContext(context.Background(), r, size, wizardry.Limits{})
+}
+
+// IdentifyContext is like Identify, but gives up when ctx is done, or when
+// identification exceeds the given limits, see wizardry.Limits
+func IdentifyContext(ctx context.Context, r io.ReaderAt, size int64, limits wizardry.Limits) (*ExecutableInfo, error) {
+	result, err := magic.IdentifyContext(ctx, r, size, limits)
+	if err != nil {
+		return nil, err
+	}
+	return FromResult(result)
+}
+
+// IdentifyFile is like Identify, for the file at path
+func IdentifyFile(path string) (*ExecutableInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return Identify(f, stat.Size())
+}
+
+// FromResult builds executable information from the fields captured by
+// the bundled magic rules (format, arch, bits, endianness, subsystem and
+// slices), for callers that identify targets themselves.
+func FromResult(result wizardry.Result) (*ExecutableInfo, error) {
+	fields := result.Fields
+
+	info := &ExecutableInfo{
+		Format:      Format(fields.Get("format")),
+		Description: wizutil.MergeStrings(result.Strings),
+	}
+
+	switch info.Format {
+	case FormatELF, FormatMachO, FormatPE, FormatMSDOS, FormatNE, FormatLX:
+		// good
+	default:
+		return nil, ErrNotExecutable
+	}
+
+	if fields.Get("slices") != "" {
+		info.Universal = true
+		for _, rawArch := range fields["arch"] {
+			info.Slices = append(info.Slices, Slice{
+				Arch:    ParseArch(rawArch),
+				RawArch: rawArch,
+			})
+		}
+		return info, nil
+	}
+
+	info.RawArch = fields.Get("arch")
+	info.Arch = ParseArch(info.RawArch)
+
+	if bits, err := strconv.Atoi(fields.Get("bits")); err == nil {
+		info.Bits = bits
+	}
+
+	switch fields.Get("endianness") {
+	case "little":
+		info.ByteOrder = binary.LittleEndian
+	case "big":
+		info.ByteOrder = binary.BigEndian
+	}
+
+	switch fields.Get("subsystem") {
+	case "GUI":
+		info.Subsystem = SubsystemGUI
+	case "console":
+		info.Subsystem = SubsystemConsole
+	}
+
+	return info, nil
+}
@@ -0,0 +1,176 @@
+package exe
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func identifyBytes(t *testing.T, target []byte) *ExecutableInfo {
+	info, err := Identify(bytes.NewReader(target), int64(len(target)))
+	assert.NoError(t, err)
+	return info
+}
+
+func Test_ELF(t *testing.T) {
+	target := make([]byte, 64)
+	copy(target, "\x7fELF")
+	target[4] = 2                                  // 64-bit
+	target[5] = 1                                  // little-endian
+	target[6] = 1                                  // version
+	binary.LittleEndian.PutUint16(target[16:], 2)  // executable
+	binary.LittleEndian.PutUint16(target[18:], 62) // x86-64
+	binary.LittleEndian.PutUint32(target[20:], 1)
+
+	info := identifyBytes(t, target)
+	assert.EqualValues(t, FormatELF, info.Format)
+	assert.EqualValues(t, ArchAMD64, info.Arch)
+	assert.EqualValues(t, 64, info.Bits)
+	assert.EqualValues(t, binary.LittleEndian, info.ByteOrder)
+	assert.False(t, info.Universal)
+
+	target[4] = 1                               // 32-bit
+	target[5] = 2                               // big-endian
+	binary.BigEndian.PutUint16(target[18:], 20) // PowerPC
+
+	info = identifyBytes(t, target)
+	assert.EqualValues(t, ArchPPC, info.Arch)
+	assert.EqualValues(t, 32, info.Bits)
+	assert.EqualValues(t, binary.BigEndian, info.ByteOrder)
+}
+
+func makePE(machine uint16, optionalMagic uint16, subsystem uint16) []byte {
+	target := make([]byte, 512)
+	copy(target, "MZ")
+	binary.LittleEndian.PutUint16(target[0x18:], 0x40)
+	binary.LittleEndian.PutUint32(target[0x3c:], 0x80)
+
+	pe := target[0x80:]
+	copy(pe, "PE\x00\x00")
+	binary.LittleEndian.PutUint16(pe[4:], machine)
+	binary.LittleEndian.PutUint16(pe[24:], optionalMagic)
+	binary.LittleEndian.PutUint16(pe[92:], subsystem)
+	return target
+}
+
+func Test_PE(t *testing.T) {
+	info := identifyBytes(t, makePE(0x14c, 0x10b, 3))
+	assert.EqualValues(t, FormatPE, info.Format)
+	assert.EqualValues(t, Arch386, info.Arch)
+	assert.EqualValues(t, 32, info.Bits)
+	assert.EqualValues(t, SubsystemConsole, info.Subsystem)
+
+	info = identifyBytes(t, makePE(0xaa64, 0x20b, 2))
+	assert.EqualValues(t, ArchARM64, info.Arch)
+	assert.EqualValues(t, 64, info.Bits)
+	assert.EqualValues(t, SubsystemGUI, info.Subsystem)
+}
+
+func makeThinMachO(magic uint32, cpuType int32, fileType uint32) []byte {
+	target := make([]byte, 64)
+	binary.LittleEndian.PutUint32(target[0:], magic)
+	binary.LittleEndian.PutUint32(target[4:], uint32(cpuType))
+	binary.LittleEndian.PutUint32(target[12:], fileType)
+	return target
+}
+
+func Test_MachO(t *testing.T) {
+	info := identifyBytes(t, makeThinMachO(0xfeedfacf, 0x0100000c, 2))
+	assert.EqualValues(t, FormatMachO, info.Format)
+	assert.EqualValues(t, ArchARM64, info.Arch)
+	assert.EqualValues(t, 64, info.Bits)
+	assert.EqualValues(t, binary.LittleEndian, info.ByteOrder)
+
+	// universal binary with an i386 and an x86_64 slice
+	fat := make([]byte, 0x3000)
+	binary.BigEndian.PutUint32(fat[0:], 0xcafebabe)
+	binary.BigEndian.PutUint32(fat[4:], 2)
+	slices := []struct {
+		cpuType int32
+		offset  uint32
+		magic   uint32
+	}{
+		{7, 0x1000, 0xfeedface},
+		{0x01000007, 0x2000, 0xfeedfacf},
+	}
+	for i, slice := range slices {
+		entry := fat[8+20*i:]
+		binary.BigEndian.PutUint32(entry[0:], uint32(slice.cpuType))
+		binary.BigEndian.PutUint32(entry[4:], 3)
+		binary.BigEndian.PutUint32(entry[8:], slice.offset)
+		binary.BigEndian.PutUint32(entry[12:], 64)
+		copy(fat[slice.offset:], makeThinMachO(slice.magic, slice.cpuType, 2))
+	}
+
+	info = identifyBytes(t, fat)
+	assert.EqualValues(t, FormatMachO, info.Format)
+	assert.True(t, info.Universal)
+	assert.EqualValues(t, ArchUnknown, info.Arch)
+	assert.EqualValues(t, []Slice{
+		{Arch: Arch386, RawArch: "i386"},
+		{Arch: ArchAMD64, RawArch: "x86_64"},
+	}, info.Slices)
+}
+
+func Test_NotExecutable(t *testing.T) {
+	target := []byte("#!/bin/sh\necho hi\n")
+	_, err := Identify(bytes.NewReader(target), int64(len(target)))
+	assert.Equal(t, ErrNotExecutable, err)
+}
+
+func Test_Concurrent(t *testing.T) {
+	elf := make([]byte, 64)
+	copy(elf, "\x7fELF\x02\x01\x01")
+	binary.LittleEndian.PutUint16(elf[16:], 2)
+	binary.LittleEndian.PutUint16(elf[18:], 62)
+	targets := [][]byte{elf, makePE(0x14c, 0x10b, 3), makeThinMachO(0xfeedfacf, 0x0100000c, 2)}
+
+	var expected []*ExecutableInfo
+	for _, target := range targets {
+		expected = append(expected, identifyBytes(t, target))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				k := (i + j) % len(targets)
+				assert.EqualValues(t, expected[k], identifyBytes(t, targets[k]))
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func BenchmarkIdentify(b *testing.B) {
+	elf := make([]byte, 64)
+	copy(elf, "\x7fELF\x02\x01\x01")
+	binary.LittleEndian.PutUint16(elf[16:], 2)
+	binary.LittleEndian.PutUint16(elf[18:], 62)
+
+	targets := []struct {
+		name   string
+		target []byte
+	}{
+		{"elf", elf},
+		{"pe", makePE(0x14c, 0x10b, 3)},
+		{"mach-o", makeThinMachO(0xfeedfacf, 0x0100000c, 2)},
+		{"script", []byte("#!/bin/sh\necho hi\n")},
+		{"text", bytes.Repeat([]byte("all work and no play\n"), 32)},
+		{"binary", bytes.Repeat([]byte{0x13, 0x37, 0x00, 0xfe}, 128)},
+	}
+
+	for _, tt := range targets {
+		b.Run(tt.name, func(b *testing.B) {
+			r := bytes.NewReader(tt.target)
+			for i := 0; i < b.N; i++ {
+				Identify(r, int64(len(tt.target)))
+			}
+		})
+	}
+}
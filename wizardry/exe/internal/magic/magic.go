@@ -0,0 +1,12351 @@
+// this file has been generated by github.com/itchio/wizardry
+// from a set of magic rules. you probably don't want to edit it by hand
+
+package magic
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"github.com/itchio/wizardry/wizardry"
+	"github.com/itchio/wizardry/wizardry/wizutil"
+	"io"
+)
+
+// silence import errors, if we don't use string/search etc.
+var _ wizardry.StringTestFlags
+var _ fmt.State
+var l binary.ByteOrder = binary.LittleEndian
+var b binary.ByteOrder = binary.BigEndian
+var gt = wizardry.StringTestWith
+var ht = wizardry.SearchTestWith
+var gu = wizardry.String16Test
+var gv = wizardry.ReadString16
+var gs = wizardry.ReadString
+var gp = wizardry.ReadBytes
+var gg = wizardry.ReadGUID
+var gd = wizardry.DERTest
+var t = true
+var f = false
+
+// state is what a single identification keeps track of, so that
+// identifications may run concurrently
+type state struct {
+	gw *wizardry.Guard // enforces the limits of the identification
+	tb [8]byte         // integers are read into it
+	ic int             // indirection depth
+}
+
+const (
+	// Fingerprint identifies the rules this package was generated from,
+	// see wizparser.Spellbook.Fingerprint
+	Fingerprint = "13c6043b43ec32f0938834a8cfd7a0126b265516a3b92d6bde1b2355137d3acb"
+	// Chatty, EmitComments, MIME and Linear are the options this package
+	// was generated with, see wizcompiler.CompileOptions
+	Chatty       = false
+	EmitComments = false
+	MIME         = false
+	Linear       = false
+)
+
+// Identify follows the rules to find out the type of a target of the
+// given size, falling back to text classification if nothing matched.
+// The result is empty if "use" rules nest too deeply, see IdentifyContext.
+// It may be called from several goroutines at once.
+func Identify(r io.ReaderAt, size int64) wizardry.Result {
+	res, _ := IdentifyContext(context.Background(), r, size, wizardry.Limits{})
+	return res
+}
+
+// IdentifyContext is like Identify, but gives up when ctx is done, or
+// when identification exceeds the given limits. The error is then
+// either ctx's error, or a *wizardry.LimitError.
+func IdentifyContext(ctx context.Context, r io.ReaderAt, size int64, lm wizardry.Limits) (wizardry.Result, error) {
+	st := &state{gw: wizardry.NewGuard(ctx, r, lm)}
+	sr := wizutil.NewSliceReader(st.gw, 0, size)
+	fd := make(wizardry.Fields)
+	out := identify__Root(st, sr, 0, fd)
+	if len(out) == 0 {
+		out = append(out, st.gw.ClassifyText(sr).String())
+	}
+	// reads fail silently once the guard has failed
+	if err := st.gw.Err(); err != nil {
+		return wizardry.Result{}, err
+	}
+	return wizardry.Result{Strings: out, Fields: fd}, nil
+}
+
+// Pages lists the named pages of rules compiled in this package
+func Pages() []string {
+	return []string{
+		"cur-entry",
+		"cur-ico-dir",
+		"cur-ico-entry",
+		"elf-le",
+		"ico-entry",
+		"lotus-cells",
+		"mach-o",
+		"mach-o-be",
+		"mach-o-cpu",
+		"msdos-com",
+		"msdos-driver",
+	}
+}
+
+// classifies the target as text or binary, at most once per page
+func ix(st *state, r *wizutil.SliceReader, tx **wizardry.TextInfo) bool {
+	if *tx == nil {
+		*tx = st.gw.ClassifyText(r)
+	}
+	return (*tx).IsText()
+}
+
+// reads an unsigned 8-bit little-endian integer
+func f1l(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	n, err := r.ReadAt(st.tb[:1], int64(off))
+	if n < 1 || err != nil {
+		return 0, f
+	}
+	return uint64(st.tb[0]), t
+}
+
+// reads an unsigned 8-bit big-endian integer
+func f1b(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	n, err := r.ReadAt(st.tb[:1], int64(off))
+	if n < 1 || err != nil {
+		return 0, f
+	}
+	return uint64(st.tb[0]), t
+}
+
+// reads an unsigned 16-bit little-endian integer
+func f2l(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	n, err := r.ReadAt(st.tb[:2], int64(off))
+	if n < 2 || err != nil {
+		return 0, f
+	}
+	return uint64(l.Uint16(st.tb[:])), t
+}
+
+// reads an unsigned 16-bit big-endian integer
+func f2b(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	n, err := r.ReadAt(st.tb[:2], int64(off))
+	if n < 2 || err != nil {
+		return 0, f
+	}
+	return uint64(b.Uint16(st.tb[:])), t
+}
+
+// reads an unsigned 32-bit little-endian integer
+func f4l(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	n, err := r.ReadAt(st.tb[:4], int64(off))
+	if n < 4 || err != nil {
+		return 0, f
+	}
+	return uint64(l.Uint32(st.tb[:])), t
+}
+
+// reads an unsigned 32-bit big-endian integer
+func f4b(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	n, err := r.ReadAt(st.tb[:4], int64(off))
+	if n < 4 || err != nil {
+		return 0, f
+	}
+	return uint64(b.Uint32(st.tb[:])), t
+}
+
+// reads an unsigned 64-bit little-endian integer
+func f8l(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	n, err := r.ReadAt(st.tb[:8], int64(off))
+	if n < 8 || err != nil {
+		return 0, f
+	}
+	return uint64(l.Uint64(st.tb[:])), t
+}
+
+// reads an unsigned 64-bit big-endian integer
+func f8b(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	n, err := r.ReadAt(st.tb[:8], int64(off))
+	if n < 8 || err != nil {
+		return 0, f
+	}
+	return uint64(b.Uint64(st.tb[:])), t
+}
+
+// reads an unsigned 8-bit little-endian integer, from the header window if it holds it
+func w1l(st *state, r *wizutil.SliceReader, hw []byte, po int64, off int64) (uint64, bool) {
+	i := off - po
+	if i < 0 || i+1 > int64(len(hw)) {
+		return f1l(st, r, off)
+	}
+	return uint64(hw[i]), t
+}
+
+// reads an unsigned 8-bit big-endian integer, from the header window if it holds it
+func w1b(st *state, r *wizutil.SliceReader, hw []byte, po int64, off int64) (uint64, bool) {
+	i := off - po
+	if i < 0 || i+1 > int64(len(hw)) {
+		return f1b(st, r, off)
+	}
+	return uint64(hw[i]), t
+}
+
+// reads an unsigned 16-bit little-endian integer, from the header window if it holds it
+func w2l(st *state, r *wizutil.SliceReader, hw []byte, po int64, off int64) (uint64, bool) {
+	i := off - po
+	if i < 0 || i+2 > int64(len(hw)) {
+		return f2l(st, r, off)
+	}
+	return uint64(l.Uint16(hw[i:])), t
+}
+
+// reads an unsigned 16-bit big-endian integer, from the header window if it holds it
+func w2b(st *state, r *wizutil.SliceReader, hw []byte, po int64, off int64) (uint64, bool) {
+	i := off - po
+	if i < 0 || i+2 > int64(len(hw)) {
+		return f2b(st, r, off)
+	}
+	return uint64(b.Uint16(hw[i:])), t
+}
+
+// reads an unsigned 32-bit little-endian integer, from the header window if it holds it
+func w4l(st *state, r *wizutil.SliceReader, hw []byte, po int64, off int64) (uint64, bool) {
+	i := off - po
+	if i < 0 || i+4 > int64(len(hw)) {
+		return f4l(st, r, off)
+	}
+	return uint64(l.Uint32(hw[i:])), t
+}
+
+// reads an unsigned 32-bit big-endian integer, from the header window if it holds it
+func w4b(st *state, r *wizutil.SliceReader, hw []byte, po int64, off int64) (uint64, bool) {
+	i := off - po
+	if i < 0 || i+4 > int64(len(hw)) {
+		return f4b(st, r, off)
+	}
+	return uint64(b.Uint32(hw[i:])), t
+}
+
+// reads an unsigned 64-bit little-endian integer, from the header window if it holds it
+func w8l(st *state, r *wizutil.SliceReader, hw []byte, po int64, off int64) (uint64, bool) {
+	i := off - po
+	if i < 0 || i+8 > int64(len(hw)) {
+		return f8l(st, r, off)
+	}
+	return uint64(l.Uint64(hw[i:])), t
+}
+
+// reads an unsigned 64-bit big-endian integer, from the header window if it holds it
+func w8b(st *state, r *wizutil.SliceReader, hw []byte, po int64, off int64) (uint64, bool) {
+	i := off - po
+	if i < 0 || i+8 > int64(len(hw)) {
+		return f8b(st, r, off)
+	}
+	return uint64(b.Uint64(hw[i:])), t
+}
+
+// reads a 32-bit little-endian ID3 synchsafe integer
+func f4il(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	v, k := f4l(st, r, off)
+	return wizardry.DecodeID3(v), k
+}
+
+// reads a 32-bit big-endian ID3 synchsafe integer
+func f4ib(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	v, k := f4b(st, r, off)
+	return wizardry.DecodeID3(v), k
+}
+
+// reads a 32-bit middle-endian integer
+func f4m(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	n, err := r.ReadAt(st.tb[:4], int64(off))
+	if n < 4 || err != nil {
+		return 0, f
+	}
+	return uint64(wizardry.MiddleEndianUint32(st.tb[:])), t
+}
+
+func identify__Root(st *state, r *wizutil.SliceReader, po int64, fd wizardry.Fields) []string {
+	var out []string
+	var ss []string
+	ss = ss[0:]
+	var gf [32]int64
+	gf[0] = po
+	var ra uint64
+	ra &= ra
+	var rb uint64
+	rb &= rb
+	var rc uint64
+	rc &= rc
+	var rA int64
+	rA &= rA
+	var rB int64
+	rB &= rB
+	var sv string
+	sv += ""
+	var sb []byte
+	sb = sb[0:]
+	var sm [32]uint64
+	sm[0] &= sm[0]
+	var k bool
+	k = !!k
+	var l bool
+	l = !!l
+	var m bool
+	m = !!m
+	var d = make([]bool, 32)
+	d[0] = !!d[0]
+	var tx *wizardry.TextInfo
+	var hw = gp(r, po, 139)
+	var hb uint64
+	hb, _ = w1l(st, r, hw, po, po)
+
+	a := func(args ...string) {
+		out = append(out, args...)
+	}
+	switch hb {
+	case 0x23:
+		sb = gp(r, po, 22)
+		sm[0] = 0
+		if len(sb) >= 2 && string(sb[0:2]) == "#!" {
+			if len(sb) > 2 {
+				switch sb[2] {
+				case 32:
+					if len(sb) > 3 && sb[3] == 47 {
+						if len(sb) > 4 {
+							switch sb[4] {
+							case 98:
+								if len(sb) >= 8 && string(sb[5:8]) == "in/" {
+									if len(sb) > 8 {
+										switch sb[8] {
+										case 97:
+											if len(sb) >= 11 && string(sb[9:11]) == "wk" {
+												sm[0] |= 0x100000
+											}
+										case 98:
+											if len(sb) >= 12 && string(sb[9:12]) == "ash" {
+												sm[0] |= 0x1800000
+											}
+										case 99:
+											if len(sb) >= 11 && string(sb[9:11]) == "sh" {
+												sm[0] |= 0x4
+											}
+										case 103:
+											if len(sb) >= 12 && string(sb[9:12]) == "awk" {
+												sm[0] |= 0x20000
+											}
+										case 107:
+											if len(sb) >= 11 && string(sb[9:11]) == "sh" {
+												sm[0] |= 0x18
+											}
+										case 110:
+											if len(sb) >= 12 && string(sb[9:12]) == "awk" {
+												sm[0] |= 0x4000
+											}
+										case 114:
+											if len(sb) > 9 && sb[9] == 99 {
+												sm[0] |= 0x400000
+											}
+										case 115:
+											if len(sb) > 9 && sb[9] == 104 {
+												sm[0] |= 0x3
+											}
+										case 116:
+											if len(sb) >= 12 && string(sb[9:12]) == "csh" {
+												sm[0] |= 0x20
+											}
+										case 122:
+											if len(sb) >= 11 && string(sb[9:11]) == "sh" {
+												sm[0] |= 0x200
+											}
+										}
+									}
+								}
+							case 117:
+								if len(sb) >= 8 && string(sb[5:8]) == "sr/" {
+									if len(sb) > 8 {
+										switch sb[8] {
+										case 98:
+											if len(sb) >= 12 && string(sb[9:12]) == "in/" {
+												if len(sb) > 12 {
+													switch sb[12] {
+													case 97:
+														if len(sb) >= 15 && string(sb[13:15]) == "wk" {
+															sm[0] |= 0x200000
+														}
+													case 98:
+														if len(sb) >= 16 && string(sb[13:16]) == "ash" {
+															sm[0] |= 0x6000000
+														}
+													case 103:
+														if len(sb) >= 16 && string(sb[13:16]) == "awk" {
+															sm[0] |= 0x40000
+														}
+													case 110:
+														if len(sb) >= 16 && string(sb[13:16]) == "awk" {
+															sm[0] |= 0x8000
+														}
+													case 116:
+														if len(sb) >= 16 && string(sb[13:16]) == "csh" {
+															sm[0] |= 0x40
+														}
+													case 122:
+														if len(sb) >= 15 && string(sb[13:15]) == "sh" {
+															sm[0] |= 0x400
+														}
+													}
+												}
+											}
+										case 108:
+											if len(sb) >= 14 && string(sb[9:14]) == "ocal/" {
+												if len(sb) > 14 {
+													switch sb[14] {
+													case 98:
+														if len(sb) > 15 {
+															switch sb[15] {
+															case 97:
+																if len(sb) >= 18 && string(sb[16:18]) == "sh" {
+																	sm[0] |= 0x18000000
+																}
+															case 105:
+																if len(sb) >= 18 && string(sb[16:18]) == "n/" {
+																	if len(sb) > 18 {
+																		switch sb[18] {
+																		case 97:
+																			if len(sb) > 19 {
+																				switch sb[19] {
+																				case 101:
+																					sm[0] |= 0x2000
+																				case 115:
+																					if len(sb) > 20 && sb[20] == 104 {
+																						sm[0] |= 0x1000
+																					}
+																				}
+																			}
+																		case 98:
+																			if len(sb) >= 22 && string(sb[19:22]) == "ash" {
+																				sm[0] |= 0x60000000
+																			}
+																		case 103:
+																			if len(sb) >= 22 && string(sb[19:22]) == "awk" {
+																				sm[0] |= 0x80000
+																			}
+																		case 110:
+																			if len(sb) >= 22 && string(sb[19:22]) == "awk" {
+																				sm[0] |= 0x10000
+																			}
+																		case 116:
+																			if len(sb) >= 22 && string(sb[19:22]) == "csh" {
+																				sm[0] |= 0x100
+																			}
+																		case 122:
+																			if len(sb) >= 21 && string(sb[19:21]) == "sh" {
+																				sm[0] |= 0x800
+																			}
+																		}
+																	}
+																}
+															}
+														}
+													case 116:
+														if len(sb) >= 18 && string(sb[15:18]) == "csh" {
+															sm[0] |= 0x80
+														}
+													}
+												}
+											}
+										}
+									}
+								}
+							}
+						}
+					}
+				case 47:
+					if len(sb) > 3 {
+						switch sb[3] {
+						case 98:
+							if len(sb) >= 7 && string(sb[4:7]) == "in/" {
+								if len(sb) > 7 {
+									switch sb[7] {
+									case 97:
+										if len(sb) >= 10 && string(sb[8:10]) == "wk" {
+											sm[0] |= 0x100000
+										}
+									case 98:
+										if len(sb) >= 11 && string(sb[8:11]) == "ash" {
+											sm[0] |= 0x1800000
+										}
+									case 99:
+										if len(sb) >= 10 && string(sb[8:10]) == "sh" {
+											sm[0] |= 0x4
+										}
+									case 103:
+										if len(sb) >= 11 && string(sb[8:11]) == "awk" {
+											sm[0] |= 0x20000
+										}
+									case 107:
+										if len(sb) >= 10 && string(sb[8:10]) == "sh" {
+											sm[0] |= 0x18
+										}
+									case 110:
+										if len(sb) >= 11 && string(sb[8:11]) == "awk" {
+											sm[0] |= 0x4000
+										}
+									case 114:
+										if len(sb) > 8 && sb[8] == 99 {
+											sm[0] |= 0x400000
+										}
+									case 115:
+										if len(sb) > 8 && sb[8] == 104 {
+											sm[0] |= 0x3
+										}
+									case 116:
+										if len(sb) >= 11 && string(sb[8:11]) == "csh" {
+											sm[0] |= 0x20
+										}
+									case 122:
+										if len(sb) >= 10 && string(sb[8:10]) == "sh" {
+											sm[0] |= 0x200
+										}
+									}
+								}
+							}
+						case 117:
+							if len(sb) >= 7 && string(sb[4:7]) == "sr/" {
+								if len(sb) > 7 {
+									switch sb[7] {
+									case 98:
+										if len(sb) >= 11 && string(sb[8:11]) == "in/" {
+											if len(sb) > 11 {
+												switch sb[11] {
+												case 97:
+													if len(sb) >= 14 && string(sb[12:14]) == "wk" {
+														sm[0] |= 0x200000
+													}
+												case 98:
+													if len(sb) >= 15 && string(sb[12:15]) == "ash" {
+														sm[0] |= 0x6000000
+													}
+												case 103:
+													if len(sb) >= 15 && string(sb[12:15]) == "awk" {
+														sm[0] |= 0x40000
+													}
+												case 110:
+													if len(sb) >= 15 && string(sb[12:15]) == "awk" {
+														sm[0] |= 0x8000
+													}
+												case 116:
+													if len(sb) >= 15 && string(sb[12:15]) == "csh" {
+														sm[0] |= 0x40
+													}
+												case 122:
+													if len(sb) >= 14 && string(sb[12:14]) == "sh" {
+														sm[0] |= 0x400
+													}
+												}
+											}
+										}
+									case 108:
+										if len(sb) >= 13 && string(sb[8:13]) == "ocal/" {
+											if len(sb) > 13 {
+												switch sb[13] {
+												case 98:
+													if len(sb) > 14 {
+														switch sb[14] {
+														case 97:
+															if len(sb) >= 17 && string(sb[15:17]) == "sh" {
+																sm[0] |= 0x18000000
+															}
+														case 105:
+															if len(sb) >= 17 && string(sb[15:17]) == "n/" {
+																if len(sb) > 17 {
+																	switch sb[17] {
+																	case 97:
+																		if len(sb) > 18 {
+																			switch sb[18] {
+																			case 101:
+																				sm[0] |= 0x2000
+																			case 115:
+																				if len(sb) > 19 && sb[19] == 104 {
+																					sm[0] |= 0x1000
+																				}
+																			}
+																		}
+																	case 98:
+																		if len(sb) >= 21 && string(sb[18:21]) == "ash" {
+																			sm[0] |= 0x60000000
+																		}
+																	case 103:
+																		if len(sb) >= 21 && string(sb[18:21]) == "awk" {
+																			sm[0] |= 0x80000
+																		}
+																	case 110:
+																		if len(sb) >= 21 && string(sb[18:21]) == "awk" {
+																			sm[0] |= 0x10000
+																		}
+																	case 116:
+																		if len(sb) >= 21 && string(sb[18:21]) == "csh" {
+																			sm[0] |= 0x100
+																		}
+																	case 122:
+																		if len(sb) >= 20 && string(sb[18:20]) == "sh" {
+																			sm[0] |= 0x800
+																		}
+																	}
+																}
+															}
+														}
+													}
+												case 116:
+													if len(sb) >= 17 && string(sb[14:17]) == "csh" {
+														sm[0] |= 0x80
+													}
+												}
+											}
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+		if sm[0]&0x1 == 0 {
+			goto f1a
+		}
+		if !ix(st, r, &tx) {
+			goto f1a
+		}
+		rA = gt(r, po, "#! /bin/sh", 18, 0)
+		if rA < 0 {
+			goto f1a
+		}
+		a("POSIX shell script text executable")
+	f1a:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x2 == 0 {
+			goto f1b
+		}
+		if ix(st, r, &tx) {
+			goto f1b
+		}
+		rA = gt(r, po, "#! /bin/sh", 34, 0)
+		if rA < 0 {
+			goto f1b
+		}
+		a("POSIX shell script executable (binary data)")
+	f1b:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x4 == 0 {
+			goto f1c
+		}
+		if !ix(st, r, &tx) {
+			goto f1c
+		}
+		rA = gt(r, po, "#! /bin/csh", 18, 0)
+		if rA < 0 {
+			goto f1c
+		}
+		a("C shell script text executable")
+	f1c:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x8 == 0 {
+			goto f1d
+		}
+		if !ix(st, r, &tx) {
+			goto f1d
+		}
+		rA = gt(r, po, "#! /bin/ksh", 18, 0)
+		if rA < 0 {
+			goto f1d
+		}
+		a("Korn shell script text executable")
+	f1d:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x10 == 0 {
+			goto f1e
+		}
+		if ix(st, r, &tx) {
+			goto f1e
+		}
+		rA = gt(r, po, "#! /bin/ksh", 34, 0)
+		if rA < 0 {
+			goto f1e
+		}
+		a("Korn shell script executable (binary data)")
+	f1e:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x20 == 0 {
+			goto f1f
+		}
+		if !ix(st, r, &tx) {
+			goto f1f
+		}
+		rA = gt(r, po, "#! /bin/tcsh", 18, 0)
+		if rA < 0 {
+			goto f1f
+		}
+		a("Tenex C shell script text executable")
+	f1f:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x40 == 0 {
+			goto f20
+		}
+		if !ix(st, r, &tx) {
+			goto f20
+		}
+		rA = gt(r, po, "#! /usr/bin/tcsh", 18, 0)
+		if rA < 0 {
+			goto f20
+		}
+		a("Tenex C shell script text executable")
+	f20:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x80 == 0 {
+			goto f21
+		}
+		if !ix(st, r, &tx) {
+			goto f21
+		}
+		rA = gt(r, po, "#! /usr/local/tcsh", 18, 0)
+		if rA < 0 {
+			goto f21
+		}
+		a("Tenex C shell script text executable")
+	f21:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x100 == 0 {
+			goto f22
+		}
+		if !ix(st, r, &tx) {
+			goto f22
+		}
+		rA = gt(r, po, "#! /usr/local/bin/tcsh", 18, 0)
+		if rA < 0 {
+			goto f22
+		}
+		a("Tenex C shell script text executable")
+	f22:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x200 == 0 {
+			goto f23
+		}
+		if !ix(st, r, &tx) {
+			goto f23
+		}
+		rA = gt(r, po, "#! /bin/zsh", 18, 0)
+		if rA < 0 {
+			goto f23
+		}
+		a("Paul Falstad's zsh script text executable")
+	f23:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x400 == 0 {
+			goto f24
+		}
+		if !ix(st, r, &tx) {
+			goto f24
+		}
+		rA = gt(r, po, "#! /usr/bin/zsh", 18, 0)
+		if rA < 0 {
+			goto f24
+		}
+		a("Paul Falstad's zsh script text executable")
+	f24:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x800 == 0 {
+			goto f25
+		}
+		if !ix(st, r, &tx) {
+			goto f25
+		}
+		rA = gt(r, po, "#! /usr/local/bin/zsh", 18, 0)
+		if rA < 0 {
+			goto f25
+		}
+		a("Paul Falstad's zsh script text executable")
+	f25:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x1000 == 0 {
+			goto f26
+		}
+		if !ix(st, r, &tx) {
+			goto f26
+		}
+		rA = gt(r, po, "#! /usr/local/bin/ash", 18, 0)
+		if rA < 0 {
+			goto f26
+		}
+		a("Neil Brown's ash script text executable")
+	f26:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x2000 == 0 {
+			goto f27
+		}
+		if !ix(st, r, &tx) {
+			goto f27
+		}
+		rA = gt(r, po, "#! /usr/local/bin/ae", 18, 0)
+		if rA < 0 {
+			goto f27
+		}
+		a("Neil Brown's ae script text executable")
+	f27:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x4000 == 0 {
+			goto f28
+		}
+		if !ix(st, r, &tx) {
+			goto f28
+		}
+		rA = gt(r, po, "#! /bin/nawk", 18, 0)
+		if rA < 0 {
+			goto f28
+		}
+		a("new awk script text executable")
+	f28:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x8000 == 0 {
+			goto f29
+		}
+		if !ix(st, r, &tx) {
+			goto f29
+		}
+		rA = gt(r, po, "#! /usr/bin/nawk", 18, 0)
+		if rA < 0 {
+			goto f29
+		}
+		a("new awk script text executable")
+	f29:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x10000 == 0 {
+			goto f2a
+		}
+		if !ix(st, r, &tx) {
+			goto f2a
+		}
+		rA = gt(r, po, "#! /usr/local/bin/nawk", 18, 0)
+		if rA < 0 {
+			goto f2a
+		}
+		a("new awk script text executable")
+	f2a:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x20000 == 0 {
+			goto f2b
+		}
+		if !ix(st, r, &tx) {
+			goto f2b
+		}
+		rA = gt(r, po, "#! /bin/gawk", 18, 0)
+		if rA < 0 {
+			goto f2b
+		}
+		a("GNU awk script text executable")
+	f2b:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x40000 == 0 {
+			goto f2c
+		}
+		if !ix(st, r, &tx) {
+			goto f2c
+		}
+		rA = gt(r, po, "#! /usr/bin/gawk", 18, 0)
+		if rA < 0 {
+			goto f2c
+		}
+		a("GNU awk script text executable")
+	f2c:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x80000 == 0 {
+			goto f2d
+		}
+		if !ix(st, r, &tx) {
+			goto f2d
+		}
+		rA = gt(r, po, "#! /usr/local/bin/gawk", 18, 0)
+		if rA < 0 {
+			goto f2d
+		}
+		a("GNU awk script text executable")
+	f2d:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x100000 == 0 {
+			goto f2e
+		}
+		if !ix(st, r, &tx) {
+			goto f2e
+		}
+		rA = gt(r, po, "#! /bin/awk", 18, 0)
+		if rA < 0 {
+			goto f2e
+		}
+		a("awk script text executable")
+	f2e:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x200000 == 0 {
+			goto f2f
+		}
+		if !ix(st, r, &tx) {
+			goto f2f
+		}
+		rA = gt(r, po, "#! /usr/bin/awk", 18, 0)
+		if rA < 0 {
+			goto f2f
+		}
+		a("awk script text executable")
+	f2f:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x400000 == 0 {
+			goto f30
+		}
+		if !ix(st, r, &tx) {
+			goto f30
+		}
+		rA = gt(r, po, "#! /bin/rc", 18, 0)
+		if rA < 0 {
+			goto f30
+		}
+		a("Plan 9 rc shell script text executable")
+	f30:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x800000 == 0 {
+			goto f31
+		}
+		if !ix(st, r, &tx) {
+			goto f31
+		}
+		rA = gt(r, po, "#! /bin/bash", 18, 0)
+		if rA < 0 {
+			goto f31
+		}
+		a("Bourne-Again shell script text executable")
+	f31:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x1000000 == 0 {
+			goto f32
+		}
+		if ix(st, r, &tx) {
+			goto f32
+		}
+		rA = gt(r, po, "#! /bin/bash", 34, 0)
+		if rA < 0 {
+			goto f32
+		}
+		a("Bourne-Again shell script executable (binary data)")
+	f32:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x2000000 == 0 {
+			goto f33
+		}
+		if !ix(st, r, &tx) {
+			goto f33
+		}
+		rA = gt(r, po, "#! /usr/bin/bash", 18, 0)
+		if rA < 0 {
+			goto f33
+		}
+		a("Bourne-Again shell script text executable")
+	f33:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x4000000 == 0 {
+			goto f34
+		}
+		if ix(st, r, &tx) {
+			goto f34
+		}
+		rA = gt(r, po, "#! /usr/bin/bash", 34, 0)
+		if rA < 0 {
+			goto f34
+		}
+		a("Bourne-Again shell script executable (binary data)")
+	f34:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x8000000 == 0 {
+			goto f35
+		}
+		if !ix(st, r, &tx) {
+			goto f35
+		}
+		rA = gt(r, po, "#! /usr/local/bash", 18, 0)
+		if rA < 0 {
+			goto f35
+		}
+		a("Bourne-Again shell script text executable")
+	f35:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x10000000 == 0 {
+			goto f36
+		}
+		if ix(st, r, &tx) {
+			goto f36
+		}
+		rA = gt(r, po, "#! /usr/local/bash", 34, 0)
+		if rA < 0 {
+			goto f36
+		}
+		a("Bourne-Again shell script executable (binary data)")
+	f36:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x20000000 == 0 {
+			goto f37
+		}
+		if !ix(st, r, &tx) {
+			goto f37
+		}
+		rA = gt(r, po, "#! /usr/local/bin/bash", 18, 0)
+		if rA < 0 {
+			goto f37
+		}
+		a("Bourne-Again shell script text executable")
+	f37:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x40000000 == 0 {
+			goto f38
+		}
+		if ix(st, r, &tx) {
+			goto f38
+		}
+		rA = gt(r, po, "#! /usr/local/bin/bash", 34, 0)
+		if rA < 0 {
+			goto f38
+		}
+		a("Bourne-Again shell script executable (binary data)")
+	f38:
+		if len(out) > 0 {
+			return out
+		}
+	case 0xca:
+		rc, m = w4b(st, r, hw, po, po)
+		if !(m && rc == 3405691582) {
+			goto f0
+		}
+		rc, m = w4b(st, r, hw, po, po+4)
+		if !(m && int64(int32(rc)) > 30) {
+			goto f1
+		}
+		a("compiled Java class data,")
+		rc, m = w2b(st, r, hw, po, po+6)
+		if !m {
+			goto f2
+		}
+		a(wizardry.FormatDescription("version %d.", int64(int16(rc))))
+	f2:
+		rc, m = w2b(st, r, hw, po, po+4)
+		if !m {
+			goto f3
+		}
+		a(wizardry.FormatDescription("\\b%d", int64(int16(rc))))
+	f3:
+		rc, m = w4b(st, r, hw, po, po+4)
+		switch rc {
+		case 46:
+			a("(Java 1.2)")
+		case 47:
+			a("(Java 1.3)")
+		case 48:
+			a("(Java 1.4)")
+		case 49:
+			a("(Java 1.5)")
+		case 50:
+			a("(Java 1.6)")
+		default:
+			{
+				goto f4
+			}
+		}
+	f4:
+	f1:
+	f0:
+		if len(out) > 0 {
+			return out
+		}
+		rc, m = w4b(st, r, hw, po, po)
+		if !(m && rc == 3405697037) {
+			goto f9
+		}
+		a("JAR compressed with pack200,")
+		rc, m = w1l(st, r, hw, po, po+5)
+		if !m {
+			goto fa
+		}
+		a(wizardry.FormatDescription("version %d.", int64(int8(rc))))
+	fa:
+		rc, m = w1l(st, r, hw, po, po+4)
+		if !m {
+			goto fb
+		}
+		a(wizardry.FormatDescription("\\b%d", int64(int8(rc))))
+	fb:
+	f9:
+		if len(out) > 0 {
+			return out
+		}
+		rc, m = w4b(st, r, hw, po, po)
+		if !(m && rc == 3405697037) {
+			goto fc
+		}
+		a("JAR compressed with pack200,")
+		rc, m = w1l(st, r, hw, po, po+5)
+		if !m {
+			goto fd
+		}
+		a(wizardry.FormatDescription("version %d.", int64(int8(rc))))
+	fd:
+		rc, m = w1l(st, r, hw, po, po+4)
+		if !m {
+			goto fe
+		}
+		a(wizardry.FormatDescription("\\b%d", int64(int8(rc))))
+	fe:
+	fc:
+		if len(out) > 0 {
+			return out
+		}
+		rc, m = w4b(st, r, hw, po, po)
+		if !(m && rc == 3405691582) {
+			goto ff
+		}
+		rc, m = w4b(st, r, hw, po, po+4)
+		if !(m && rc == 1) {
+			goto f10
+		}
+		a("Mach-O universal binary with 1 architecture:")
+		fd.Add("format", "mach-o")
+		fd.Add("slices", "1")
+		if !st.gw.EnterUse() {
+			goto f11
+		}
+		ss = identifyMachO(st, r, po+8, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f11
+		}
+		a(ss...)
+		a("\\b")
+	f11:
+	f10:
+		if !(m && int64(int32(rc)) > 1) {
+			goto f12
+		}
+		if !(m && int64(int32(rc)) < 20) {
+			goto f13
+		}
+		a(wizardry.FormatDescription("Mach-O universal binary with %ld architectures:", int64(int32(rc))))
+		fd.Add("format", "mach-o")
+		fd.Add("slices", wizardry.FormatDescription("%ld", int64(int32(rc))))
+		if !st.gw.EnterUse() {
+			goto f14
+		}
+		ss = identifyMachO(st, r, po+8, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f14
+		}
+		a(ss...)
+		a("\\b")
+	f14:
+		if !st.gw.EnterUse() {
+			goto f15
+		}
+		ss = identifyMachO(st, r, po+28, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f15
+		}
+		a(ss...)
+		a("\\b")
+	f15:
+	f13:
+		if !(m && int64(int32(rc)) > 2) {
+			goto f16
+		}
+		if !st.gw.EnterUse() {
+			goto f17
+		}
+		ss = identifyMachO(st, r, po+48, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f17
+		}
+		a(ss...)
+		a("\\b")
+	f17:
+	f16:
+		if !(m && int64(int32(rc)) > 3) {
+			goto f18
+		}
+		if !st.gw.EnterUse() {
+			goto f19
+		}
+		ss = identifyMachO(st, r, po+68, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f19
+		}
+		a(ss...)
+		a("\\b")
+	f19:
+	f18:
+	f12:
+	ff:
+		if len(out) > 0 {
+			return out
+		}
+	}
+	rA, rB = ht(r, po, 1, "=<?php", 4)
+	if rA < 0 {
+		goto f39
+	}
+	a("PHP script text")
+f39:
+	if len(out) > 0 {
+		return out
+	}
+	rA, rB = ht(r, po, 1, "=<?\n", 0)
+	if rA < 0 {
+		goto f3a
+	}
+	a("PHP script text")
+f3a:
+	if len(out) > 0 {
+		return out
+	}
+	rA, rB = ht(r, po, 1, "=<?\r", 0)
+	if rA < 0 {
+		goto f3b
+	}
+	a("PHP script text")
+f3b:
+	if len(out) > 0 {
+		return out
+	}
+	rA, rB = ht(r, po, 1, "#! /usr/local/bin/php", 2)
+	if rA < 0 {
+		goto f3c
+	}
+	a("PHP script text executable")
+f3c:
+	if len(out) > 0 {
+		return out
+	}
+	rA, rB = ht(r, po, 1, "#! /usr/bin/php", 2)
+	if rA < 0 {
+		goto f3d
+	}
+	a("PHP script text executable")
+f3d:
+	if len(out) > 0 {
+		return out
+	}
+	switch hb {
+	case 0x23:
+		rA = gt(r, po, "#!/usr/bin/pdmenu", 0, 0)
+		if rA < 0 {
+			goto f41
+		}
+		a("Pdmenu configuration file text")
+	f41:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x24:
+		if !ix(st, r, &tx) {
+			goto f40
+		}
+		rA = gt(r, po, "$!", 16, 0)
+		if rA < 0 {
+			goto f40
+		}
+		a("DCL command file")
+	f40:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x3c:
+		rA = gt(r, po, "<?php /* Smarty version", 0, 0)
+		if rA < 0 {
+			goto f3e
+		}
+		a("Smarty compiled template")
+	f3e:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x5a:
+		rA = gt(r, po, "Zend\x00", 0, 0)
+		if rA < 0 {
+			goto f3f
+		}
+		a("PHP script Zend Optimizer data")
+	f3f:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x7f:
+		rA = gt(r, po, "\x7fELF", 0, 0)
+		if rA < 0 {
+			goto f42
+		}
+		a("ELF")
+		fd.Add("format", "elf")
+		rc, m = w1l(st, r, hw, po, po+4)
+		if !(m && rc == 0) {
+			goto f43
+		}
+		a("invalid class")
+	f43:
+		if !(m && rc == 1) {
+			goto f44
+		}
+		a("32-bit")
+		fd.Add("bits", "32")
+	f44:
+		if !(m && rc == 2) {
+			goto f45
+		}
+		a("64-bit")
+		fd.Add("bits", "64")
+	f45:
+		rc, m = w1l(st, r, hw, po, po+5)
+		if !(m && rc == 0) {
+			goto f46
+		}
+		a("invalid byte order")
+	f46:
+		if !(m && rc == 1) {
+			goto f47
+		}
+		a("LSB")
+		fd.Add("endianness", "little")
+		if !st.gw.EnterUse() {
+			goto f48
+		}
+		ss = identifyElfLe(st, r, po, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f48
+		}
+		a(ss...)
+	f48:
+	f47:
+		if !(m && rc == 2) {
+			goto f49
+		}
+		a("MSB")
+		fd.Add("endianness", "big")
+		if !st.gw.EnterUse() {
+			goto f4a
+		}
+		ss = identifyElfLe__Swapped(st, r, po, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f4a
+		}
+		a(ss...)
+	f4a:
+	f49:
+		rc, m = w1l(st, r, hw, po, po+4)
+		if !(m && int64(int8(rc)) < 128) {
+			goto f4b
+		}
+		sv = gs(r, po+8, 96)
+		rA = gt(r, po+8, "\x00", 0, 2)
+		if rA < 0 {
+			goto f4c
+		}
+		a(wizardry.FormatDescription("(%s)", wizardry.StringTestFlags(0).Printable(sv)))
+	f4c:
+	f4b:
+		sb = gp(r, po+8, 1)
+		sm[1] = 0
+		if len(sb) > 0 {
+			switch sb[0] {
+			case 0:
+				sm[1] |= 0x1
+			case 2:
+				sm[1] |= 0x2
+			}
+		}
+		if sm[1]&0x1 == 0 {
+			goto f4d
+		}
+		rA = gt(r, po+8, "\x00", 0, 0)
+		if rA < 0 {
+			goto f4d
+		}
+		rc, m = w1l(st, r, hw, po, po+7)
+		if !(m && rc == 0) {
+			goto f4e
+		}
+		a("(SYSV)")
+		fd.Add("os", "SYSV")
+	f4e:
+		if !(m && rc == 1) {
+			goto f4f
+		}
+		a("(HP-UX)")
+		fd.Add("os", "HP-UX")
+	f4f:
+		if !(m && rc == 2) {
+			goto f50
+		}
+		a("(NetBSD)")
+		fd.Add("os", "NetBSD")
+	f50:
+		if !(m && rc == 3) {
+			goto f51
+		}
+		a("(GNU/Linux)")
+		fd.Add("os", "GNU/Linux")
+	f51:
+		if !(m && rc == 4) {
+			goto f52
+		}
+		a("(GNU/Hurd)")
+		fd.Add("os", "GNU/Hurd")
+	f52:
+		if !(m && rc == 5) {
+			goto f53
+		}
+		a("(86Open)")
+		fd.Add("os", "86Open")
+	f53:
+		if !(m && rc == 6) {
+			goto f54
+		}
+		a("(Solaris)")
+		fd.Add("os", "Solaris")
+	f54:
+		if !(m && rc == 7) {
+			goto f55
+		}
+		a("(Monterey)")
+		fd.Add("os", "Monterey")
+	f55:
+		if !(m && rc == 8) {
+			goto f56
+		}
+		a("(IRIX)")
+		fd.Add("os", "IRIX")
+	f56:
+		if !(m && rc == 9) {
+			goto f57
+		}
+		a("(FreeBSD)")
+		fd.Add("os", "FreeBSD")
+	f57:
+		if !(m && rc == 10) {
+			goto f58
+		}
+		a("(Tru64)")
+		fd.Add("os", "Tru64")
+	f58:
+		if !(m && rc == 11) {
+			goto f59
+		}
+		a("(Novell Modesto)")
+		fd.Add("os", "Novell Modesto")
+	f59:
+		if !(m && rc == 12) {
+			goto f5a
+		}
+		a("(OpenBSD)")
+		fd.Add("os", "OpenBSD")
+	f5a:
+	f4d:
+		if sm[1]&0x2 == 0 {
+			goto f5b
+		}
+		rA = gt(r, po+8, "\x02", 0, 0)
+		if rA < 0 {
+			goto f5b
+		}
+		rc, m = w1l(st, r, hw, po, po+7)
+		if !(m && rc == 13) {
+			goto f5c
+		}
+		a("(OpenVMS)")
+		fd.Add("os", "OpenVMS")
+	f5c:
+		if !(m && rc == 97) {
+			goto f5d
+		}
+		a("(ARM)")
+		fd.Add("os", "ARM")
+	f5d:
+		if !(m && rc == 255) {
+			goto f5e
+		}
+		a("(embedded)")
+		fd.Add("os", "embedded")
+	f5e:
+	f5b:
+	f42:
+		if len(out) > 0 {
+			return out
+		}
+	}
+	rc, m = w4l(st, r, hw, po, po)
+	if !(m && rc&4294967294 == 4277009102) {
+		goto f5f
+	}
+	a("Mach-O")
+	fd.Add("format", "mach-o")
+	fd.Add("endianness", "little")
+	if !st.gw.EnterUse() {
+		goto f60
+	}
+	ss = identifyMachOBe__Swapped(st, r, po, fd)
+	st.gw.ExitUse()
+	if len(ss) == 0 {
+		goto f60
+	}
+	a(ss...)
+f60:
+f5f:
+	if len(out) > 0 {
+		return out
+	}
+	rc, m = w4b(st, r, hw, po, po)
+	if !(m && rc&4294967294 == 4277009102) {
+		goto f61
+	}
+	a("Mach-O")
+	fd.Add("format", "mach-o")
+	fd.Add("endianness", "big")
+	if !st.gw.EnterUse() {
+		goto f62
+	}
+	ss = identifyMachOBe(st, r, po, fd)
+	st.gw.ExitUse()
+	if len(ss) == 0 {
+		goto f62
+	}
+	a(ss...)
+f62:
+f61:
+	if len(out) > 0 {
+		return out
+	}
+	if !ix(st, r, &tx) {
+		goto f63
+	}
+	rA = gt(r, po, "@", 16, 0)
+	if rA < 0 {
+		goto f63
+	}
+	sb = gp(r, po+1, 4)
+	sm[1] = 0
+	if len(sb) > 0 {
+		switch sb[0] {
+		case 32:
+			sm[1] |= 0x1
+		case 69:
+			if len(sb) > 1 {
+				switch sb[1] {
+				case 67:
+					if len(sb) > 2 {
+						switch sb[2] {
+						case 72:
+							sm[1] |= 0x2
+						case 104:
+							sm[1] |= 0x2
+						}
+					}
+				case 99:
+					if len(sb) > 2 {
+						switch sb[2] {
+						case 72:
+							sm[1] |= 0x2
+						case 104:
+							sm[1] |= 0x2
+						}
+					}
+				}
+			}
+		case 82:
+			if len(sb) > 1 {
+				switch sb[1] {
+				case 69:
+					if len(sb) > 2 {
+						switch sb[2] {
+						case 77:
+							sm[1] |= 0x4
+						case 109:
+							sm[1] |= 0x4
+						}
+					}
+				case 101:
+					if len(sb) > 2 {
+						switch sb[2] {
+						case 77:
+							sm[1] |= 0x4
+						case 109:
+							sm[1] |= 0x4
+						}
+					}
+				}
+			}
+		case 83:
+			if len(sb) > 1 {
+				switch sb[1] {
+				case 69:
+					if len(sb) > 2 {
+						switch sb[2] {
+						case 84:
+							if len(sb) > 3 && sb[3] == 32 {
+								sm[1] |= 0x8
+							}
+						case 116:
+							if len(sb) > 3 && sb[3] == 32 {
+								sm[1] |= 0x8
+							}
+						}
+					}
+				case 101:
+					if len(sb) > 2 {
+						switch sb[2] {
+						case 84:
+							if len(sb) > 3 && sb[3] == 32 {
+								sm[1] |= 0x8
+							}
+						case 116:
+							if len(sb) > 3 && sb[3] == 32 {
+								sm[1] |= 0x8
+							}
+						}
+					}
+				}
+			}
+		case 101:
+			if len(sb) > 1 {
+				switch sb[1] {
+				case 67:
+					if len(sb) > 2 {
+						switch sb[2] {
+						case 72:
+							sm[1] |= 0x2
+						case 104:
+							sm[1] |= 0x2
+						}
+					}
+				case 99:
+					if len(sb) > 2 {
+						switch sb[2] {
+						case 72:
+							sm[1] |= 0x2
+						case 104:
+							sm[1] |= 0x2
+						}
+					}
+				}
+			}
+		case 114:
+			if len(sb) > 1 {
+				switch sb[1] {
+				case 69:
+					if len(sb) > 2 {
+						switch sb[2] {
+						case 77:
+							sm[1] |= 0x4
+						case 109:
+							sm[1] |= 0x4
+						}
+					}
+				case 101:
+					if len(sb) > 2 {
+						switch sb[2] {
+						case 77:
+							sm[1] |= 0x4
+						case 109:
+							sm[1] |= 0x4
+						}
+					}
+				}
+			}
+		case 115:
+			if len(sb) > 1 {
+				switch sb[1] {
+				case 69:
+					if len(sb) > 2 {
+						switch sb[2] {
+						case 84:
+							if len(sb) > 3 && sb[3] == 32 {
+								sm[1] |= 0x8
+							}
+						case 116:
+							if len(sb) > 3 && sb[3] == 32 {
+								sm[1] |= 0x8
+							}
+						}
+					}
+				case 101:
+					if len(sb) > 2 {
+						switch sb[2] {
+						case 84:
+							if len(sb) > 3 && sb[3] == 32 {
+								sm[1] |= 0x8
+							}
+						case 116:
+							if len(sb) > 3 && sb[3] == 32 {
+								sm[1] |= 0x8
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	if sm[1]&0x1 == 0 {
+		goto f64
+	}
+	rA = gt(r, po+1, " echo off", 5, 0)
+	if rA < 0 {
+		goto f64
+	}
+	a("DOS batch file text")
+f64:
+	if sm[1]&0x2 == 0 {
+		goto f65
+	}
+	rA = gt(r, po+1, "echo off", 5, 0)
+	if rA < 0 {
+		goto f65
+	}
+	a("DOS batch file text")
+f65:
+	if sm[1]&0x4 == 0 {
+		goto f66
+	}
+	rA = gt(r, po+1, "rem", 5, 0)
+	if rA < 0 {
+		goto f66
+	}
+	a("DOS batch file text")
+f66:
+	if sm[1]&0x8 == 0 {
+		goto f67
+	}
+	rA = gt(r, po+1, "set ", 5, 0)
+	if rA < 0 {
+		goto f67
+	}
+	a("DOS batch file text")
+f67:
+f63:
+	if len(out) > 0 {
+		return out
+	}
+	rA, rB = ht(r, po+100, 65535, "rxfuncadd", 0)
+	if rA < 0 {
+		goto f68
+	}
+f68:
+	if len(out) > 0 {
+		return out
+	}
+	rA, rB = ht(r, po+100, 65535, "say", 0)
+	if rA < 0 {
+		goto f69
+	}
+f69:
+	if len(out) > 0 {
+		return out
+	}
+	switch hb {
+	case 0x4b:
+		sb = gp(r, po, 3)
+		sm[0] = 0
+		if len(sb) > 0 && sb[0] == 75 {
+			if len(sb) > 1 {
+				switch sb[1] {
+				case 67:
+					if len(sb) > 2 && sb[2] == 70 {
+						sm[0] |= 0x1
+					}
+				case 76:
+					if len(sb) > 2 && sb[2] == 70 {
+						sm[0] |= 0x2
+					}
+				}
+			}
+		}
+		if sm[0]&0x1 == 0 {
+			goto f12c
+		}
+		if ix(st, r, &tx) {
+			goto f12c
+		}
+		rA = gt(r, po, "KCF", 32, 0)
+		if rA < 0 {
+			goto f12c
+		}
+		a("FreeDOS KEYBoard Layout collection")
+		rc, m = w2l(st, r, hw, po, po+3)
+		if !m {
+			goto f12d
+		}
+		a(wizardry.FormatDescription("\\b, version 0x%x", rc))
+	f12d:
+		rc, m = w1l(st, r, hw, po, po+6)
+		if !(m && rc > 0) {
+			goto f12e
+		}
+		sv = gs(r, po+7, 96)
+		rA = gt(r, po+7, "\x00", 0, 2)
+		if rA < 0 {
+			goto f12f
+		}
+		a(wizardry.FormatDescription("\\b, author=%-.14s", wizardry.StringTestFlags(0).Printable(sv)))
+	f12f:
+		rA, rB = ht(r, po+7, 254, "\xff", 0)
+		if rA < 0 {
+			goto f130
+		}
+		gf[2] = po + 7 + rA + rB
+		a("\\b, info=")
+		sv = gs(r, gf[2], 96)
+		a(wizardry.FormatDescription("\\b%-.15s", wizardry.StringTestFlags(0).Printable(sv)))
+	f130:
+	f12e:
+	f12c:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x2 == 0 {
+			goto f132
+		}
+		if ix(st, r, &tx) {
+			goto f132
+		}
+		rA = gt(r, po, "KLF", 32, 0)
+		if rA < 0 {
+			goto f132
+		}
+		a("FreeDOS KEYBoard Layout file")
+		rc, m = w2l(st, r, hw, po, po+3)
+		if !m {
+			goto f133
+		}
+		a(wizardry.FormatDescription("\\b, version 0x%x", rc))
+	f133:
+		rc, m = w1l(st, r, hw, po, po+5)
+		if !(m && rc > 0) {
+			goto f134
+		}
+		sv = gs(r, po+8, 96)
+		a(wizardry.FormatDescription("\\b, name=%-.2s", wizardry.StringTestFlags(0).Printable(sv)))
+	f134:
+	f132:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x4d:
+		if ix(st, r, &tx) {
+			goto f6f
+		}
+		rA = gt(r, po, "MZ", 32, 0)
+		if rA < 0 {
+			goto f6f
+		}
+		rc, m = w2l(st, r, hw, po, po+24)
+		if !(m && int64(int16(rc)) < 64) {
+			goto f70
+		}
+		a("MS-DOS executable")
+		fd.Add("format", "msdos")
+	f70:
+		if !(m && int64(int16(rc)) > 63) {
+			goto f71
+		}
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto f72
+		}
+		rA = gt(r, int64(ra), "PE\x00\x00", 0, 0)
+		if rA < 0 {
+			goto f72
+		}
+		gf[2] = int64(ra) + rA
+		a("PE")
+		fd.Add("format", "pe")
+		fd.Add("endianness", "little")
+		d[2] = f
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto f73
+		}
+		rc, m = f2l(st, r, int64(ra)+24)
+		if !(m && rc == 267) {
+			goto f73
+		}
+		a("\\b32 executable")
+		fd.Add("bits", "32")
+		d[2] = t
+	f73:
+		if !k {
+			goto f74
+		}
+		if !(m && rc == 523) {
+			goto f74
+		}
+		a("\\b32+ executable")
+		fd.Add("bits", "64")
+		d[2] = t
+	f74:
+		if !k {
+			goto f75
+		}
+		if !(m && rc == 263) {
+			goto f75
+		}
+		a("ROM image")
+		d[2] = t
+	f75:
+		if !k {
+			goto f76
+		}
+		if d[2] {
+			goto f76
+		}
+		gf[3] = int64(ra) + 24
+		a("Unknown PE signature")
+		rc, m = f2l(st, r, gf[3])
+		if !m {
+			goto f77
+		}
+		a(wizardry.FormatDescription("0x%x", int64(int16(rc))))
+	f77:
+		d[2] = t
+	f76:
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto f78
+		}
+		rc, m = f2l(st, r, int64(ra)+22)
+		if !(m && int64(int16(rc&8192)) > 0) {
+			goto f78
+		}
+		a("(DLL)")
+		fd.Add("type", "DLL")
+		d[2] = t
+	f78:
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto f79
+		}
+		rc, m = f2l(st, r, int64(ra)+92)
+		if !(m && rc == 1) {
+			goto f79
+		}
+		a("(native)")
+		fd.Add("subsystem", "native")
+		d[2] = t
+	f79:
+		if !k {
+			goto f7a
+		}
+		if !(m && rc == 2) {
+			goto f7a
+		}
+		a("(GUI)")
+		fd.Add("subsystem", "GUI")
+		d[2] = t
+	f7a:
+		if !k {
+			goto f7b
+		}
+		if !(m && rc == 3) {
+			goto f7b
+		}
+		a("(console)")
+		fd.Add("subsystem", "console")
+		d[2] = t
+	f7b:
+		if !k {
+			goto f7c
+		}
+		if !(m && rc == 7) {
+			goto f7c
+		}
+		a("(POSIX)")
+		fd.Add("subsystem", "POSIX")
+		d[2] = t
+	f7c:
+		if !k {
+			goto f7d
+		}
+		if !(m && rc == 9) {
+			goto f7d
+		}
+		a("(Windows CE)")
+		fd.Add("subsystem", "Windows CE")
+		d[2] = t
+	f7d:
+		if !k {
+			goto f7e
+		}
+		if !(m && rc == 10) {
+			goto f7e
+		}
+		a("(EFI application)")
+		fd.Add("subsystem", "EFI application")
+		d[2] = t
+	f7e:
+		if !k {
+			goto f7f
+		}
+		if !(m && rc == 11) {
+			goto f7f
+		}
+		a("(EFI boot service driver)")
+		fd.Add("subsystem", "EFI boot service driver")
+		d[2] = t
+	f7f:
+		if !k {
+			goto f80
+		}
+		if !(m && rc == 12) {
+			goto f80
+		}
+		a("(EFI runtime driver)")
+		fd.Add("subsystem", "EFI runtime driver")
+		d[2] = t
+	f80:
+		if !k {
+			goto f81
+		}
+		if !(m && rc == 13) {
+			goto f81
+		}
+		a("(EFI ROM)")
+		fd.Add("subsystem", "EFI ROM")
+		d[2] = t
+	f81:
+		if !k {
+			goto f82
+		}
+		if !(m && rc == 14) {
+			goto f82
+		}
+		a("(XBOX)")
+		fd.Add("subsystem", "XBOX")
+		d[2] = t
+	f82:
+		if !k {
+			goto f83
+		}
+		if !(m && rc == 15) {
+			goto f83
+		}
+		a("(Windows boot application)")
+		fd.Add("subsystem", "Windows boot application")
+		d[2] = t
+	f83:
+		if !k {
+			goto f84
+		}
+		if d[2] {
+			goto f84
+		}
+		gf[3] = int64(ra) + 92
+		a("(Unknown subsystem")
+		rc, m = f2l(st, r, gf[3])
+		if !m {
+			goto f85
+		}
+		a(wizardry.FormatDescription("0x%x)", int64(int16(rc))))
+	f85:
+		d[2] = t
+	f84:
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto f86
+		}
+		rc, m = f2l(st, r, int64(ra)+4)
+		if !(m && rc == 332) {
+			goto f86
+		}
+		a("Intel 80386")
+		fd.Add("arch", "Intel 80386")
+		d[2] = t
+	f86:
+		if !k {
+			goto f87
+		}
+		if !(m && rc == 358) {
+			goto f87
+		}
+		a("MIPS R4000")
+		fd.Add("arch", "MIPS R4000")
+		d[2] = t
+	f87:
+		if !k {
+			goto f88
+		}
+		if !(m && rc == 360) {
+			goto f88
+		}
+		a("MIPS R10000")
+		fd.Add("arch", "MIPS R10000")
+		d[2] = t
+	f88:
+		if !k {
+			goto f89
+		}
+		if !(m && rc == 388) {
+			goto f89
+		}
+		a("Alpha")
+		fd.Add("arch", "Alpha")
+		d[2] = t
+	f89:
+		if !k {
+			goto f8a
+		}
+		if !(m && rc == 418) {
+			goto f8a
+		}
+		a("Hitachi SH3")
+		fd.Add("arch", "Hitachi SH3")
+		d[2] = t
+	f8a:
+		if !k {
+			goto f8b
+		}
+		if !(m && rc == 422) {
+			goto f8b
+		}
+		a("Hitachi SH4")
+		fd.Add("arch", "Hitachi SH4")
+		d[2] = t
+	f8b:
+		if !k {
+			goto f8c
+		}
+		if !(m && rc == 448) {
+			goto f8c
+		}
+		a("ARM")
+		fd.Add("arch", "ARM")
+		d[2] = t
+	f8c:
+		if !k {
+			goto f8d
+		}
+		if !(m && rc == 450) {
+			goto f8d
+		}
+		a("ARM Thumb")
+		fd.Add("arch", "ARM Thumb")
+		d[2] = t
+	f8d:
+		if !k {
+			goto f8e
+		}
+		if !(m && rc == 452) {
+			goto f8e
+		}
+		a("ARMv7 Thumb")
+		fd.Add("arch", "ARMv7 Thumb")
+		d[2] = t
+	f8e:
+		if !k {
+			goto f8f
+		}
+		if !(m && rc == 496) {
+			goto f8f
+		}
+		a("PowerPC")
+		fd.Add("arch", "PowerPC")
+		d[2] = t
+	f8f:
+		if !k {
+			goto f90
+		}
+		if !(m && rc == 512) {
+			goto f90
+		}
+		a("Intel Itanium")
+		fd.Add("arch", "Intel Itanium")
+		d[2] = t
+	f90:
+		if !k {
+			goto f91
+		}
+		if !(m && rc == 614) {
+			goto f91
+		}
+		a("MIPS16")
+		fd.Add("arch", "MIPS16")
+		d[2] = t
+	f91:
+		if !k {
+			goto f92
+		}
+		if !(m && rc == 616) {
+			goto f92
+		}
+		a("Motorola 68000")
+		fd.Add("arch", "Motorola 68000")
+		d[2] = t
+	f92:
+		if !k {
+			goto f93
+		}
+		if !(m && rc == 656) {
+			goto f93
+		}
+		a("PA-RISC")
+		fd.Add("arch", "PA-RISC")
+		d[2] = t
+	f93:
+		if !k {
+			goto f94
+		}
+		if !(m && rc == 870) {
+			goto f94
+		}
+		a("MIPSIV")
+		fd.Add("arch", "MIPSIV")
+		d[2] = t
+	f94:
+		if !k {
+			goto f95
+		}
+		if !(m && rc == 1126) {
+			goto f95
+		}
+		a("MIPS16 with FPU")
+		fd.Add("arch", "MIPS16 with FPU")
+		d[2] = t
+	f95:
+		if !k {
+			goto f96
+		}
+		if !(m && rc == 3772) {
+			goto f96
+		}
+		a("EFI byte code")
+		fd.Add("arch", "EFI byte code")
+		d[2] = t
+	f96:
+		if !k {
+			goto f97
+		}
+		if !(m && rc == 34404) {
+			goto f97
+		}
+		a("x86-64")
+		fd.Add("arch", "x86-64")
+		d[2] = t
+	f97:
+		if !k {
+			goto f98
+		}
+		if !(m && rc == 43620) {
+			goto f98
+		}
+		a("Aarch64")
+		fd.Add("arch", "Aarch64")
+		d[2] = t
+	f98:
+		if !k {
+			goto f99
+		}
+		if !(m && rc == 49390) {
+			goto f99
+		}
+		a("MSIL")
+		fd.Add("arch", "MSIL")
+		d[2] = t
+	f99:
+		if !k {
+			goto f9a
+		}
+		if d[2] {
+			goto f9a
+		}
+		gf[3] = int64(ra) + 4
+		a("Unknown processor type")
+		rc, m = f2l(st, r, gf[3])
+		if !m {
+			goto f9b
+		}
+		a(wizardry.FormatDescription("0x%x", int64(int16(rc))))
+	f9b:
+		d[2] = t
+	f9a:
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto f9c
+		}
+		rc, m = f2l(st, r, int64(ra)+22)
+		if !(m && int64(int16(rc&512)) > 0) {
+			goto f9c
+		}
+		a("(stripped to external PDB)")
+		d[2] = t
+	f9c:
+		if !k {
+			goto f9d
+		}
+		if !(m && int64(int16(rc&4096)) > 0) {
+			goto f9d
+		}
+		a("system file")
+		d[2] = t
+	f9d:
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto f9e
+		}
+		rc, m = f2l(st, r, int64(ra)+24)
+		if !(m && rc == 267) {
+			goto f9e
+		}
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto f9f
+		}
+		rc, m = f4l(st, r, int64(ra)+232)
+		if !(m && int64(int32(rc)) > 0) {
+			goto f9f
+		}
+		a("Mono/.Net assembly")
+	f9f:
+		d[2] = t
+	f9e:
+		if !k {
+			goto fa0
+		}
+		if !(m && rc == 523) {
+			goto fa0
+		}
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto fa1
+		}
+		rc, m = f4l(st, r, int64(ra)+248)
+		if !(m && int64(int32(rc)) > 0) {
+			goto fa1
+		}
+		a("Mono/.Net assembly")
+	fa1:
+		d[2] = t
+	fa0:
+		ra, k = w2l(st, r, hw, po, 8)
+		if !k {
+			goto fa2
+		}
+		rA = gt(r, int64(ra)*16, "32STUB", 0, 0)
+		if rA < 0 {
+			goto fa2
+		}
+		a("\\b, 32rtm DOS extender")
+		d[2] = t
+	fa2:
+		if !k {
+			goto fa3
+		}
+		rA = gt(r, int64(ra)*16, "32STUB", 0, 0)
+		if rA >= 0 {
+			goto fa3
+		}
+		a("\\b, for MS Windows")
+		d[2] = t
+	fa3:
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto fa4
+		}
+		rA = gt(r, int64(ra)+248, "UPX0", 0, 0)
+		if rA < 0 {
+			goto fa4
+		}
+		a("\\b, UPX compressed")
+		d[2] = t
+	fa4:
+		if !k {
+			goto fa5
+		}
+		rA, rB = ht(r, int64(ra)+248, 320, "PEC2", 0)
+		if rA < 0 {
+			goto fa5
+		}
+		a("\\b, PECompact2 compressed")
+		d[2] = t
+	fa5:
+		if !k {
+			goto fa6
+		}
+		rA, rB = ht(r, int64(ra)+248, 320, "UPX2", 0)
+		if rA < 0 {
+			goto fa6
+		}
+		gf[3] = int64(ra) + 248 + rA + rB
+		ra, k = f4l(st, r, 16+gf[3])
+		if !k {
+			goto fa7
+		}
+		rb, l = f4l(st, r, 16+gf[3]+-4)
+		if !l {
+			goto fa7
+		}
+		rA = gt(r, int64(ra)+int64(rb), "PK\x03\x04", 0, 0)
+		if rA < 0 {
+			goto fa7
+		}
+		a("\\b, ZIP self-extracting archive (Info-Zip)")
+	fa7:
+		d[2] = t
+	fa6:
+		if !k {
+			goto fa8
+		}
+		rA, rB = ht(r, int64(ra)+248, 320, ".idata", 0)
+		if rA < 0 {
+			goto fa8
+		}
+		gf[3] = int64(ra) + 248 + rA + rB
+		ra, k = f4l(st, r, 14+gf[3])
+		if !k {
+			goto f1000000a9
+		}
+		rb, l = f4l(st, r, 14+gf[3]+-4)
+		if !l {
+			goto f1000000a9
+		}
+		sb = gp(r, int64(ra)+int64(rb), 4)
+		sm[4] = 0
+		if len(sb) > 0 {
+			switch sb[0] {
+			case 80:
+				if len(sb) >= 4 && string(sb[1:4]) == "K\x03\x04" {
+					sm[4] |= 0x1
+				}
+			case 90:
+				if len(sb) > 1 && sb[1] == 90 {
+					if len(sb) > 2 {
+						switch sb[2] {
+						case 48:
+							sm[4] |= 0x2
+						case 49:
+							sm[4] |= 0x4
+						}
+					}
+				}
+			}
+		}
+		if !k {
+			goto fa9
+		}
+		rb, l = f4l(st, r, 14+gf[3]+-4)
+		if !l {
+			goto fa9
+		}
+		if sm[4]&0x1 == 0 {
+			goto fa9
+		}
+		rA = gt(r, int64(ra)+int64(rb), "PK\x03\x04", 0, 0)
+		if rA < 0 {
+			goto fa9
+		}
+		a("\\b, ZIP self-extracting archive (Info-Zip)")
+	fa9:
+		if !k {
+			goto faa
+		}
+		rb, l = f4l(st, r, 14+gf[3]+-4)
+		if !l {
+			goto faa
+		}
+		if sm[4]&0x2 == 0 {
+			goto faa
+		}
+		rA = gt(r, int64(ra)+int64(rb), "ZZ0", 0, 0)
+		if rA < 0 {
+			goto faa
+		}
+		a("\\b, ZZip self-extracting archive")
+	faa:
+		if !k {
+			goto fab
+		}
+		rb, l = f4l(st, r, 14+gf[3]+-4)
+		if !l {
+			goto fab
+		}
+		if sm[4]&0x4 == 0 {
+			goto fab
+		}
+		rA = gt(r, int64(ra)+int64(rb), "ZZ1", 0, 0)
+		if rA < 0 {
+			goto fab
+		}
+		a("\\b, ZZip self-extracting archive")
+	fab:
+	f1000000a9:
+		d[2] = t
+	fa8:
+		if !k {
+			goto fac
+		}
+		rA, rB = ht(r, int64(ra)+248, 320, ".rsrc", 0)
+		if rA < 0 {
+			goto fac
+		}
+		gf[3] = int64(ra) + 248 + rA + rB
+		ra, k = f4l(st, r, 15+gf[3])
+		if !k {
+			goto f1000000ad
+		}
+		rb, l = f4l(st, r, 15+gf[3]+-4)
+		if !l {
+			goto f1000000ad
+		}
+		sb = gp(r, int64(ra)+int64(rb), 4)
+		sm[4] = 0
+		if len(sb) > 0 {
+			switch sb[0] {
+			case 82:
+				if len(sb) >= 4 && string(sb[1:4]) == "ar!" {
+					sm[4] |= 0x2
+				}
+			case 97:
+				if len(sb) >= 4 && string(sb[1:4]) == "\\\x04\x05" {
+					sm[4] |= 0x1
+				}
+			}
+		}
+		if !k {
+			goto fad
+		}
+		rb, l = f4l(st, r, 15+gf[3]+-4)
+		if !l {
+			goto fad
+		}
+		if sm[4]&0x1 == 0 {
+			goto fad
+		}
+		rA = gt(r, int64(ra)+int64(rb), "a\\\x04\x05", 0, 0)
+		if rA < 0 {
+			goto fad
+		}
+		a("\\b, WinHKI self-extracting archive")
+	fad:
+		if !k {
+			goto fae
+		}
+		rb, l = f4l(st, r, 15+gf[3]+-4)
+		if !l {
+			goto fae
+		}
+		if sm[4]&0x2 == 0 {
+			goto fae
+		}
+		rA = gt(r, int64(ra)+int64(rb), "Rar!", 0, 0)
+		if rA < 0 {
+			goto fae
+		}
+		a("\\b, RAR self-extracting archive")
+	fae:
+	f1000000ad:
+		if !k {
+			goto faf
+		}
+		rb, l = f4l(st, r, 15+gf[3]+-4)
+		if !l {
+			goto faf
+		}
+		rA, rB = ht(r, int64(ra)+int64(rb), 12288, "MSCF", 0)
+		if rA < 0 {
+			goto faf
+		}
+		a("\\b, InstallShield self-extracting archive")
+	faf:
+		if !k {
+			goto fb0
+		}
+		rb, l = f4l(st, r, 15+gf[3]+-4)
+		if !l {
+			goto fb0
+		}
+		rA, rB = ht(r, int64(ra)+int64(rb), 32, "Nullsoft", 0)
+		if rA < 0 {
+			goto fb0
+		}
+		a("\\b, Nullsoft Installer self-extracting archive")
+	fb0:
+		d[2] = t
+	fac:
+		if !k {
+			goto fb1
+		}
+		rA, rB = ht(r, int64(ra)+248, 320, ".data", 0)
+		if rA < 0 {
+			goto fb1
+		}
+		gf[3] = int64(ra) + 248 + rA + rB
+		ra, k = f4l(st, r, 15+gf[3])
+		if !k {
+			goto fb2
+		}
+		rA = gt(r, int64(ra), "WEXTRACT", 0, 0)
+		if rA < 0 {
+			goto fb2
+		}
+		a("\\b, MS CAB-Installer self-extracting archive")
+	fb2:
+		d[2] = t
+	fb1:
+		if !k {
+			goto fb3
+		}
+		rA, rB = ht(r, int64(ra)+248, 320, ".petite\x00", 0)
+		if rA < 0 {
+			goto fb3
+		}
+		a("\\b, Petite compressed")
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto fb4
+		}
+		gf[4] = int64(ra) + 248
+		ra, k = f4l(st, r, 260+gf[4])
+		if !k {
+			goto fb5
+		}
+		rb, l = f4l(st, r, 260+gf[4]+-4)
+		if !l {
+			goto fb5
+		}
+		rA = gt(r, int64(ra)+int64(rb), "!sfx!", 0, 0)
+		if rA < 0 {
+			goto fb5
+		}
+		a("\\b, ACE self-extracting archive")
+	fb5:
+	fb4:
+		d[2] = t
+	fb3:
+		if !k {
+			goto fb6
+		}
+		rA, rB = ht(r, int64(ra)+248, 320, ".WISE", 0)
+		if rA < 0 {
+			goto fb6
+		}
+		a("\\b, WISE installer self-extracting archive")
+		d[2] = t
+	fb6:
+		if !k {
+			goto fb7
+		}
+		rA, rB = ht(r, int64(ra)+248, 320, ".dz\x00\x00\x00", 0)
+		if rA < 0 {
+			goto fb7
+		}
+		a("\\b, Dzip self-extracting archive")
+		d[2] = t
+	fb7:
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto fb8
+		}
+		rA, rB = ht(r, int64(ra)+248+gf[2], 256, "_winzip_", 0)
+		if rA < 0 {
+			goto fb8
+		}
+		a("\\b, ZIP self-extracting archive (WinZip)")
+		d[2] = t
+	fb8:
+		if !k {
+			goto fb9
+		}
+		rA, rB = ht(r, int64(ra)+248+gf[2], 256, "SharedD", 0)
+		if rA < 0 {
+			goto fb9
+		}
+		a("\\b, Microsoft Installer self-extracting archive")
+		d[2] = t
+	fb9:
+		rA = gt(r, po+48, "Inno", 0, 0)
+		if rA < 0 {
+			goto fba
+		}
+		a("\\b, InnoSetup self-extracting archive")
+		d[2] = t
+	fba:
+		rA, rB = ht(r, po, 61440, "Inno Setup Setup Data", 0)
+		if rA < 0 {
+			goto fbb
+		}
+		a("\\b, InnoSetup installer")
+		d[2] = t
+	fbb:
+	f72:
+		if !k {
+			goto fbc
+		}
+		rA = gt(r, int64(ra), "PE\x00\x00", 0, 0)
+		if rA >= 0 {
+			goto fbc
+		}
+		a("MS-DOS executable")
+	fbc:
+		if !k {
+			goto f1000000bd
+		}
+		sb = gp(r, int64(ra), 4)
+		sm[2] = 0
+		if len(sb) > 0 {
+			switch sb[0] {
+			case 76:
+				if len(sb) > 1 {
+					switch sb[1] {
+					case 69:
+						if len(sb) >= 4 && string(sb[2:4]) == "\x00\x00" {
+							sm[2] |= 0x8
+						}
+					case 88:
+						if len(sb) >= 4 && string(sb[2:4]) == "\x00\x00" {
+							sm[2] |= 0x2
+						}
+					}
+				}
+			case 78:
+				if len(sb) > 1 && sb[1] == 69 {
+					sm[2] |= 0x1
+				}
+			case 87:
+				if len(sb) > 1 && sb[1] == 51 {
+					sm[2] |= 0x4
+				}
+			}
+		}
+		if !k {
+			goto fbd
+		}
+		if sm[2]&0x1 == 0 {
+			goto fbd
+		}
+		rA = gt(r, int64(ra), "NE", 0, 0)
+		if rA < 0 {
+			goto fbd
+		}
+		gf[2] = int64(ra) + rA
+		a("\\b, NE")
+		fd.Add("format", "ne")
+		d[2] = f
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto fbe
+		}
+		rc, m = f1l(st, r, int64(ra)+54)
+		switch rc {
+		case 1:
+			a("for OS/2 1.x")
+		case 2:
+			a("for MS Windows 3.x")
+		case 3:
+			a("for MS-DOS")
+		case 4:
+			a("for Windows 386")
+		case 5:
+			a("for Borland Operating System Services")
+		default:
+			{
+				goto fbe
+			}
+		}
+		d[2] = t
+	fbe:
+		if !k {
+			goto fc3
+		}
+		if d[2] {
+			goto fc3
+		}
+		if !k {
+			goto fc4
+		}
+		rc, m = f1l(st, r, int64(ra)+54)
+		if !m {
+			goto fc4
+		}
+		a(wizardry.FormatDescription("(unknown OS %x)", int64(int8(rc))))
+	fc4:
+		d[2] = t
+	fc3:
+		if !k {
+			goto fc5
+		}
+		rc, m = f1l(st, r, int64(ra)+54)
+		if !(m && rc == 129) {
+			goto fc5
+		}
+		a("for MS-DOS, Phar Lap DOS extender")
+		d[2] = t
+	fc5:
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto fc6
+		}
+		rc, m = f2l(st, r, int64(ra)+12)
+		if !(m && rc&32771 == 32770) {
+			goto fc6
+		}
+		a("(DLL)")
+		d[2] = t
+	fc6:
+		if !k {
+			goto fc7
+		}
+		if !(m && rc&32771 == 32769) {
+			goto fc7
+		}
+		a("(driver)")
+		d[2] = t
+	fc7:
+		ra, k = f2l(st, r, 36+gf[2])
+		if !k {
+			goto fc8
+		}
+		rA = gt(r, int64(ra)-1+gf[2], "ARJSFX", 0, 0)
+		if rA < 0 {
+			goto fc8
+		}
+		a("\\b, ARJ self-extracting archive")
+		d[2] = t
+	fc8:
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto fc9
+		}
+		rA, rB = ht(r, int64(ra)+112, 128, "WinZip(R) Self-Extractor", 0)
+		if rA < 0 {
+			goto fc9
+		}
+		a("\\b, ZIP self-extracting archive (WinZip)")
+		d[2] = t
+	fc9:
+	fbd:
+		if !k {
+			goto fca
+		}
+		if sm[2]&0x2 == 0 {
+			goto fca
+		}
+		rA = gt(r, int64(ra), "LX\x00\x00", 0, 0)
+		if rA < 0 {
+			goto fca
+		}
+		gf[2] = int64(ra) + rA
+		a("\\b, LX")
+		fd.Add("format", "lx")
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto fcb
+		}
+		rc, m = f2l(st, r, int64(ra)+10)
+		if !(m && int64(int16(rc)) < 1) {
+			goto fcb
+		}
+		a("(unknown OS)")
+	fcb:
+		if !k {
+			goto fcc
+		}
+		rc, m = f2l(st, r, int64(ra)+10)
+		switch rc {
+		case 1:
+			a("for OS/2")
+		case 2:
+			a("for MS Windows")
+		case 3:
+			a("for DOS")
+		default:
+			{
+				goto fcc
+			}
+		}
+	fcc:
+		if !k {
+			goto fcf
+		}
+		rc, m = f2l(st, r, int64(ra)+10)
+		if !(m && int64(int16(rc)) > 3) {
+			goto fcf
+		}
+		a("(unknown OS)")
+	fcf:
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto fd0
+		}
+		rc, m = f4l(st, r, int64(ra)+16)
+		if !(m && rc&163840 == 32768) {
+			goto fd0
+		}
+		a("(DLL)")
+	fd0:
+		if !k {
+			goto fd1
+		}
+		if !(m && int64(int32(rc&131072)) > 0) {
+			goto fd1
+		}
+		a("(device driver)")
+	fd1:
+		if !k {
+			goto fd2
+		}
+		if !(m && rc&768 == 768) {
+			goto fd2
+		}
+		a("(GUI)")
+	fd2:
+		if !k {
+			goto fd3
+		}
+		if !(m && int64(int32(rc&164608)) < 768) {
+			goto fd3
+		}
+		a("(console)")
+	fd3:
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto fd4
+		}
+		rc, m = f2l(st, r, int64(ra)+8)
+		switch rc {
+		case 1:
+			a("i80286")
+		case 2:
+			a("i80386")
+		case 3:
+			a("i80486")
+		default:
+			{
+				goto fd4
+			}
+		}
+	fd4:
+		ra, k = w2l(st, r, hw, po, 8)
+		if !k {
+			goto fd7
+		}
+		rA = gt(r, int64(ra)*16, "emx", 0, 0)
+		if rA < 0 {
+			goto fd7
+		}
+		gf[3] = int64(ra)*16 + rA
+		a("\\b, emx")
+		sv = gs(r, gf[3]+1, 96)
+		a(wizardry.FormatDescription("%s", wizardry.StringTestFlags(0).Printable(sv)))
+	fd7:
+		ra, k = f4l(st, r, 84+gf[2])
+		if !k {
+			goto fd9
+		}
+		rA = gt(r, int64(ra)-3+gf[2], "arjsfx", 0, 0)
+		if rA < 0 {
+			goto fd9
+		}
+		a("\\b, ARJ self-extracting archive")
+	fd9:
+	fca:
+		if !k {
+			goto fda
+		}
+		if sm[2]&0x4 == 0 {
+			goto fda
+		}
+		rA = gt(r, int64(ra), "W3", 0, 0)
+		if rA < 0 {
+			goto fda
+		}
+		a("\\b, W3 for MS Windows")
+	fda:
+		if !k {
+			goto fdb
+		}
+		if sm[2]&0x8 == 0 {
+			goto fdb
+		}
+		rA = gt(r, int64(ra), "LE\x00\x00", 0, 0)
+		if rA < 0 {
+			goto fdb
+		}
+		gf[2] = int64(ra) + rA
+		a("\\b, LE executable")
+		ra, k = w4l(st, r, hw, po, 60)
+		if !k {
+			goto fdc
+		}
+		rc, m = f2l(st, r, int64(ra)+10)
+		if !(m && rc == 1) {
+			goto fdc
+		}
+		gf[3] = int64(ra) + 12
+		rA, rB = ht(r, po+576, 256, "DOS/4G", 0)
+		if rA < 0 {
+			goto fdd
+		}
+		a("for MS-DOS, DOS4GW DOS extender")
+	fdd:
+		rA, rB = ht(r, po+576, 512, "WATCOM C/C++", 0)
+		if rA < 0 {
+			goto fde
+		}
+		a("for MS-DOS, DOS4GW DOS extender")
+	fde:
+		rA, rB = ht(r, po+1088, 256, "CauseWay DOS Extender", 0)
+		if rA < 0 {
+			goto fdf
+		}
+		a("for MS-DOS, CauseWay DOS extender")
+	fdf:
+		rA, rB = ht(r, po+64, 64, "PMODE/W", 0)
+		if rA < 0 {
+			goto fe0
+		}
+		a("for MS-DOS, PMODE/W DOS extender")
+	fe0:
+		rA, rB = ht(r, po+64, 64, "STUB/32A", 0)
+		if rA < 0 {
+			goto fe1
+		}
+		a("for MS-DOS, DOS/32A DOS extender (stub)")
+	fe1:
+		rA, rB = ht(r, po+64, 128, "STUB/32C", 0)
+		if rA < 0 {
+			goto fe2
+		}
+		a("for MS-DOS, DOS/32A DOS extender (configurable stub)")
+	fe2:
+		rA, rB = ht(r, po+64, 128, "DOS/32A", 0)
+		if rA < 0 {
+			goto fe3
+		}
+		a("for MS-DOS, DOS/32A DOS extender (embedded)")
+	fe3:
+		rc, m = f4l(st, r, gf[3]+36)
+		if !(m && int64(int32(rc)) < 80) {
+			goto fe4
+		}
+		gf[4] = gf[3] + 40
+		ra, k = f4l(st, r, 76+gf[4])
+		if !k {
+			goto fe5
+		}
+		rA = gt(r, int64(ra), "\xfc\xb8WATCOM", 0, 0)
+		if rA < 0 {
+			goto fe5
+		}
+		gf[5] = int64(ra) + rA
+		rA, rB = ht(r, gf[5], 8, "3\xdbf\xb9", 0)
+		if rA < 0 {
+			goto fe6
+		}
+		a("\\b, 32Lite compressed")
+	fe6:
+	fe5:
+	fe4:
+	fdc:
+		if !k {
+			goto fe7
+		}
+		rc, m = f2l(st, r, int64(ra)+10)
+		switch rc {
+		case 2:
+			a("for MS Windows")
+		case 3:
+			a("for DOS")
+		case 4:
+			a("for MS Windows (VxD)")
+		default:
+			{
+				goto fe7
+			}
+		}
+	fe7:
+		ra, k = f4l(st, r, 124+gf[2])
+		if !k {
+			goto fea
+		}
+		rA = gt(r, int64(ra)+38, "UPX", 0, 0)
+		if rA < 0 {
+			goto fea
+		}
+		a("\\b, UPX compressed")
+	fea:
+		ra, k = f4l(st, r, 84+gf[2])
+		if !k {
+			goto feb
+		}
+		rA = gt(r, int64(ra)-3+gf[2], "UNACE", 0, 0)
+		if rA < 0 {
+			goto feb
+		}
+		a("\\b, ACE self-extracting archive")
+	feb:
+	fdb:
+	f1000000bd:
+		rc, m = w4l(st, r, hw, po, po+60)
+		if !(m && int64(int32(rc)) > 536870912) {
+			goto fec
+		}
+		ra, k = w2l(st, r, hw, po, 4)
+		if !k {
+			goto fed
+		}
+		rc, m = f2l(st, r, int64(ra)*512)
+		if !(m && rc != 332) {
+			goto fed
+		}
+		a("\\b, MZ for MS-DOS")
+	fed:
+	fec:
+	f71:
+		rc, m = w4l(st, r, hw, po, po+2)
+		if !(m && rc != 0) {
+			goto fee
+		}
+		rc, m = w2l(st, r, hw, po, po+24)
+		if !(m && int64(int16(rc)) < 64) {
+			goto fef
+		}
+		ra, k = w2l(st, r, hw, po, 4)
+		if !k {
+			goto ff0
+		}
+		rc, m = f2l(st, r, int64(ra)*512)
+		if !(m && rc != 332) {
+			goto ff0
+		}
+		gf[3] = int64(ra)*512 + 2
+		ra, k = w2l(st, r, hw, po, 2)
+		if !k {
+			goto ff1
+		}
+		rA = gt(r, int64(ra)-514+gf[3], "LE", 0, 0)
+		if rA >= 0 {
+			goto ff1
+		}
+		gf[4] = int64(ra) - 514 + gf[3]
+		rA = gt(r, gf[4]+-2, "BW", 0, 0)
+		if rA >= 0 {
+			goto ff2
+		}
+		a("\\b, MZ for MS-DOS")
+	ff2:
+	ff1:
+		if !k {
+			goto f1000000f3
+		}
+		sb = gp(r, int64(ra)-514+gf[3], 2)
+		sm[4] = 0
+		if len(sb) > 0 {
+			switch sb[0] {
+			case 66:
+				if len(sb) > 1 && sb[1] == 87 {
+					sm[4] |= 0x2
+				}
+			case 76:
+				if len(sb) > 1 && sb[1] == 69 {
+					sm[4] |= 0x1
+				}
+			}
+		}
+		if !k {
+			goto ff3
+		}
+		if sm[4]&0x1 == 0 {
+			goto ff3
+		}
+		rA = gt(r, int64(ra)-514+gf[3], "LE", 0, 0)
+		if rA < 0 {
+			goto ff3
+		}
+		a("\\b, LE")
+		rA, rB = ht(r, po+576, 256, "DOS/4G", 0)
+		if rA < 0 {
+			goto ff4
+		}
+		a("for MS-DOS, DOS4GW DOS extender")
+	ff4:
+	ff3:
+		if !k {
+			goto ff5
+		}
+		if sm[4]&0x2 == 0 {
+			goto ff5
+		}
+		rA = gt(r, int64(ra)-514+gf[3], "BW", 0, 0)
+		if rA < 0 {
+			goto ff5
+		}
+		rA, rB = ht(r, po+576, 256, "DOS/4G", 0)
+		if rA < 0 {
+			goto ff6
+		}
+		a("\\b, LE for MS-DOS, DOS4GW DOS extender (embedded)")
+	ff6:
+		rA, rB = ht(r, po+576, 256, "!DOS/4G", 0)
+		if rA < 0 {
+			goto ff7
+		}
+		a("\\b, BW collection for MS-DOS")
+	ff7:
+	ff5:
+	f1000000f3:
+	ff0:
+	fef:
+	fee:
+		ra, k = w2l(st, r, hw, po, 4)
+		if !k {
+			goto ff8
+		}
+		rc, m = f2l(st, r, int64(ra)*512)
+		if !(m && rc == 332) {
+			goto ff8
+		}
+		gf[1] = int64(ra)*512 + 2
+		a("\\b, COFF")
+		ra, k = w2l(st, r, hw, po, 8)
+		if !k {
+			goto f1000000f9
+		}
+		sb = gp(r, int64(ra)*16, 8)
+		sm[2] = 0
+		if len(sb) > 0 {
+			switch sb[0] {
+			case 101:
+				if len(sb) >= 3 && string(sb[1:3]) == "mx" {
+					sm[2] |= 0x2
+				}
+			case 103:
+				if len(sb) >= 8 && string(sb[1:8]) == "o32stub" {
+					sm[2] |= 0x1
+				}
+			}
+		}
+		if !k {
+			goto ff9
+		}
+		if sm[2]&0x1 == 0 {
+			goto ff9
+		}
+		rA = gt(r, int64(ra)*16, "go32stub", 0, 0)
+		if rA < 0 {
+			goto ff9
+		}
+		a("for MS-DOS, DJGPP go32 DOS extender")
+	ff9:
+		if !k {
+			goto ffa
+		}
+		if sm[2]&0x2 == 0 {
+			goto ffa
+		}
+		rA = gt(r, int64(ra)*16, "emx", 0, 0)
+		if rA < 0 {
+			goto ffa
+		}
+		gf[2] = int64(ra)*16 + rA
+		sv = gs(r, gf[2]+1, 96)
+		a(wizardry.FormatDescription("for DOS, Win or OS/2, emx %s", wizardry.StringTestFlags(0).Printable(sv)))
+	ffa:
+	f1000000f9:
+		ra, k = f4l(st, r, 66+gf[1])
+		if !k {
+			goto ffc
+		}
+		gf[2] = int64(ra) - 3 + gf[1] + 1
+		rA = gt(r, gf[2]+38, "UPX", 0, 0)
+		if rA < 0 {
+			goto ffd
+		}
+		a("\\b, UPX compressed")
+	ffd:
+	ffc:
+		rA, rB = ht(r, gf[1]+44, 160, ".text", 0)
+		if rA < 0 {
+			goto ffe
+		}
+		gf[2] = gf[1] + 44 + rA + rB
+		rc, m = f4l(st, r, gf[2]+11)
+		if !(m && int64(int32(rc)) < 8192) {
+			goto fff
+		}
+		gf[3] = gf[2] + 15
+		rc, m = f4l(st, r, gf[3])
+		if !(m && int64(int32(rc)) > 24576) {
+			goto f100
+		}
+		a("\\b, 32lite compressed")
+	f100:
+	fff:
+	ffe:
+	ff8:
+		ra, k = w2l(st, r, hw, po, 8)
+		if !k {
+			goto f101
+		}
+		rA = gt(r, int64(ra)*16, "$WdX", 0, 0)
+		if rA < 0 {
+			goto f101
+		}
+		a("\\b, WDos/X DOS extender")
+	f101:
+		rA = gt(r, po+53, "\x8e\xc0\xb9\b\x00\xf3\xa5Ju\xeb\x8eÎ\xd83\xff\xbe0\x00\x05", 0, 0)
+		if rA < 0 {
+			goto f102
+		}
+		a("\\b, aPack compressed")
+	f102:
+		sv = gs(r, po+231, 96)
+		rA = gt(r, po+231, "LH/2 ", 0, 0)
+		if rA < 0 {
+			goto f103
+		}
+		a(wizardry.FormatDescription("Self-Extract \\b, %s", wizardry.StringTestFlags(0).Printable(sv)))
+	f103:
+		sb = gp(r, po+28, 4)
+		sm[1] = 0
+		if len(sb) > 0 {
+			switch sb[0] {
+			case 76:
+				if len(sb) > 1 && sb[1] == 90 {
+					if len(sb) > 2 {
+						switch sb[2] {
+						case 48:
+							if len(sb) > 3 && sb[3] == 57 {
+								sm[1] |= 0x10
+							}
+						case 57:
+							if len(sb) > 3 && sb[3] == 49 {
+								sm[1] |= 0x20
+							}
+						}
+					}
+				}
+			case 82:
+				if len(sb) >= 4 && string(sb[1:4]) == "JSX" {
+					sm[1] |= 0x4
+				}
+			case 85:
+				if len(sb) >= 4 && string(sb[1:4]) == "C2X" {
+					sm[1] |= 0x1
+				}
+			case 87:
+				if len(sb) >= 4 && string(sb[1:4]) == "WP " {
+					sm[1] |= 0x2
+				}
+			case 100:
+				if len(sb) >= 4 && string(sb[1:4]) == "iet" {
+					sm[1] |= 0x8
+				}
+			case 116:
+				if len(sb) > 1 && sb[1] == 122 {
+					sm[1] |= 0x40
+				}
+			}
+		}
+		if sm[1]&0x1 == 0 {
+			goto f104
+		}
+		rA = gt(r, po+28, "UC2X", 0, 0)
+		if rA < 0 {
+			goto f104
+		}
+		a("\\b, UCEXE compressed")
+	f104:
+		if sm[1]&0x2 == 0 {
+			goto f105
+		}
+		rA = gt(r, po+28, "WWP ", 0, 0)
+		if rA < 0 {
+			goto f105
+		}
+		a("\\b, WWPACK compressed")
+	f105:
+		if sm[1]&0x4 == 0 {
+			goto f106
+		}
+		rA = gt(r, po+28, "RJSX", 0, 0)
+		if rA < 0 {
+			goto f106
+		}
+		a("\\b, ARJ self-extracting archive")
+	f106:
+		if sm[1]&0x8 == 0 {
+			goto f107
+		}
+		rA = gt(r, po+28, "diet", 0, 0)
+		if rA < 0 {
+			goto f107
+		}
+		a("\\b, diet compressed")
+	f107:
+		if sm[1]&0x10 == 0 {
+			goto f108
+		}
+		rA = gt(r, po+28, "LZ09", 0, 0)
+		if rA < 0 {
+			goto f108
+		}
+		a("\\b, LZEXE v0.90 compressed")
+	f108:
+		if sm[1]&0x20 == 0 {
+			goto f109
+		}
+		rA = gt(r, po+28, "LZ91", 0, 0)
+		if rA < 0 {
+			goto f109
+		}
+		a("\\b, LZEXE v0.91 compressed")
+	f109:
+		if sm[1]&0x40 == 0 {
+			goto f10a
+		}
+		rA = gt(r, po+28, "tz", 0, 0)
+		if rA < 0 {
+			goto f10a
+		}
+		a("\\b, TinyProg compressed")
+	f10a:
+		sb = gp(r, po+30, 31)
+		sm[1] = 0
+		if len(sb) > 0 {
+			switch sb[0] {
+			case 67:
+				if len(sb) >= 31 && string(sb[1:31]) == "opyright 1989-1990 PKWARE Inc." {
+					sm[1] |= 0x1
+				}
+			case 80:
+				if len(sb) >= 12 && string(sb[1:12]) == "KLITE Copr." {
+					sm[1] |= 0x2
+				}
+			}
+		}
+		if sm[1]&0x1 == 0 {
+			goto f10b
+		}
+		rA = gt(r, po+30, "Copyright 1989-1990 PKWARE Inc.", 0, 0)
+		if rA < 0 {
+			goto f10b
+		}
+		a("Self-extracting PKZIP archive")
+	f10b:
+		if sm[1]&0x2 == 0 {
+			goto f10c
+		}
+		rA = gt(r, po+30, "PKLITE Copr.", 0, 0)
+		if rA < 0 {
+			goto f10c
+		}
+		a("Self-extracting PKZIP archive")
+	f10c:
+		rA, rB = ht(r, po+32, 224, "aRJsfX", 0)
+		if rA < 0 {
+			goto f10d
+		}
+		a("\\b, ARJ self-extracting archive")
+	f10d:
+		rA = gt(r, po+32, "AIN", 0, 0)
+		if rA < 0 {
+			goto f10e
+		}
+		rA = gt(r, po+35, "2", 0, 0)
+		if rA < 0 {
+			goto f10f
+		}
+		a("\\b, AIN 2.x compressed")
+	f10f:
+		rA = gt(r, po+35, "2", 0, 1)
+		if rA < 0 {
+			goto f110
+		}
+		a("\\b, AIN 1.x compressed")
+	f110:
+		rA = gt(r, po+35, "2", 0, 2)
+		if rA < 0 {
+			goto f111
+		}
+		a("\\b, AIN 1.x compressed")
+	f111:
+	f10e:
+		sb = gp(r, po+36, 9)
+		sm[1] = 0
+		if len(sb) > 0 {
+			switch sb[0] {
+			case 32:
+				if len(sb) > 1 && sb[1] == 36 {
+					if len(sb) > 2 {
+						switch sb[2] {
+						case 65:
+							if len(sb) >= 5 && string(sb[3:5]) == "RX" {
+								sm[1] |= 0x4
+							}
+						case 76:
+							if len(sb) >= 7 && string(sb[3:7]) == "Harc" {
+								sm[1] |= 0x8
+							}
+						}
+					}
+				}
+			case 76:
+				if len(sb) > 1 && sb[1] == 72 {
+					if len(sb) > 2 {
+						switch sb[2] {
+						case 65:
+							if len(sb) >= 9 && string(sb[3:9]) == "'s SFX" {
+								sm[1] |= 0x2
+							}
+						case 97:
+							if len(sb) >= 9 && string(sb[3:9]) == "'s SFX" {
+								sm[1] |= 0x1
+							}
+						}
+					}
+				}
+			}
+		}
+		if sm[1]&0x1 == 0 {
+			goto f112
+		}
+		rA = gt(r, po+36, "LHa's SFX", 0, 0)
+		if rA < 0 {
+			goto f112
+		}
+		a("\\b, LHa self-extracting archive")
+	f112:
+		if sm[1]&0x2 == 0 {
+			goto f113
+		}
+		rA = gt(r, po+36, "LHA's SFX", 0, 0)
+		if rA < 0 {
+			goto f113
+		}
+		a("\\b, LHa self-extracting archive")
+	f113:
+		if sm[1]&0x4 == 0 {
+			goto f114
+		}
+		rA = gt(r, po+36, " $ARX", 0, 0)
+		if rA < 0 {
+			goto f114
+		}
+		a("\\b, ARX self-extracting archive")
+	f114:
+		if sm[1]&0x8 == 0 {
+			goto f115
+		}
+		rA = gt(r, po+36, " $LHarc", 0, 0)
+		if rA < 0 {
+			goto f115
+		}
+		a("\\b, LHarc self-extracting archive")
+	f115:
+		rA = gt(r, po+32, "SFX by LARC", 0, 0)
+		if rA < 0 {
+			goto f116
+		}
+		a("\\b, LARC self-extracting archive")
+	f116:
+		rA = gt(r, po+64, "aPKG", 0, 0)
+		if rA < 0 {
+			goto f117
+		}
+		a("\\b, aPackage self-extracting archive")
+	f117:
+		rA = gt(r, po+100, "W Collis\x00\x00", 0, 0)
+		if rA < 0 {
+			goto f118
+		}
+		a("\\b, Compack compressed")
+	f118:
+		rA = gt(r, po+122, "Windows self-extracting ZIP", 0, 0)
+		if rA < 0 {
+			goto f119
+		}
+		gf[1] = po + 122 + rA
+		a("\\b, ZIP self-extracting archive")
+		rA, rB = ht(r, gf[1]+244, 320, "\x00@\x01\x00", 0)
+		if rA < 0 {
+			goto f11a
+		}
+		gf[2] = gf[1] + 244 + rA + rB
+		ra, k = f4l(st, r, 0+gf[2])
+		if !k {
+			goto f11b
+		}
+		rb, l = f4l(st, r, 0+gf[2]+4)
+		if !l {
+			goto f11b
+		}
+		rA = gt(r, int64(ra)+int64(rb), "MSCF", 0, 0)
+		if rA < 0 {
+			goto f11b
+		}
+		a("\\b, WinHKI CAB self-extracting archive")
+	f11b:
+	f11a:
+	f119:
+		rA = gt(r, po+1638, "-lh5-", 0, 0)
+		if rA < 0 {
+			goto f11c
+		}
+		a("\\b, LHa self-extracting archive v2.13S")
+	f11c:
+		rA = gt(r, po+96392, "Rar!", 0, 0)
+		if rA < 0 {
+			goto f11d
+		}
+		a("\\b, RAR self-extracting archive")
+	f11d:
+		ra, k = w2l(st, r, hw, po, 4)
+		if !k {
+			goto f11e
+		}
+		gf[1] = int64(ra)*512 + 4
+		ra, k = w2l(st, r, hw, po, 2)
+		if !k {
+			goto f11f
+		}
+		gf[2] = int64(ra) - 517 + gf[1] + 1
+		sb = gp(r, gf[2], 4)
+		sm[3] = 0
+		if len(sb) > 0 {
+			switch sb[0] {
+			case 33:
+				if len(sb) > 1 {
+					switch sb[1] {
+					case 17:
+						sm[3] |= 0x4
+					case 18:
+						sm[3] |= 0x8
+					case 23:
+						sm[3] |= 0x10
+					case 24:
+						sm[3] |= 0x20
+					}
+				}
+			case 80:
+				if len(sb) >= 4 && string(sb[1:4]) == "K\x03\x04" {
+					sm[3] |= 0x1
+				}
+			case 82:
+				if len(sb) >= 4 && string(sb[1:4]) == "ar!" {
+					sm[3] |= 0x2
+				}
+			}
+		}
+		if sm[3]&0x1 == 0 {
+			goto f120
+		}
+		rA = gt(r, gf[2], "PK\x03\x04", 0, 0)
+		if rA < 0 {
+			goto f120
+		}
+		a("\\b, ZIP self-extracting archive")
+	f120:
+		if sm[3]&0x2 == 0 {
+			goto f121
+		}
+		rA = gt(r, gf[2], "Rar!", 0, 0)
+		if rA < 0 {
+			goto f121
+		}
+		a("\\b, RAR self-extracting archive")
+	f121:
+		if sm[3]&0x4 == 0 {
+			goto f122
+		}
+		rA = gt(r, gf[2], "!\x11", 0, 0)
+		if rA < 0 {
+			goto f122
+		}
+		a("\\b, AIN 2.x self-extracting archive")
+	f122:
+		if sm[3]&0x8 == 0 {
+			goto f123
+		}
+		rA = gt(r, gf[2], "!\x12", 0, 0)
+		if rA < 0 {
+			goto f123
+		}
+		a("\\b, AIN 2.x self-extracting archive")
+	f123:
+		if sm[3]&0x10 == 0 {
+			goto f124
+		}
+		rA = gt(r, gf[2], "!\x17", 0, 0)
+		if rA < 0 {
+			goto f124
+		}
+		a("\\b, AIN 1.x self-extracting archive")
+	f124:
+		if sm[3]&0x20 == 0 {
+			goto f125
+		}
+		rA = gt(r, gf[2], "!\x18", 0, 0)
+		if rA < 0 {
+			goto f125
+		}
+		a("\\b, AIN 1.x self-extracting archive")
+	f125:
+		rA, rB = ht(r, gf[2]+7, 400, "**ACE**", 0)
+		if rA < 0 {
+			goto f126
+		}
+		a("\\b, ACE self-extracting archive")
+	f126:
+		rA, rB = ht(r, gf[2], 1152, "UC2SFX Header", 0)
+		if rA < 0 {
+			goto f127
+		}
+		a("\\b, UC2 self-extracting archive")
+	f127:
+	f11f:
+	f11e:
+		ra, k = w2l(st, r, hw, po, 8)
+		if !k {
+			goto f128
+		}
+		rA, rB = ht(r, int64(ra)*16, 32, "PKSFX", 0)
+		if rA < 0 {
+			goto f128
+		}
+		a("\\b, ZIP self-extracting archive (PKZIP)")
+	f128:
+		rA = gt(r, po+49801, "y\xff\x80\xffv\xff", 0, 0)
+		if rA < 0 {
+			goto f129
+		}
+		a("\\b, CODEC archive v3.21")
+		rc, m = f2l(st, r, po+49824)
+		if !(m && rc == 1) {
+			goto f12a
+		}
+		a("\\b, 1 file")
+	f12a:
+		if !(m && int64(int16(rc)) > 1) {
+			goto f12b
+		}
+		a(wizardry.FormatDescription("\\b, %u files", int64(int16(rc))))
+	f12b:
+	f129:
+	f6f:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x66:
+		rc, m = w2l(st, r, hw, po, po)
+		if !(m && rc == 358) {
+			goto f6a
+		}
+		a("MS Windows COFF MIPS R4000 object file")
+	f6a:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x68:
+		rc, m = w2l(st, r, hw, po, po)
+		if !(m && rc == 616) {
+			goto f6c
+		}
+		a("MS Windows COFF Motorola 68000 object file")
+	f6c:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x84:
+		rc, m = w2l(st, r, hw, po, po)
+		if !(m && rc == 388) {
+			goto f6b
+		}
+		a("MS Windows COFF Alpha object file")
+	f6b:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x90:
+		rc, m = w2l(st, r, hw, po, po)
+		if !(m && rc == 656) {
+			goto f6e
+		}
+		a("MS Windows COFF PA-RISC object file")
+	f6e:
+		if len(out) > 0 {
+			return out
+		}
+	case 0xf0:
+		rc, m = w2l(st, r, hw, po, po)
+		if !(m && rc == 496) {
+			goto f6d
+		}
+		a("MS Windows COFF PowerPC object file")
+	f6d:
+		if len(out) > 0 {
+			return out
+		}
+	case 0xff:
+		rA = gt(r, po, "\xffKEYB   \x00\x00\x00\x00", 0, 0)
+		if rA < 0 {
+			goto f136
+		}
+		rA = gt(r, po+12, "\x00\x00\x00\x00`\x04\xf0", 0, 0)
+		if rA < 0 {
+			goto f137
+		}
+		a("MS-DOS KEYBoard Layout file")
+	f137:
+	f136:
+		if len(out) > 0 {
+			return out
+		}
+	}
+	rc, m = w8l(st, r, hw, po, po)
+	if !(m && rc&8388071129087 == 4294967295) {
+		goto f138
+	}
+	if !st.gw.EnterUse() {
+		goto f139
+	}
+	ss = identifyMsdosDriver(st, r, po, fd)
+	st.gw.ExitUse()
+	if len(ss) == 0 {
+		goto f139
+	}
+	a(ss...)
+f139:
+f138:
+	if len(out) > 0 {
+		return out
+	}
+	switch hb {
+	case 0x12:
+		rc, m = w8l(st, r, hw, po, po)
+		if !(m && rc == 365847100979675154) {
+			goto f13a
+		}
+		if !st.gw.EnterUse() {
+			goto f13b
+		}
+		ss = identifyMsdosDriver(st, r, po, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f13b
+		}
+		a(ss...)
+	f13b:
+	f13a:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x16:
+		rc, m = w8l(st, r, hw, po, po)
+		if !(m && rc == 3671137388043632662) {
+			goto f13c
+		}
+		if !st.gw.EnterUse() {
+			goto f13d
+		}
+		ss = identifyMsdosDriver(st, r, po, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f13d
+		}
+		a(ss...)
+	f13d:
+	f13c:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x8c:
+		rc, m = w1l(st, r, hw, po, po)
+		if !(m && rc == 140) {
+			goto f146
+		}
+		rA = gt(r, po+4, "O====", 0, 0)
+		if rA >= 0 {
+			goto f147
+		}
+		rA = gt(r, po+5, "MAIN", 0, 0)
+		if rA >= 0 {
+			goto f148
+		}
+		rc, m = w1l(st, r, hw, po, po+4)
+		if !(m && rc > 13) {
+			goto f149
+		}
+		a("DOS executable (COM, 0x8C-variant)")
+	f149:
+	f148:
+	f147:
+	f146:
+		if len(out) > 0 {
+			return out
+		}
+	case 0xeb:
+		rc, m = w4l(st, r, hw, po, po)
+		if !(m && rc == 4294906091) {
+			goto f14a
+		}
+		a("DR-DOS executable (COM)")
+	f14a:
+		if len(out) > 0 {
+			return out
+		}
+	case 0xff:
+		rc, m = w8l(st, r, hw, po, po)
+		if !(m && rc == 35747322042318847) {
+			goto f13e
+		}
+		if !st.gw.EnterUse() {
+			goto f13f
+		}
+		ss = identifyMsdosDriver(st, r, po, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f13f
+		}
+		a(ss...)
+	f13f:
+	f13e:
+		if len(out) > 0 {
+			return out
+		}
+		rc, m = w8l(st, r, hw, po, po)
+		if !(m && rc == 6192449487699967) {
+			goto f140
+		}
+		if !st.gw.EnterUse() {
+			goto f141
+		}
+		ss = identifyMsdosDriver(st, r, po, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f141
+		}
+		a(ss...)
+	f141:
+	f140:
+		if len(out) > 0 {
+			return out
+		}
+		rc, m = w8l(st, r, hw, po, po)
+		if !(m && rc == 862167487276384255) {
+			goto f142
+		}
+		if !st.gw.EnterUse() {
+			goto f143
+		}
+		ss = identifyMsdosDriver(st, r, po, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f143
+		}
+		a(ss...)
+	f143:
+	f142:
+		if len(out) > 0 {
+			return out
+		}
+		rc, m = w8l(st, r, hw, po, po)
+		if !(m && rc == 557611562475454463) {
+			goto f144
+		}
+		if !st.gw.EnterUse() {
+			goto f145
+		}
+		ss = identifyMsdosDriver(st, r, po, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f145
+		}
+		a(ss...)
+	f145:
+	f144:
+		if len(out) > 0 {
+			return out
+		}
+	}
+	rc, m = w2b(st, r, hw, po, po)
+	if !(m && rc&60301 > 60160) {
+		goto f14b
+	}
+f14b:
+	if len(out) > 0 {
+		return out
+	}
+	switch hb {
+	case 0x81:
+		if ix(st, r, &tx) {
+			goto f15d
+		}
+		rA = gt(r, po, "\x81\xfc", 32, 0)
+		if rA < 0 {
+			goto f15d
+		}
+		rA = gt(r, po+4, "w\x02\xcd \xb9", 0, 0)
+		if rA < 0 {
+			goto f15e
+		}
+		rA = gt(r, po+36, "UPX!", 0, 0)
+		if rA < 0 {
+			goto f15f
+		}
+		a("FREE-DOS executable (COM), UPX compressed")
+	f15f:
+	f15e:
+	f15d:
+		if len(out) > 0 {
+			return out
+		}
+	case 0xb8:
+		rc, m = w1l(st, r, hw, po, po)
+		if !(m && rc == 184) {
+			goto f157
+		}
+		rA = gt(r, po, "\xb8\xc0\a\x8e", 0, 0)
+		if rA >= 0 {
+			goto f158
+		}
+		d[1] = f
+		rc, m = w4l(st, r, hw, po, po+1)
+		if !(m && rc&4294967294 == 567102718) {
+			goto f159
+		}
+		a("COM executable (32-bit COMBOOT")
+		rc, m = w4l(st, r, hw, po, po+1)
+		switch rc {
+		case 567102719:
+			a("\\b)")
+		case 567102718:
+			a("\\b, relocatable)")
+		default:
+			{
+				goto f15a
+			}
+		}
+	f15a:
+		d[1] = t
+	f159:
+		if d[1] {
+			goto f15c
+		}
+		a("COM executable for DOS")
+		d[1] = t
+	f15c:
+	f158:
+	f157:
+		if len(out) > 0 {
+			return out
+		}
+	case 0xe9:
+		rc, m = w1l(st, r, hw, po, po)
+		if !(m && rc == 233) {
+			goto f150
+		}
+		rc, m = w2l(st, r, hw, po, po+1)
+		if !(m && int64(int16(rc)) > -1) {
+			goto f151
+		}
+		ra, k = w2l(st, r, hw, po, 1)
+		if !k {
+			goto f152
+		}
+		if !st.gw.EnterUse() {
+			goto f153
+		}
+		ss = identifyMsdosCom(st, r, po, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f153
+		}
+		a(ss...)
+	f153:
+	f152:
+	f151:
+		if !(m && int64(int16(rc)) < -259) {
+			goto f154
+		}
+		ra, k = w2l(st, r, hw, po, 1)
+		if !k {
+			goto f155
+		}
+		if !st.gw.EnterUse() {
+			goto f156
+		}
+		ss = identifyMsdosCom(st, r, po, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f156
+		}
+		a(ss...)
+	f156:
+	f155:
+	f154:
+	f150:
+		if len(out) > 0 {
+			return out
+		}
+	case 0xeb:
+		rc, m = w1l(st, r, hw, po, po)
+		if !(m && rc == 235) {
+			goto f14c
+		}
+		rc, m = w1l(st, r, hw, po, po+1)
+		if !(m && int64(int8(rc)) > -1) {
+			goto f14d
+		}
+		ra, k = w1l(st, r, hw, po, 1)
+		if !k {
+			goto f14e
+		}
+		if !st.gw.EnterUse() {
+			goto f14f
+		}
+		ss = identifyMsdosCom(st, r, po, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f14f
+		}
+		a(ss...)
+	f14f:
+	f14e:
+	f14d:
+	f14c:
+		if len(out) > 0 {
+			return out
+		}
+	}
+	rA = gt(r, po+252, "Must have DOS version", 0, 0)
+	if rA < 0 {
+		goto f160
+	}
+	a("DR-DOS executable (COM)")
+f160:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po+34, "UPX!", 0, 0)
+	if rA < 0 {
+		goto f161
+	}
+	a("FREE-DOS executable (COM), UPX compressed")
+f161:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po+35, "UPX!", 0, 0)
+	if rA < 0 {
+		goto f162
+	}
+	a("FREE-DOS executable (COM), UPX compressed")
+f162:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po+2, "\xcd!", 0, 0)
+	if rA < 0 {
+		goto f163
+	}
+	a("COM executable for DOS")
+f163:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po+4, "\xcd!", 0, 0)
+	if rA < 0 {
+		goto f164
+	}
+	a("COM executable for DOS")
+f164:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po+5, "\xcd!", 0, 0)
+	if rA < 0 {
+		goto f165
+	}
+	a("COM executable for DOS")
+f165:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po+7, "\xcd!", 0, 0)
+	if rA < 0 {
+		goto f166
+	}
+	rc, m = w1l(st, r, hw, po, po)
+	if !(m && rc != 184) {
+		goto f167
+	}
+	a("COM executable for DOS")
+f167:
+f166:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po+10, "\xcd!", 0, 0)
+	if rA < 0 {
+		goto f168
+	}
+	rA = gt(r, po+5, "\xcd!", 0, 0)
+	if rA >= 0 {
+		goto f169
+	}
+	a("COM executable for DOS")
+f169:
+f168:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po+13, "\xcd!", 0, 0)
+	if rA < 0 {
+		goto f16a
+	}
+	a("COM executable for DOS")
+f16a:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po+18, "\xcd!", 0, 0)
+	if rA < 0 {
+		goto f16b
+	}
+	a("COM executable for MS-DOS")
+f16b:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po+23, "\xcd!", 0, 0)
+	if rA < 0 {
+		goto f16c
+	}
+	a("COM executable for MS-DOS")
+f16c:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po+30, "\xcd!", 0, 0)
+	if rA < 0 {
+		goto f16d
+	}
+	a("COM executable for MS-DOS")
+f16d:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po+70, "\xcd!", 0, 0)
+	if rA < 0 {
+		goto f16e
+	}
+	a("COM executable for DOS")
+f16e:
+	if len(out) > 0 {
+		return out
+	}
+	rA, rB = ht(r, po+6, 10, "\xfcW\xf3\xa5\xc3", 0)
+	if rA < 0 {
+		goto f16f
+	}
+	a("COM executable for MS-DOS")
+f16f:
+	if len(out) > 0 {
+		return out
+	}
+	rA, rB = ht(r, po+6, 10, "\xfcW\xf3\xa4\xc3", 0)
+	if rA < 0 {
+		goto f170
+	}
+	a("COM executable for DOS")
+	rA, rB = ht(r, po+24, 16, "P\xa4\xff\xd5s", 0)
+	if rA < 0 {
+		goto f171
+	}
+	a("\\b, aPack compressed")
+f171:
+f170:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po+60, "W Collis\x00\x00", 0, 0)
+	if rA < 0 {
+		goto f172
+	}
+	a("COM executable for MS-DOS, Compack compressed")
+f172:
+	if len(out) > 0 {
+		return out
+	}
+	sb = gp(r, po, 24)
+	sm[0] = 0
+	if len(sb) > 0 {
+		switch sb[0] {
+		case 76:
+			if len(sb) > 1 && sb[1] == 90 {
+				sm[0] |= 0x1
+			}
+		case 208:
+			if len(sb) >= 8 && string(sb[1:8]) == "\xcf\x11ࡱ\x1a\xe1" {
+				if len(sb) > 8 {
+					switch sb[8] {
+					case 1:
+						if len(sb) >= 24 && string(sb[9:24]) == "\x02\x01\r\x00\x02\x00\x00\x06\x0e+4\x03\x02\x01\x01" {
+							sm[0] |= 0x4
+						}
+					case 65:
+						if len(sb) >= 24 && string(sb[9:24]) == "AFB\r\x00OM\x06\x0e+4\x01\x01\x01\xff" {
+							sm[0] |= 0x2
+						}
+					}
+				}
+			}
+		}
+	}
+	if sm[0]&0x1 == 0 {
+		goto f173
+	}
+	if ix(st, r, &tx) {
+		goto f173
+	}
+	rA = gt(r, po, "LZ", 32, 0)
+	if rA < 0 {
+		goto f173
+	}
+	a("MS-DOS executable (built-in)")
+f173:
+	if len(out) > 0 {
+		return out
+	}
+	if sm[0]&0x2 == 0 {
+		goto f174
+	}
+	if ix(st, r, &tx) {
+		goto f174
+	}
+	rA = gt(r, po, "\xd0\xcf\x11ࡱ\x1a\xe1AAFB\r\x00OM\x06\x0e+4\x01\x01\x01\xff", 32, 0)
+	if rA < 0 {
+		goto f174
+	}
+	a("AAF legacy file using MS Structured Storage")
+	rc, m = w1l(st, r, hw, po, po+30)
+	switch rc {
+	case 9:
+		a("(512B sectors)")
+	case 12:
+		a("(4kB sectors)")
+	default:
+		{
+			goto f175
+		}
+	}
+f175:
+f174:
+	if len(out) > 0 {
+		return out
+	}
+	if sm[0]&0x4 == 0 {
+		goto f177
+	}
+	if ix(st, r, &tx) {
+		goto f177
+	}
+	rA = gt(r, po, "\xd0\xcf\x11ࡱ\x1a\xe1\x01\x02\x01\r\x00\x02\x00\x00\x06\x0e+4\x03\x02\x01\x01", 32, 0)
+	if rA < 0 {
+		goto f177
+	}
+	a("AAF file using MS Structured Storage")
+	rc, m = w1l(st, r, hw, po, po+30)
+	switch rc {
+	case 9:
+		a("(512B sectors)")
+	case 12:
+		a("(4kB sectors)")
+	default:
+		{
+			goto f178
+		}
+	}
+f178:
+f177:
+	if len(out) > 0 {
+		return out
+	}
+	sb = gp(r, po+2080, 27)
+	sm[0] = 0
+	if len(sb) > 0 {
+		switch sb[0] {
+		case 68:
+			if len(sb) >= 26 && string(sb[1:26]) == "ocumento Microsoft Word 6" {
+				sm[0] |= 0x2
+			}
+		case 77:
+			if len(sb) >= 27 && string(sb[1:27]) == "icrosoft Word 6.0 Document" {
+				sm[0] |= 0x1
+			}
+		}
+	}
+	if sm[0]&0x1 == 0 {
+		goto f17a
+	}
+	sv = gs(r, po+2080, 96)
+	rA = gt(r, po+2080, "Microsoft Word 6.0 Document", 0, 0)
+	if rA < 0 {
+		goto f17a
+	}
+	a(wizardry.FormatDescription("%s", wizardry.StringTestFlags(0).Printable(sv)))
+f17a:
+	if len(out) > 0 {
+		return out
+	}
+	if sm[0]&0x2 == 0 {
+		goto f17b
+	}
+	rA = gt(r, po+2080, "Documento Microsoft Word 6", 0, 0)
+	if rA < 0 {
+		goto f17b
+	}
+	a("Spanish Microsoft Word 6 document data")
+f17b:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po+2112, "MSWordDoc", 0, 0)
+	if rA < 0 {
+		goto f17c
+	}
+	a("Microsoft Word document data")
+f17c:
+	if len(out) > 0 {
+		return out
+	}
+	rc, m = w4b(st, r, hw, po, po)
+	if !(m && rc == 834535424) {
+		goto f17d
+	}
+	a("Microsoft Word Document")
+f17d:
+	if len(out) > 0 {
+		return out
+	}
+	if ix(st, r, &tx) {
+		goto f17e
+	}
+	rA = gt(r, po, "PO^Q`", 32, 0)
+	if rA < 0 {
+		goto f17e
+	}
+	a("Microsoft Word 6.0 Document")
+f17e:
+	if len(out) > 0 {
+		return out
+	}
+	rc, m = w4l(st, r, hw, po, po+4)
+	if !(m && rc == 0) {
+		goto f17f
+	}
+	rc, m = w4b(st, r, hw, po, po)
+	if !(m && rc == 4264689664) {
+		goto f180
+	}
+	a("Microsoft Word for Macintosh 1.0")
+f180:
+	if !(m && rc == 4264820736) {
+		goto f181
+	}
+	a("Microsoft Word for Macintosh 3.0")
+f181:
+	if !(m && rc == 4265017372) {
+		goto f182
+	}
+	a("Microsoft Word for Macintosh 4.0")
+f182:
+	if !(m && rc == 4265017379) {
+		goto f183
+	}
+	a("Microsoft Word for Macintosh 5.0")
+f183:
+f17f:
+	if len(out) > 0 {
+		return out
+	}
+	if ix(st, r, &tx) {
+		goto f184
+	}
+	rA = gt(r, po, "ۥ-\x00\x00\x00", 32, 0)
+	if rA < 0 {
+		goto f184
+	}
+	a("Microsoft Word 2.0 Document")
+f184:
+	if len(out) > 0 {
+		return out
+	}
+	if ix(st, r, &tx) {
+		goto f185
+	}
+	rA = gt(r, po+512, "\xec\xa5\xc1", 32, 0)
+	if rA < 0 {
+		goto f185
+	}
+	a("Microsoft Word Document")
+f185:
+	if len(out) > 0 {
+		return out
+	}
+	if ix(st, r, &tx) {
+		goto f186
+	}
+	rA = gt(r, po, "ۥ-\x00", 32, 0)
+	if rA < 0 {
+		goto f186
+	}
+	a("Microsoft WinWord 2.0 Document")
+f186:
+	if len(out) > 0 {
+		return out
+	}
+	sv = gs(r, po+2080, 96)
+	rA = gt(r, po+2080, "Microsoft Excel 5.0 Worksheet", 0, 0)
+	if rA < 0 {
+		goto f187
+	}
+	a(wizardry.FormatDescription("%s", wizardry.StringTestFlags(0).Printable(sv)))
+f187:
+	if len(out) > 0 {
+		return out
+	}
+	if ix(st, r, &tx) {
+		goto f188
+	}
+	rA = gt(r, po, "ۥ-\x00", 32, 0)
+	if rA < 0 {
+		goto f188
+	}
+	a("Microsoft WinWord 2.0 Document")
+f188:
+	if len(out) > 0 {
+		return out
+	}
+	sv = gs(r, po+2080, 96)
+	rA = gt(r, po+2080, "Foglio di lavoro Microsoft Exce", 0, 0)
+	if rA < 0 {
+		goto f189
+	}
+	a(wizardry.FormatDescription("%s", wizardry.StringTestFlags(0).Printable(sv)))
+f189:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po+2114, "Biff5", 0, 0)
+	if rA < 0 {
+		goto f18a
+	}
+	a("Microsoft Excel 5.0 Worksheet")
+f18a:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po+2121, "Biff5", 0, 0)
+	if rA < 0 {
+		goto f18b
+	}
+	a("Microsoft Excel 5.0 Worksheet")
+f18b:
+	if len(out) > 0 {
+		return out
+	}
+	switch hb {
+	case 0x00:
+		rc, m = w4b(st, r, hw, po, po)
+		if !(m && rc == 6656) {
+			goto f18d
+		}
+		rc, m = w1l(st, r, hw, po, po+20)
+		if !(m && rc > 0) {
+			goto f18e
+		}
+		if !(m && rc < 32) {
+			goto f18f
+		}
+		a("Lotus 1-2-3")
+		d[2] = f
+		rc, m = w2l(st, r, hw, po, po+4)
+		if !(m && rc == 4096) {
+			goto f190
+		}
+		a("WorKsheet, version 3")
+		d[2] = t
+	f190:
+		if !(m && rc == 4098) {
+			goto f191
+		}
+		a("WorKsheet, version 4")
+		d[2] = t
+	f191:
+		if !(m && rc == 4099) {
+			goto f192
+		}
+		a("WorKsheet, version 97")
+		d[2] = t
+	f192:
+		if !(m && rc == 4101) {
+			goto f193
+		}
+		a("WorKsheet, version 9.8 Millennium")
+		d[2] = t
+	f193:
+		if !(m && rc == 32769) {
+			goto f194
+		}
+		a("FoRMatting data")
+		d[2] = t
+	f194:
+		if !(m && rc == 32775) {
+			goto f195
+		}
+		a("ForMatting data, version 3")
+		d[2] = t
+	f195:
+		if d[2] {
+			goto f196
+		}
+		a("unknown")
+		rc, m = w2l(st, r, hw, po, po+6)
+		if !(m && rc == 4) {
+			goto f197
+		}
+		a("worksheet")
+	f197:
+		if !(m && rc != 4) {
+			goto f198
+		}
+		a("formatting data")
+	f198:
+		rc, m = w2l(st, r, hw, po, po+4)
+		if !m {
+			goto f199
+		}
+		a(wizardry.FormatDescription("\\b, revision 0x%x", rc))
+	f199:
+		d[2] = t
+	f196:
+		rc, m = w2l(st, r, hw, po, po+6)
+		if !(m && rc == 4) {
+			goto f19a
+		}
+		a("\\b, cell range")
+		rc, m = w4l(st, r, hw, po, po+8)
+		if !(m && rc != 0) {
+			goto f19b
+		}
+		rc, m = w1l(st, r, hw, po, po+10)
+		if !(m && rc > 0) {
+			goto f19c
+		}
+		a(wizardry.FormatDescription("\\b%d*", rc))
+	f19c:
+		rc, m = w2l(st, r, hw, po, po+8)
+		if !m {
+			goto f19d
+		}
+		a(wizardry.FormatDescription("\\b%d,", rc))
+	f19d:
+		rc, m = w1l(st, r, hw, po, po+11)
+		if !m {
+			goto f19e
+		}
+		a(wizardry.FormatDescription("\\b%d-", rc))
+	f19e:
+	f19b:
+		rc, m = w1l(st, r, hw, po, po+14)
+		if !(m && rc > 0) {
+			goto f19f
+		}
+		a(wizardry.FormatDescription("\\b%d*", rc))
+	f19f:
+		rc, m = w2l(st, r, hw, po, po+12)
+		if !m {
+			goto f1a0
+		}
+		a(wizardry.FormatDescription("\\b%d,", rc))
+	f1a0:
+		rc, m = w1l(st, r, hw, po, po+15)
+		if !m {
+			goto f1a1
+		}
+		a(wizardry.FormatDescription("\\b%d", rc))
+	f1a1:
+		rc, m = w1l(st, r, hw, po, po+20)
+		if !(m && rc > 1) {
+			goto f1a2
+		}
+		a(wizardry.FormatDescription("\\b, character set 0x%x", rc))
+	f1a2:
+		rc, m = w1l(st, r, hw, po, po+21)
+		if !m {
+			goto f1a3
+		}
+		a(wizardry.FormatDescription("\\b, flags 0x%x", rc))
+	f1a3:
+		d[2] = t
+	f19a:
+		if !(m && rc != 4) {
+			goto f1a4
+		}
+		rA, rB = ht(r, po+30, 29, "\x00\xae", 0)
+		if rA < 0 {
+			goto f1a5
+		}
+		gf[4] = po + 30 + rA + rB
+		sv = gs(r, gf[4]+4, 96)
+		rA = gt(r, gf[4]+4, "\x00", 0, 2)
+		if rA < 0 {
+			goto f1a6
+		}
+		a(wizardry.FormatDescription("\\b, 1st font \"%s\"", wizardry.StringTestFlags(0).Printable(sv)))
+	f1a6:
+	f1a5:
+		d[2] = t
+	f1a4:
+	f18f:
+	f18e:
+	f18d:
+		if len(out) > 0 {
+			return out
+		}
+		rc, m = w4b(st, r, hw, po, po)
+		if !(m && rc == 512) {
+			goto f1a7
+		}
+		rc, m = w1l(st, r, hw, po, po+7)
+		if !(m && rc == 0) {
+			goto f1a8
+		}
+		rc, m = w1l(st, r, hw, po, po+6)
+		if !(m && rc > 0) {
+			goto f1a9
+		}
+		a("Lotus")
+		d[2] = f
+		rc, m = w2l(st, r, hw, po, po+4)
+		if !(m && rc == 7) {
+			goto f1aa
+		}
+		a("1-2-3 CoNFiguration, version 2.x (PGRAPH.CNF)")
+		d[2] = t
+	f1aa:
+		if !(m && rc == 3077) {
+			goto f1ab
+		}
+		a("1-2-3 CoNFiguration, version 2.4J")
+		d[2] = t
+	f1ab:
+		if !(m && rc == 2049) {
+			goto f1ac
+		}
+		a("1-2-3 CoNFiguration, version 1-2.1")
+		d[2] = t
+	f1ac:
+		if !(m && rc == 2050) {
+			goto f1ad
+		}
+		a("Symphony CoNFiguration")
+		d[2] = t
+	f1ad:
+		if !(m && rc == 2052) {
+			goto f1ae
+		}
+		a("1-2-3 CoNFiguration, version 2.2")
+		d[2] = t
+	f1ae:
+		if !(m && rc == 2058) {
+			goto f1af
+		}
+		a("1-2-3 CoNFiguration, version 2.3-2.4")
+		d[2] = t
+	f1af:
+		if !(m && rc == 5122) {
+			goto f1b0
+		}
+		a("1-2-3 CoNFiguration, version 3.x")
+		d[2] = t
+	f1b0:
+		if !(m && rc == 5200) {
+			goto f1b1
+		}
+		a("1-2-3 CoNFiguration, version 4.x")
+		d[2] = t
+	f1b1:
+		if !(m && rc == 1028) {
+			goto f1b2
+		}
+		a("1-2-3 WorKSheet, version 1")
+		d[2] = t
+	f1b2:
+		if !(m && rc == 1029) {
+			goto f1b3
+		}
+		a("Symphony WoRksheet, version 1.0")
+		d[2] = t
+	f1b3:
+		if !(m && rc == 1030) {
+			goto f1b4
+		}
+		a("1-2-3/Symphony worksheet, version 2")
+		d[2] = t
+	f1b4:
+		if !(m && rc == 1536) {
+			goto f1b5
+		}
+		a("1-2-3 WorKsheet, version 1.xJ")
+		d[2] = t
+	f1b5:
+		if !(m && rc == 1538) {
+			goto f1b6
+		}
+		a("1-2-3 worksheet, version 2.4J")
+		d[2] = t
+	f1b6:
+		if !(m && rc == 32774) {
+			goto f1b7
+		}
+		a("1-2-3 ForMaTting data, version 2.x")
+		d[2] = t
+	f1b7:
+		if !(m && rc == 32775) {
+			goto f1b8
+		}
+		a("1-2-3 FoRMatting data, version 2.0")
+		d[2] = t
+	f1b8:
+		if d[2] {
+			goto f1b9
+		}
+		a("unknown worksheet or configuration")
+		rc, m = w2l(st, r, hw, po, po+4)
+		if !m {
+			goto f1ba
+		}
+		a(wizardry.FormatDescription("\\b, revision 0x%x", rc))
+	f1ba:
+		d[2] = t
+	f1b9:
+		if !st.gw.EnterUse() {
+			goto f1bb
+		}
+		ss = identifyLotusCells(st, r, po+6, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f1bb
+		}
+		a(ss...)
+		d[2] = t
+	f1bb:
+		ra, k = w2l(st, r, hw, po, 8)
+		if !k {
+			goto f1bc
+		}
+		if !st.gw.EnterUse() {
+			goto f1bc
+		}
+		ss = identifyLotusCells(st, r, int64(ra)+10, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f1bc
+		}
+		a(ss...)
+		d[2] = t
+	f1bc:
+	f1a9:
+	f1a8:
+	f1a7:
+		if len(out) > 0 {
+			return out
+		}
+		rc, m = w4b(st, r, hw, po, po)
+		if !(m && rc == 256) {
+			goto f1d0
+		}
+		rc, m = w1l(st, r, hw, po, po+9)
+		if !(m && rc == 0) {
+			goto f1d1
+		}
+		if !st.gw.EnterUse() {
+			goto f1d3
+		}
+		ss = identifyCurIcoDir(st, r, po, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f1d3
+		}
+		a(ss...)
+	f1d3:
+	f1d1:
+		if !(m && rc == 255) {
+			goto f1d4
+		}
+		if !st.gw.EnterUse() {
+			goto f1d6
+		}
+		ss = identifyCurIcoDir(st, r, po, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f1d6
+		}
+		a(ss...)
+	f1d6:
+	f1d4:
+	f1d0:
+		if len(out) > 0 {
+			return out
+		}
+		rc, m = w4b(st, r, hw, po, po)
+		if !(m && rc == 512) {
+			goto f1d7
+		}
+		rc, m = w1l(st, r, hw, po, po+9)
+		if !(m && rc == 0) {
+			goto f1d8
+		}
+		if !st.gw.EnterUse() {
+			goto f1d9
+		}
+		ss = identifyCurIcoDir(st, r, po, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f1d9
+		}
+		a(ss...)
+	f1d9:
+	f1d8:
+		if !(m && rc == 255) {
+			goto f1da
+		}
+		if !st.gw.EnterUse() {
+			goto f1db
+		}
+		ss = identifyCurIcoDir(st, r, po, fd)
+		st.gw.ExitUse()
+		if len(ss) == 0 {
+			goto f1db
+		}
+		a(ss...)
+	f1db:
+	f1da:
+	f1d7:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x01:
+		if ix(st, r, &tx) {
+			goto f1c4
+		}
+		rA = gt(r, po, "\x01\x00\t\x00", 32, 0)
+		if rA < 0 {
+			goto f1c4
+		}
+		a("ms-windows metafont .wmf")
+	f1c4:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x02:
+		if ix(st, r, &tx) {
+			goto f1c3
+		}
+		rA = gt(r, po, "\x02\x00\t\x00", 32, 0)
+		if rA < 0 {
+			goto f1c3
+		}
+		a("ms-windows metafont .wmf")
+	f1c3:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x03:
+		sb = gp(r, po, 8)
+		sm[0] = 0
+		if len(sb) > 0 && sb[0] == 3 {
+			if len(sb) > 1 {
+				switch sb[1] {
+				case 1:
+					if len(sb) >= 8 && string(sb[2:8]) == "\x01\x048\x01\x00\x00" {
+						sm[0] |= 0x1
+					}
+				case 2:
+					if len(sb) >= 8 && string(sb[2:8]) == "\x01\x048\x01\x00\x00" {
+						sm[0] |= 0x2
+					}
+				case 3:
+					if len(sb) >= 8 && string(sb[2:8]) == "\x01\x048\x01\x00\x00" {
+						sm[0] |= 0x4
+					}
+				}
+			}
+		}
+		if sm[0]&0x1 == 0 {
+			goto f1c5
+		}
+		if ix(st, r, &tx) {
+			goto f1c5
+		}
+		rA = gt(r, po, "\x03\x01\x01\x048\x01\x00\x00", 32, 0)
+		if rA < 0 {
+			goto f1c5
+		}
+		a("tz3 ms-works file")
+	f1c5:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x2 == 0 {
+			goto f1c6
+		}
+		if ix(st, r, &tx) {
+			goto f1c6
+		}
+		rA = gt(r, po, "\x03\x02\x01\x048\x01\x00\x00", 32, 0)
+		if rA < 0 {
+			goto f1c6
+		}
+		a("tz3 ms-works file")
+	f1c6:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x4 == 0 {
+			goto f1c7
+		}
+		if ix(st, r, &tx) {
+			goto f1c7
+		}
+		rA = gt(r, po, "\x03\x03\x01\x048\x01\x00\x00", 32, 0)
+		if rA < 0 {
+			goto f1c7
+		}
+		a("tz3 ms-works file")
+	f1c7:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x04:
+		rc, m = w4l(st, r, hw, po, po)
+		if !(m && rc == 4) {
+			goto f1e0
+		}
+		rc, m = w4l(st, r, hw, po, po+12)
+		if !(m && rc == 280) {
+			goto f1e1
+		}
+		a("Windows Recycle Bin INFO2 file (Win98 or below)")
+	f1e1:
+	f1e0:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x05:
+		rc, m = w4l(st, r, hw, po, po)
+		if !(m && rc == 5) {
+			goto f1e2
+		}
+		rc, m = w4l(st, r, hw, po, po+12)
+		if !(m && rc == 800) {
+			goto f1e3
+		}
+		a("Windows Recycle Bin INFO2 file (Win2k - WinXP)")
+	f1e3:
+	f1e2:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x09:
+		if ix(st, r, &tx) {
+			goto f18c
+		}
+		rA = gt(r, po, "\t\x04\x06\x00\x00\x00\x10\x00", 32, 0)
+		if rA < 0 {
+			goto f18c
+		}
+		a("Microsoft Excel Worksheet")
+	f18c:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x42:
+		if ix(st, r, &tx) {
+			goto f1cf
+		}
+		rA = gt(r, po, "BA(\x00\x00\x00.\x00\x00\x00\x00\x00\x00\x00", 32, 0)
+		if rA < 0 {
+			goto f1cf
+		}
+		a("Icon for MS Windows")
+	f1cf:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x4d:
+		if ix(st, r, &tx) {
+			goto f1ce
+		}
+		rA = gt(r, po, "MDIF\x1a\x00\b\x00\x00\x00\xfa&@}\x01\x00\x01\x1e\x01\x00", 32, 0)
+		if rA < 0 {
+			goto f1ce
+		}
+		a("MS Windows special zipped file")
+	f1ce:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x4e:
+		if ix(st, r, &tx) {
+			goto f1c1
+		}
+		rA = gt(r, po, "Nullsoft AVS Preset ", 32, 0)
+		if rA < 0 {
+			goto f1c1
+		}
+		a("Winamp plug in")
+	f1c1:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x50:
+		if ix(st, r, &tx) {
+			goto f1dc
+		}
+		rA = gt(r, po, "PK\b\bBGI", 32, 0)
+		if rA < 0 {
+			goto f1dc
+		}
+		a("Borland font")
+		sv = gs(r, po+4, 96)
+		rA = gt(r, po+4, "\x00", 0, 2)
+		if rA < 0 {
+			goto f1dd
+		}
+		a(wizardry.FormatDescription("%s", wizardry.StringTestFlags(0).Printable(sv)))
+	f1dd:
+	f1dc:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x57:
+		sb = gp(r, po, 9)
+		sm[0] = 0
+		if len(sb) >= 7 && string(sb[0:7]) == "WordPro" {
+			if len(sb) > 7 {
+				switch sb[7] {
+				case 0:
+					sm[0] |= 0x1
+				case 13:
+					if len(sb) > 8 && sb[8] == 251 {
+						sm[0] |= 0x2
+					}
+				}
+			}
+		}
+		if sm[0]&0x1 == 0 {
+			goto f1bd
+		}
+		if ix(st, r, &tx) {
+			goto f1bd
+		}
+		rA = gt(r, po, "WordPro\x00", 32, 0)
+		if rA < 0 {
+			goto f1bd
+		}
+		a("Lotus WordPro")
+	f1bd:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x2 == 0 {
+			goto f1be
+		}
+		if ix(st, r, &tx) {
+			goto f1be
+		}
+		rA = gt(r, po, "WordPro\r\xfb", 32, 0)
+		if rA < 0 {
+			goto f1be
+		}
+		a("Lotus WordPro")
+	f1be:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x70:
+		if ix(st, r, &tx) {
+			goto f1de
+		}
+		rA = gt(r, po, "pk\b\bBGI", 32, 0)
+		if rA < 0 {
+			goto f1de
+		}
+		a("Borland device")
+		sv = gs(r, po+4, 96)
+		rA = gt(r, po+4, "\x00", 0, 2)
+		if rA < 0 {
+			goto f1df
+		}
+		a(wizardry.FormatDescription("%s", wizardry.StringTestFlags(0).Printable(sv)))
+	f1df:
+	f1de:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x71:
+		rA = gt(r, po, "q\xa8\x00\x00\x01\x02", 0, 0)
+		if rA < 0 {
+			goto f1bf
+		}
+		rA = gt(r, po+12, "Stirling Technologies,", 0, 0)
+		if rA < 0 {
+			goto f1c0
+		}
+		a("InstallShield Uninstall Script")
+	f1c0:
+	f1bf:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x89:
+		sb = gp(r, po, 20)
+		sm[0] = 0
+		if len(sb) >= 2 && string(sb[0:2]) == "\x89\x00" {
+			if len(sb) > 2 {
+				switch sb[2] {
+				case 63:
+					if len(sb) >= 9 && string(sb[3:9]) == "\x03\x05\x003\x9fW" {
+						if len(sb) > 9 {
+							switch sb[9] {
+							case 53:
+								if len(sb) >= 20 && string(sb[10:20]) == "\x17\xb6i4\x05%A\x9b\x11\x02" {
+									sm[0] |= 0x1
+								}
+							case 54:
+								if len(sb) >= 20 && string(sb[10:20]) == "\x17\xb6i4\x05%A\x9b\x11\x02" {
+									sm[0] |= 0x2
+								}
+							case 55:
+								if len(sb) >= 20 && string(sb[10:20]) == "\x17\xb6i4\x05%A\x9b\x11\x02" {
+									sm[0] |= 0x4
+								}
+							case 56:
+								if len(sb) >= 20 && string(sb[10:20]) == "\x17\xb6i4\x05%A\x9b\x11\x02" {
+									sm[0] |= 0x8
+								}
+							case 57:
+								if len(sb) >= 20 && string(sb[10:20]) == "\x17\xb6i4\x05%A\x9b\x11\x02" {
+									sm[0] |= 0x10
+								}
+							}
+						}
+					}
+				case 149:
+					if len(sb) >= 13 && string(sb[3:13]) == "\x03\x05\x002R\x87\xc4@\xe5\"" {
+						sm[0] |= 0x20
+					}
+				}
+			}
+		}
+		if sm[0]&0x1 == 0 {
+			goto f1c8
+		}
+		rA = gt(r, po, "\x89\x00?\x03\x05\x003\x9fW5\x17\xb6i4\x05%A\x9b\x11\x02", 0, 0)
+		if rA < 0 {
+			goto f1c8
+		}
+		a("PGP sig")
+	f1c8:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x2 == 0 {
+			goto f1c9
+		}
+		rA = gt(r, po, "\x89\x00?\x03\x05\x003\x9fW6\x17\xb6i4\x05%A\x9b\x11\x02", 0, 0)
+		if rA < 0 {
+			goto f1c9
+		}
+		a("PGP sig")
+	f1c9:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x4 == 0 {
+			goto f1ca
+		}
+		rA = gt(r, po, "\x89\x00?\x03\x05\x003\x9fW7\x17\xb6i4\x05%A\x9b\x11\x02", 0, 0)
+		if rA < 0 {
+			goto f1ca
+		}
+		a("PGP sig")
+	f1ca:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x8 == 0 {
+			goto f1cb
+		}
+		rA = gt(r, po, "\x89\x00?\x03\x05\x003\x9fW8\x17\xb6i4\x05%A\x9b\x11\x02", 0, 0)
+		if rA < 0 {
+			goto f1cb
+		}
+		a("PGP sig")
+	f1cb:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x10 == 0 {
+			goto f1cc
+		}
+		rA = gt(r, po, "\x89\x00?\x03\x05\x003\x9fW9\x17\xb6i4\x05%A\x9b\x11\x02", 0, 0)
+		if rA < 0 {
+			goto f1cc
+		}
+		a("PGP sig")
+	f1cc:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x20 == 0 {
+			goto f1cd
+		}
+		rA = gt(r, po, "\x89\x00\x95\x03\x05\x002R\x87\xc4@\xe5\"", 0, 0)
+		if rA < 0 {
+			goto f1cd
+		}
+		a("PGP sig")
+	f1cd:
+		if len(out) > 0 {
+			return out
+		}
+	case 0xd7:
+		if ix(st, r, &tx) {
+			goto f1c2
+		}
+		rA = gt(r, po, "\xd7\xcdƚ", 32, 0)
+		if rA < 0 {
+			goto f1c2
+		}
+		a("ms-windows metafont .wmf")
+	f1c2:
+		if len(out) > 0 {
+			return out
+		}
+	}
+	sb = gp(r, po+9, 10)
+	sm[0] = 0
+	if len(sb) >= 6 && string(sb[0:6]) == "GERBIL" {
+		if len(sb) > 6 {
+			switch sb[6] {
+			case 67:
+				if len(sb) >= 10 && string(sb[7:10]) == "LIP" {
+					sm[0] |= 0x4
+				}
+			case 68:
+				if len(sb) > 7 {
+					switch sb[7] {
+					case 66:
+						sm[0] |= 0x2
+					case 79:
+						if len(sb) > 8 && sb[8] == 67 {
+							sm[0] |= 0x1
+						}
+					}
+				}
+			}
+		}
+	}
+	if sm[0]&0x1 == 0 {
+		goto f1e4
+	}
+	rA = gt(r, po+9, "GERBILDOC", 0, 0)
+	if rA < 0 {
+		goto f1e4
+	}
+	a("First Choice document")
+f1e4:
+	if len(out) > 0 {
+		return out
+	}
+	if sm[0]&0x2 == 0 {
+		goto f1e5
+	}
+	rA = gt(r, po+9, "GERBILDB", 0, 0)
+	if rA < 0 {
+		goto f1e5
+	}
+	a("First Choice database")
+f1e5:
+	if len(out) > 0 {
+		return out
+	}
+	if sm[0]&0x4 == 0 {
+		goto f1e6
+	}
+	rA = gt(r, po+9, "GERBILCLIP", 0, 0)
+	if rA < 0 {
+		goto f1e6
+	}
+	a("First Choice database")
+f1e6:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po, "GERBIL", 0, 0)
+	if rA < 0 {
+		goto f1e7
+	}
+	a("First Choice device file")
+f1e7:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po+9, "RABBITGRAPH", 0, 0)
+	if rA < 0 {
+		goto f1e8
+	}
+	a("RabbitGraph file")
+f1e8:
+	if len(out) > 0 {
+		return out
+	}
+	switch hb {
+	case 0x21:
+		sb = gp(r, po, 9)
+		sm[0] = 0
+		if len(sb) >= 7 && string(sb[0:7]) == "!<spell" {
+			if len(sb) > 7 {
+				switch sb[7] {
+				case 50:
+					if len(sb) > 8 && sb[8] == 62 {
+						sm[0] |= 0x2
+					}
+				case 62:
+					sm[0] |= 0x1
+				}
+			}
+		}
+		if sm[0]&0x1 == 0 {
+			goto f1ea
+		}
+		rA = gt(r, po, "!<spell>", 0, 0)
+		if rA < 0 {
+			goto f1ea
+		}
+		a("MKS Spell hash list (old format)")
+	f1ea:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x2 == 0 {
+			goto f1eb
+		}
+		rA = gt(r, po, "!<spell2>", 0, 0)
+		if rA < 0 {
+			goto f1eb
+		}
+		a("MKS Spell hash list")
+	f1eb:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x44:
+		rA = gt(r, po, "DCU1", 0, 0)
+		if rA < 0 {
+			goto f1e9
+		}
+		a("Borland Delphi .DCU file")
+	f1e9:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x50:
+		rc, m = w4l(st, r, hw, po, po)
+		if !(m && rc == 134761296) {
+			goto f1ed
+		}
+		a("TurboC Font file")
+	f1ed:
+		if len(out) > 0 {
+			return out
+		}
+		rA = gt(r, po, "PMCC", 0, 0)
+		if rA < 0 {
+			goto f1ef
+		}
+		a("Windows 3.x .GRP file")
+	f1ef:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x54:
+		rA = gt(r, po, "TPF0", 0, 0)
+		if rA < 0 {
+			goto f1ee
+		}
+	f1ee:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x70:
+		rc, m = w4l(st, r, hw, po, po)
+		if !(m && rc == 134769520) {
+			goto f1ec
+		}
+		a("TurboC BGI file")
+	f1ec:
+		if len(out) > 0 {
+			return out
+		}
+	}
+	rA = gt(r, po+1, "RDC-meg", 0, 0)
+	if rA < 0 {
+		goto f1f0
+	}
+	a("MegaDots")
+	rc, m = w1l(st, r, hw, po, po+8)
+	if !(m && int64(int8(rc)) > 47) {
+		goto f1f1
+	}
+	a(wizardry.FormatDescription("version %c", int64(int8(rc))))
+f1f1:
+	rc, m = w1l(st, r, hw, po, po+9)
+	if !(m && int64(int8(rc)) > 47) {
+		goto f1f2
+	}
+	a(wizardry.FormatDescription("\\b.%c file", int64(int8(rc))))
+f1f2:
+f1f0:
+	if len(out) > 0 {
+		return out
+	}
+	rc, m = w4l(st, r, hw, po, po)
+	if !(m && rc == 76) {
+		goto f1f3
+	}
+	rc, m = w4l(st, r, hw, po, po+4)
+	if !(m && rc == 136193) {
+		goto f1f4
+	}
+	a("Windows shortcut file")
+f1f4:
+f1f3:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po+369, "MICROSOFT PIFEX\x00", 0, 0)
+	if rA < 0 {
+		goto f1f5
+	}
+	a("Windows Program Information File")
+	sv = gs(r, po+36, 96)
+	rA = gt(r, po+36, "\x00", 0, 2)
+	if rA < 0 {
+		goto f1f6
+	}
+	a(wizardry.FormatDescription("\\b for %.63s", wizardry.StringTestFlags(0).Printable(sv)))
+f1f6:
+	sv = gs(r, po+101, 96)
+	rA = gt(r, po+101, "\x00", 0, 2)
+	if rA < 0 {
+		goto f1f7
+	}
+	a(wizardry.FormatDescription("\\b, directory=%.64s", wizardry.StringTestFlags(0).Printable(sv)))
+f1f7:
+	sv = gs(r, po+165, 96)
+	rA = gt(r, po+165, "\x00", 0, 2)
+	if rA < 0 {
+		goto f1f8
+	}
+	a(wizardry.FormatDescription("\\b, parameters=%.64s", wizardry.StringTestFlags(0).Printable(sv)))
+f1f8:
+	rA, rB = ht(r, po+391, 2901, "WINDOWS VMM 4.0\x00", 0)
+	if rA < 0 {
+		goto f1f9
+	}
+	gf[1] = po + 391 + rA + rB
+	rc, m = f1l(st, r, gf[1]+94)
+	if !(m && rc > 0) {
+		goto f1fa
+	}
+	gf[2] = gf[1] + 95
+	sv = gs(r, gf[2]+-1, 96)
+	rA = gt(r, gf[2]+-1, "PIFMGR.DLL", 0, 1)
+	if rA < 0 {
+		goto f1fb
+	}
+	a(wizardry.FormatDescription("\\b, icon=%s", wizardry.StringTestFlags(0).Printable(sv)))
+f1fb:
+	sv = gs(r, gf[2]+-1, 96)
+	rA = gt(r, gf[2]+-1, "PIFMGR.DLL", 0, 2)
+	if rA < 0 {
+		goto f1fc
+	}
+	a(wizardry.FormatDescription("\\b, icon=%s", wizardry.StringTestFlags(0).Printable(sv)))
+f1fc:
+f1fa:
+	rc, m = f1l(st, r, gf[1]+240)
+	if !(m && rc > 0) {
+		goto f1fd
+	}
+	gf[2] = gf[1] + 241
+	sv = gs(r, gf[2]+-1, 96)
+	rA = gt(r, gf[2]+-1, "Terminal", 0, 1)
+	if rA < 0 {
+		goto f1fe
+	}
+	a(wizardry.FormatDescription("\\b, font=%.32s", wizardry.StringTestFlags(0).Printable(sv)))
+f1fe:
+	sv = gs(r, gf[2]+-1, 96)
+	rA = gt(r, gf[2]+-1, "Terminal", 0, 2)
+	if rA < 0 {
+		goto f1ff
+	}
+	a(wizardry.FormatDescription("\\b, font=%.32s", wizardry.StringTestFlags(0).Printable(sv)))
+f1ff:
+f1fd:
+	rc, m = f1l(st, r, gf[1]+272)
+	if !(m && rc > 0) {
+		goto f200
+	}
+	gf[2] = gf[1] + 273
+	sv = gs(r, gf[2]+-1, 96)
+	rA = gt(r, gf[2]+-1, "Lucida Console", 0, 1)
+	if rA < 0 {
+		goto f201
+	}
+	a(wizardry.FormatDescription("\\b, TrueTypeFont=%.32s", wizardry.StringTestFlags(0).Printable(sv)))
+f201:
+	sv = gs(r, gf[2]+-1, 96)
+	rA = gt(r, gf[2]+-1, "Lucida Console", 0, 2)
+	if rA < 0 {
+		goto f202
+	}
+	a(wizardry.FormatDescription("\\b, TrueTypeFont=%.32s", wizardry.StringTestFlags(0).Printable(sv)))
+f202:
+f200:
+f1f9:
+	rA, rB = ht(r, po+391, 2901, "WINDOWS NT  3.1\x00", 0)
+	if rA < 0 {
+		goto f203
+	}
+	a("\\b, Windows NT-style")
+f203:
+	rA, rB = ht(r, po+391, 2901, "CONFIG  SYS 4.0\x00", 0)
+	if rA < 0 {
+		goto f204
+	}
+	a("\\b +CONFIG.SYS")
+f204:
+	rA, rB = ht(r, po+391, 2901, "AUTOEXECBAT 4.0\x00", 0)
+	if rA < 0 {
+		goto f205
+	}
+	a("\\b +AUTOEXEC.BAT")
+f205:
+f1f5:
+	if len(out) > 0 {
+		return out
+	}
+	switch hb {
+	case 0x08:
+		rc, m = w4l(st, r, hw, po, po)
+		if !(m && rc == 1212429320) {
+			goto f212
+		}
+		a("4DOS help file")
+		sv = gs(r, po+4, 96)
+		a(wizardry.FormatDescription("\\b, version %-4.4s", wizardry.StringTestFlags(0).Printable(sv)))
+	f212:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x49:
+		if ix(st, r, &tx) {
+			goto f215
+		}
+		rA = gt(r, po, "ITSF\x03\x00\x00\x00`\x00\x00\x00", 32, 0)
+		if rA < 0 {
+			goto f215
+		}
+		a("MS Windows HtmlHelp Data")
+	f215:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x4c:
+		rc, m = w8l(st, r, hw, po, po)
+		if !(m && rc == 16325548649369164) {
+			goto f214
+		}
+		a("MS Advisor help file")
+	f214:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x4e:
+		rA = gt(r, po, "NG\x00\x01", 0, 0)
+		if rA < 0 {
+			goto f20d
+		}
+		rc, m = w4l(st, r, hw, po, po+2)
+		if !(m && rc == 256) {
+			goto f20e
+		}
+		a("Norton Guide")
+		sv = gs(r, po+8, 96)
+		rA = gt(r, po+8, "\x00", 0, 2)
+		if rA < 0 {
+			goto f20f
+		}
+		a(wizardry.FormatDescription("\"%-.40s\"", wizardry.StringTestFlags(0).Printable(sv)))
+	f20f:
+		sv = gs(r, po+48, 96)
+		rA = gt(r, po+48, "\x00", 0, 2)
+		if rA < 0 {
+			goto f210
+		}
+		a(wizardry.FormatDescription("\\b, %-.66s", wizardry.StringTestFlags(0).Printable(sv)))
+	f210:
+		sv = gs(r, po+114, 96)
+		rA = gt(r, po+114, "\x00", 0, 2)
+		if rA < 0 {
+			goto f211
+		}
+		a(wizardry.FormatDescription("%-.66s", wizardry.StringTestFlags(0).Printable(sv)))
+	f211:
+	f20e:
+	f20d:
+		if len(out) > 0 {
+			return out
+		}
+	case 0xc5:
+		rc, m = w4b(st, r, hw, po, po)
+		if !(m && rc == 3318797254) {
+			goto f206
+		}
+		a("DOS EPS Binary File")
+		rc, m = w4l(st, r, hw, po, po+4)
+		if !(m && int64(int32(rc)) > 0) {
+			goto f207
+		}
+		a(wizardry.FormatDescription("Postscript starts at byte %d", int64(int32(rc))))
+		rc, m = w4l(st, r, hw, po, po+8)
+		if !(m && int64(int32(rc)) > 0) {
+			goto f208
+		}
+		a(wizardry.FormatDescription("length %d", int64(int32(rc))))
+		rc, m = w4l(st, r, hw, po, po+12)
+		if !(m && int64(int32(rc)) > 0) {
+			goto f209
+		}
+		a(wizardry.FormatDescription("Metafile starts at byte %d", int64(int32(rc))))
+		rc, m = w4l(st, r, hw, po, po+16)
+		if !(m && int64(int32(rc)) > 0) {
+			goto f20a
+		}
+		a(wizardry.FormatDescription("length %d", int64(int32(rc))))
+	f20a:
+	f209:
+		rc, m = w4l(st, r, hw, po, po+20)
+		if !(m && int64(int32(rc)) > 0) {
+			goto f20b
+		}
+		a(wizardry.FormatDescription("TIFF starts at byte %d", int64(int32(rc))))
+		rc, m = w4l(st, r, hw, po, po+24)
+		if !(m && int64(int32(rc)) > 0) {
+			goto f20c
+		}
+		a(wizardry.FormatDescription("length %d", int64(int32(rc))))
+	f20c:
+	f20b:
+	f208:
+	f207:
+	f206:
+		if len(out) > 0 {
+			return out
+		}
+	}
+	if ix(st, r, &tx) {
+		goto f216
+	}
+	rA = gt(r, po+2, "GFA-BASIC3", 32, 0)
+	if rA < 0 {
+		goto f216
+	}
+	a("GFA-BASIC 3 data")
+f216:
+	if len(out) > 0 {
+		return out
+	}
+	switch hb {
+	case 0x01:
+		rc, m = w4l(st, r, hw, po, po)
+		if !(m && rc == 1) {
+			goto f22d
+		}
+		rA = gt(r, po+40, " EMF", 0, 0)
+		if rA < 0 {
+			goto f22e
+		}
+		a("Windows Enhanced Metafile (EMF) image data")
+		rc, m = w4l(st, r, hw, po, po+44)
+		if !m {
+			goto f22f
+		}
+		a(wizardry.FormatDescription("version 0x%x", rc))
+	f22f:
+	f22e:
+	f22d:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x49:
+		if ix(st, r, &tx) {
+			goto f21b
+		}
+		rA = gt(r, po, "ISc(", 32, 0)
+		if rA < 0 {
+			goto f21b
+		}
+		a("InstallShield Cabinet archive data")
+		rc, m = w1l(st, r, hw, po, po+5)
+		if !(m && rc&240 == 96) {
+			goto f21c
+		}
+		a("version 6,")
+	f21c:
+		if !(m && rc&240 != 96) {
+			goto f21d
+		}
+		a("version 4/5,")
+	f21d:
+		ra, k = w4l(st, r, hw, po, 12)
+		if !k {
+			goto f21e
+		}
+		rc, m = f4l(st, r, int64(ra)+40)
+		if !m {
+			goto f21e
+		}
+		a(wizardry.FormatDescription("%u files", int64(int32(rc))))
+	f21e:
+	f21b:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x4d:
+		sb = gp(r, po, 8)
+		sm[0] = 0
+		if len(sb) >= 3 && string(sb[0:3]) == "MSC" {
+			if len(sb) > 3 {
+				switch sb[3] {
+				case 69:
+					if len(sb) >= 8 && string(sb[4:8]) == "\x00\x00\x00\x00" {
+						sm[0] |= 0x2
+					}
+				case 70:
+					if len(sb) >= 8 && string(sb[4:8]) == "\x00\x00\x00\x00" {
+						sm[0] |= 0x1
+					}
+				}
+			}
+		}
+		if sm[0]&0x1 == 0 {
+			goto f217
+		}
+		if ix(st, r, &tx) {
+			goto f217
+		}
+		rA = gt(r, po, "MSCF\x00\x00\x00\x00", 32, 0)
+		if rA < 0 {
+			goto f217
+		}
+		a("Microsoft Cabinet archive data")
+		rc, m = w4l(st, r, hw, po, po+8)
+		if !m {
+			goto f218
+		}
+		a(wizardry.FormatDescription("\\b, %u bytes", int64(int32(rc))))
+	f218:
+		rc, m = w2l(st, r, hw, po, po+28)
+		if !(m && rc == 1) {
+			goto f219
+		}
+		a("\\b, 1 file")
+	f219:
+		if !(m && int64(int16(rc)) > 1) {
+			goto f21a
+		}
+		a(wizardry.FormatDescription("\\b, %u files", int64(int16(rc))))
+	f21a:
+	f217:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x2 == 0 {
+			goto f21f
+		}
+		if ix(st, r, &tx) {
+			goto f21f
+		}
+		rA = gt(r, po, "MSCE\x00\x00\x00\x00", 32, 0)
+		if rA < 0 {
+			goto f21f
+		}
+		a("Microsoft WinCE install header")
+		rc, m = w4l(st, r, hw, po, po+20)
+		switch rc {
+		case 0:
+			a("\\b, architecture-independent")
+		case 103:
+			a("\\b, Hitachi SH3")
+		case 104:
+			a("\\b, Hitachi SH4")
+		case 2577:
+			a("\\b, StrongARM")
+		case 4000:
+			a("\\b, MIPS R4000")
+		case 10003:
+			a("\\b, Hitachi SH3")
+		case 10004:
+			a("\\b, Hitachi SH3E")
+		case 10005:
+			a("\\b, Hitachi SH4")
+		case 70001:
+			a("\\b, ARM 7TDMI")
+		default:
+			{
+				goto f220
+			}
+		}
+	f220:
+		rc, m = w2l(st, r, hw, po, po+52)
+		if !(m && rc == 1) {
+			goto f229
+		}
+		a("\\b, 1 file")
+	f229:
+		if !(m && int64(int16(rc)) > 1) {
+			goto f22a
+		}
+		a(wizardry.FormatDescription("\\b, %u files", int64(int16(rc))))
+	f22a:
+		rc, m = w2l(st, r, hw, po, po+56)
+		if !(m && rc == 1) {
+			goto f22b
+		}
+		a("\\b, 1 registry entry")
+	f22b:
+		if !(m && int64(int16(rc)) > 1) {
+			goto f22c
+		}
+		a(wizardry.FormatDescription("\\b, %u registry entries", int64(int16(rc))))
+	f22c:
+	f21f:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x94:
+		if ix(st, r, &tx) {
+			goto f233
+		}
+		rA = gt(r, po, "\x94\xa6.", 32, 0)
+		if rA < 0 {
+			goto f233
+		}
+		a("Microsoft Word Document")
+	f233:
+		if len(out) > 0 {
+			return out
+		}
+	case 0xd0:
+		if ix(st, r, &tx) {
+			goto f230
+		}
+		rA = gt(r, po, "\xd0\xcf\x11ࡱ\x1a\xe1", 32, 0)
+		if rA < 0 {
+			goto f230
+		}
+		a("Microsoft Office Document")
+		sb = gp(r, po+546, 4)
+		sm[1] = 0
+		if len(sb) > 0 {
+			switch sb[0] {
+			case 98:
+				if len(sb) >= 4 && string(sb[1:4]) == "jbj" {
+					sm[1] |= 0x1
+				}
+			case 106:
+				if len(sb) >= 4 && string(sb[1:4]) == "bjb" {
+					sm[1] |= 0x2
+				}
+			}
+		}
+		if sm[1]&0x1 == 0 {
+			goto f231
+		}
+		rA = gt(r, po+546, "bjbj", 0, 0)
+		if rA < 0 {
+			goto f231
+		}
+		a("Microsoft Word Document")
+	f231:
+		if sm[1]&0x2 == 0 {
+			goto f232
+		}
+		rA = gt(r, po+546, "jbjb", 0, 0)
+		if rA < 0 {
+			goto f232
+		}
+		a("Microsoft Word Document")
+	f232:
+	f230:
+		if len(out) > 0 {
+			return out
+		}
+	}
+	rA = gt(r, po+512, "R\x00o\x00o\x00t\x00 \x00E\x00n\x00t\x00r\x00y", 0, 0)
+	if rA < 0 {
+		goto f234
+	}
+	a("Microsoft Word Document")
+f234:
+	if len(out) > 0 {
+		return out
+	}
+	switch hb {
+	case 0x24:
+		if ix(st, r, &tx) {
+			goto f235
+		}
+		rA = gt(r, po, "$RBU", 32, 0)
+		if rA < 0 {
+			goto f235
+		}
+		sv = gs(r, po+23, 96)
+		rA = gt(r, po+23, "Dell", 0, 0)
+		if rA < 0 {
+			goto f236
+		}
+		a(wizardry.FormatDescription("%s system BIOS", wizardry.StringTestFlags(0).Printable(sv)))
+	f236:
+		rc, m = w1l(st, r, hw, po, po+5)
+		if !(m && rc == 2) {
+			goto f237
+		}
+		rc, m = w1l(st, r, hw, po, po+48)
+		if !m {
+			goto f238
+		}
+		a(wizardry.FormatDescription("version %d.", int64(int8(rc))))
+	f238:
+		rc, m = w1l(st, r, hw, po, po+49)
+		if !m {
+			goto f239
+		}
+		a(wizardry.FormatDescription("\\b%d.", int64(int8(rc))))
+	f239:
+		rc, m = w1l(st, r, hw, po, po+50)
+		if !m {
+			goto f23a
+		}
+		a(wizardry.FormatDescription("\\b%d", int64(int8(rc))))
+	f23a:
+	f237:
+		if !(m && int64(int8(rc)) < 2) {
+			goto f23b
+		}
+		sv = gs(r, po+48, 96)
+		a(wizardry.FormatDescription("version %.3s", wizardry.StringTestFlags(0).Printable(sv)))
+	f23b:
+	f235:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x42:
+		if ix(st, r, &tx) {
+			goto f243
+		}
+		rA = gt(r, po, "B000FF\n", 32, 0)
+		if rA < 0 {
+			goto f243
+		}
+		a("Windows Embedded CE binary image")
+	f243:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x44:
+		if ix(st, r, &tx) {
+			goto f23d
+		}
+		rA = gt(r, po, "DDS |\x00\x00\x00", 32, 0)
+		if rA < 0 {
+			goto f23d
+		}
+		a("Microsoft DirectDraw Surface (DDS),")
+		rc, m = w4l(st, r, hw, po, po+16)
+		if !(m && int64(int32(rc)) > 0) {
+			goto f23e
+		}
+		a(wizardry.FormatDescription("%d x", int64(int32(rc))))
+	f23e:
+		rc, m = w4l(st, r, hw, po, po+12)
+		if !(m && int64(int32(rc)) > 0) {
+			goto f23f
+		}
+		a(wizardry.FormatDescription("%d,", int64(int32(rc))))
+	f23f:
+		sv = gs(r, po+84, 96)
+		a(wizardry.FormatDescription("%.4s", wizardry.StringTestFlags(0).Printable(sv)))
+	f23d:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x49:
+		if ix(st, r, &tx) {
+			goto f241
+		}
+		rA = gt(r, po, "ITOLITLS", 32, 0)
+		if rA < 0 {
+			goto f241
+		}
+		a("Microsoft Reader eBook Data")
+		rc, m = w4l(st, r, hw, po, po+8)
+		if !m {
+			goto f242
+		}
+		a(wizardry.FormatDescription("\\b, version %u", int64(int32(rc))))
+	f242:
+	f241:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x4a:
+		rA = gt(r, po, "Jetsam0", 0, 0)
+		if rA < 0 {
+			goto f24b
+		}
+		a("Mallard BASIC Jetsam index data")
+	f24b:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x4d:
+		sb = gp(r, po, 8)
+		sm[0] = 0
+		if len(sb) > 0 && sb[0] == 77 {
+			if len(sb) > 1 {
+				switch sb[1] {
+				case 73:
+					if len(sb) >= 6 && string(sb[2:6]) == "OPEN" {
+						sm[0] |= 0x2
+					}
+				case 83:
+					if len(sb) >= 8 && string(sb[2:8]) == "WIM\x00\x00\x00" {
+						sm[0] |= 0x1
+					}
+				}
+			}
+		}
+		if sm[0]&0x1 == 0 {
+			goto f244
+		}
+		if ix(st, r, &tx) {
+			goto f244
+		}
+		rA = gt(r, po, "MSWIM\x00\x00\x00", 32, 0)
+		if rA < 0 {
+			goto f244
+		}
+		a("Windows imaging (WIM) image")
+	f244:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x2 == 0 {
+			goto f24a
+		}
+		rA = gt(r, po, "MIOPEN", 0, 0)
+		if rA < 0 {
+			goto f24a
+		}
+		a("Mallard BASIC Jetsam data")
+	f24a:
+		if len(out) > 0 {
+			return out
+		}
+	case 0x57:
+		if ix(st, r, &tx) {
+			goto f245
+		}
+		rA = gt(r, po, "WLPWM\x00\x00\x00", 32, 0)
+		if rA < 0 {
+			goto f245
+		}
+		a("Windows imaging (WIM) image, wimlib pipable format")
+	f245:
+		if len(out) > 0 {
+			return out
+		}
+	case 0xfc:
+		sb = gp(r, po, 3)
+		sm[0] = 0
+		if len(sb) > 0 && sb[0] == 252 {
+			if len(sb) > 1 {
+				switch sb[1] {
+				case 3:
+					if len(sb) > 2 {
+						switch sb[2] {
+						case 0:
+							sm[0] |= 0x1
+						case 1:
+							sm[0] |= 0x4
+						}
+					}
+				case 4:
+					if len(sb) > 2 {
+						switch sb[2] {
+						case 0:
+							sm[0] |= 0x2
+						case 1:
+							sm[0] |= 0x8
+						}
+					}
+				}
+			}
+		}
+		if sm[0]&0x1 == 0 {
+			goto f246
+		}
+		rA = gt(r, po, "\xfc\x03\x00", 0, 0)
+		if rA < 0 {
+			goto f246
+		}
+		a("Mallard BASIC program data (v1.11)")
+	f246:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x2 == 0 {
+			goto f247
+		}
+		rA = gt(r, po, "\xfc\x04\x00", 0, 0)
+		if rA < 0 {
+			goto f247
+		}
+		a("Mallard BASIC program data (v1.29+)")
+	f247:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x4 == 0 {
+			goto f248
+		}
+		rA = gt(r, po, "\xfc\x03\x01", 0, 0)
+		if rA < 0 {
+			goto f248
+		}
+		a("Mallard BASIC protected program data (v1.11)")
+	f248:
+		if len(out) > 0 {
+			return out
+		}
+		if sm[0]&0x8 == 0 {
+			goto f249
+		}
+		rA = gt(r, po, "\xfc\x04\x01", 0, 0)
+		if rA < 0 {
+			goto f249
+		}
+		a("Mallard BASIC protected program data (v1.29+)")
+	f249:
+		if len(out) > 0 {
+			return out
+		}
+	}
+	rc, m = w2l(st, r, hw, po, po+3)
+	if !(m && rc > 1979) {
+		goto f24c
+	}
+	rc, m = w1l(st, r, hw, po, po+5)
+	if !(m && (rc-1) < 31) {
+		goto f24d
+	}
+	rc, m = w1l(st, r, hw, po, po+6)
+	if !(m && (rc-1) < 12) {
+		goto f24e
+	}
+	rA = gt(r, po+7, "\x00\x00\x00\x00\x00\x00\x00\x00", 0, 0)
+	if rA < 0 {
+		goto f24f
+	}
+	rc, m = w1l(st, r, hw, po, po+1)
+	if !m {
+		goto f250
+	}
+	a(wizardry.FormatDescription("DOS 2.0 backup id file, sequence %d", rc))
+f250:
+	rc, m = w1l(st, r, hw, po, po)
+	if !(m && rc == 255) {
+		goto f251
+	}
+	a("\\b, last disk")
+f251:
+f24f:
+f24e:
+f24d:
+f24c:
+	if len(out) > 0 {
+		return out
+	}
+	rc, m = w1l(st, r, hw, po, po+83)
+	if !(m && (rc-1) < 80) {
+		goto f252
+	}
+	rA = gt(r, po+84, "\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00", 0, 0)
+	if rA < 0 {
+		goto f253
+	}
+	sv = gs(r, po+5, 96)
+	a(wizardry.FormatDescription("DOS 2.0 backed up file %s,", wizardry.StringTestFlags(0).Printable(sv)))
+	rc, m = w1l(st, r, hw, po, po)
+	if !(m && rc == 255) {
+		goto f255
+	}
+	a("complete file")
+f255:
+	if !(m && rc != 255) {
+		goto f256
+	}
+	rc, m = w2l(st, r, hw, po, po+1)
+	if !m {
+		goto f257
+	}
+	a(wizardry.FormatDescription("split file, sequence %d", rc))
+f257:
+f256:
+f253:
+f252:
+	if len(out) > 0 {
+		return out
+	}
+	rA = gt(r, po, "\x8bBACKUP ", 0, 0)
+	if rA < 0 {
+		goto f258
+	}
+	rA = gt(r, po+10, "\x00\x00\x00\x00\x00\x00\x00\x00", 0, 0)
+	if rA < 0 {
+		goto f259
+	}
+	rc, m = w1l(st, r, hw, po, po+9)
+	if !m {
+		goto f25a
+	}
+	a(wizardry.FormatDescription("DOS 3.3 backup control file, sequence %d", rc))
+f25a:
+	rc, m = w1l(st, r, hw, po, po+138)
+	if !(m && rc == 255) {
+		goto f25b
+	}
+	a("\\b, last disk")
+f25b:
+f259:
+f258:
+	if len(out) > 0 {
+		return out
+	}
+	return out
+}
+
+func identifyCurEntry(st *state, r *wizutil.SliceReader, po int64, fd wizardry.Fields) []string {
+	var out []string
+	var ss []string
+	ss = ss[0:]
+	var gf [32]int64
+	gf[0] = po
+	var ra uint64
+	ra &= ra
+	var rb uint64
+	rb &= rb
+	var rc uint64
+	rc &= rc
+	var rA int64
+	rA &= rA
+	var rB int64
+	rB &= rB
+	var sv string
+	sv += ""
+	var sb []byte
+	sb = sb[0:]
+	var sm [32]uint64
+	sm[0] &= sm[0]
+	var k bool
+	k = !!k
+	var l bool
+	l = !!l
+	var m bool
+	m = !!m
+	var d = make([]bool, 32)
+	d[0] = !!d[0]
+	var hw = gp(r, po, 8)
+
+	a := func(args ...string) {
+		out = append(out, args...)
+	}
+	if !st.gw.EnterUse() {
+		goto f1
+	}
+	ss = identifyCurIcoEntry(st, r, po, fd)
+	st.gw.ExitUse()
+	if len(ss) == 0 {
+		goto f1
+	}
+	a(ss...)
+f1:
+	rc, m = w2l(st, r, hw, po, po+4)
+	if !m {
+		goto f2
+	}
+	a(wizardry.FormatDescription("\\b, hotspot @%dx", rc))
+f2:
+	rc, m = w2l(st, r, hw, po, po+6)
+	if !m {
+		goto f3
+	}
+	a(wizardry.FormatDescription("\\b%d", rc))
+f3:
+	return out
+}
+
+func identifyCurIcoDir(st *state, r *wizutil.SliceReader, po int64, fd wizardry.Fields) []string {
+	var out []string
+	var ss []string
+	ss = ss[0:]
+	var gf [32]int64
+	gf[0] = po
+	var ra uint64
+	ra &= ra
+	var rb uint64
+	rb &= rb
+	var rc uint64
+	rc &= rc
+	var rA int64
+	rA &= rA
+	var rB int64
+	rB &= rB
+	var sv string
+	sv += ""
+	var sb []byte
+	sb = sb[0:]
+	var sm [32]uint64
+	sm[0] &= sm[0]
+	var k bool
+	k = !!k
+	var l bool
+	l = !!l
+	var m bool
+	m = !!m
+	var d = make([]bool, 32)
+	d[0] = !!d[0]
+	var hw = gp(r, po, 22)
+
+	a := func(args ...string) {
+		out = append(out, args...)
+	}
+	rc, m = w4l(st, r, hw, po, po+18)
+	if !(m && rc&6 == 6) {
+		goto f1
+	}
+	ra, k = w4l(st, r, hw, po, 18)
+	if !k {
+		goto f2
+	}
+	a("MS Windows")
+	rc, m = w4b(st, r, hw, po, po)
+	if !(m && rc == 256) {
+		goto f3
+	}
+	a("icon resource")
+	rc, m = w2l(st, r, hw, po, po+4)
+	if !m {
+		goto f4
+	}
+	a(wizardry.FormatDescription("- %d icon", rc))
+f4:
+	if !(m && rc > 1) {
+		goto f5
+	}
+	a("\\bs")
+f5:
+	if !st.gw.EnterUse() {
+		goto f6
+	}
+	ss = identifyIcoEntry(st, r, po+6, fd)
+	st.gw.ExitUse()
+	if len(ss) == 0 {
+		goto f6
+	}
+	a(ss...)
+f6:
+	rc, m = w2l(st, r, hw, po, po+4)
+	if !(m && rc > 1) {
+		goto f7
+	}
+	if !st.gw.EnterUse() {
+		goto f8
+	}
+	ss = identifyIcoEntry(st, r, po+22, fd)
+	st.gw.ExitUse()
+	if len(ss) == 0 {
+		goto f8
+	}
+	a(ss...)
+f8:
+f7:
+f3:
+	if !(m && rc == 512) {
+		goto f9
+	}
+	a("cursor resource")
+	rc, m = w2l(st, r, hw, po, po+4)
+	if !m {
+		goto fa
+	}
+	a(wizardry.FormatDescription("- %d icon", rc))
+fa:
+	if !(m && rc > 1) {
+		goto fb
+	}
+	a("\\bs")
+fb:
+	if !st.gw.EnterUse() {
+		goto fc
+	}
+	ss = identifyCurEntry(st, r, po+6, fd)
+	st.gw.ExitUse()
+	if len(ss) == 0 {
+		goto fc
+	}
+	a(ss...)
+fc:
+f9:
+f2:
+f1:
+	return out
+}
+
+func identifyCurIcoEntry(st *state, r *wizutil.SliceReader, po int64, fd wizardry.Fields) []string {
+	var out []string
+	var ss []string
+	ss = ss[0:]
+	var gf [32]int64
+	gf[0] = po
+	var ra uint64
+	ra &= ra
+	var rb uint64
+	rb &= rb
+	var rc uint64
+	rc &= rc
+	var rA int64
+	rA &= rA
+	var rB int64
+	rB &= rB
+	var sv string
+	sv += ""
+	var sb []byte
+	sb = sb[0:]
+	var sm [32]uint64
+	sm[0] &= sm[0]
+	var k bool
+	k = !!k
+	var l bool
+	l = !!l
+	var m bool
+	m = !!m
+	var d = make([]bool, 32)
+	d[0] = !!d[0]
+	var hw = gp(r, po, 16)
+
+	a := func(args ...string) {
+		out = append(out, args...)
+	}
+	rc, m = w1l(st, r, hw, po, po)
+	if !(m && rc == 0) {
+		goto f1
+	}
+	a("\\b, 256x")
+f1:
+	if !(m && rc != 0) {
+		goto f2
+	}
+	a(wizardry.FormatDescription("\\b, %dx", int64(int8(rc))))
+f2:
+	rc, m = w1l(st, r, hw, po, po+1)
+	if !(m && rc == 0) {
+		goto f3
+	}
+	a("\\b256")
+f3:
+	if !(m && rc != 0) {
+		goto f4
+	}
+	a(wizardry.FormatDescription("\\b%d", int64(int8(rc))))
+f4:
+	rc, m = w1l(st, r, hw, po, po+2)
+	if !(m && rc != 0) {
+		goto f5
+	}
+	a(wizardry.FormatDescription("\\b, %d colors", rc))
+f5:
+	ra, k = w4l(st, r, hw, po, 12)
+	if !k {
+		goto f6
+	}
+	rc, m = f4b(st, r, int64(ra))
+	if !(m && rc == 2303741511) {
+		goto f6
+	}
+	gf[1] = int64(ra) + 4
+	if st.ic >= 15 || gf[1]+-4 <= 0 || gf[1]+-4 >= r.Size() {
+		goto f7
+	}
+	st.ic++
+	ss = identify__Root(st, r.Slice(gf[1]+-4), 0, fd)
+	st.ic--
+	if len(ss) == 0 {
+		goto f7
+	}
+	a("\\b with")
+	a(ss...)
+f7:
+f6:
+	if !k {
+		goto f8
+	}
+	if !(m && rc != 2303741511) {
+		goto f8
+	}
+f8:
+	return out
+}
+
+func identifyElfLe(st *state, r *wizutil.SliceReader, po int64, fd wizardry.Fields) []string {
+	var out []string
+	var ss []string
+	ss = ss[0:]
+	var gf [32]int64
+	gf[0] = po
+	var ra uint64
+	ra &= ra
+	var rb uint64
+	rb &= rb
+	var rc uint64
+	rc &= rc
+	var rA int64
+	rA &= rA
+	var rB int64
+	rB &= rB
+	var sv string
+	sv += ""
+	var sb []byte
+	sb = sb[0:]
+	var sm [32]uint64
+	sm[0] &= sm[0]
+	var k bool
+	k = !!k
+	var l bool
+	l = !!l
+	var m bool
+	m = !!m
+	var d = make([]bool, 32)
+	d[0] = !!d[0]
+	var hw = gp(r, po, 52)
+
+	a := func(args ...string) {
+		out = append(out, args...)
+	}
+	d[0] = f
+	rc, m = w2l(st, r, hw, po, po+16)
+	if !(m && rc == 0) {
+		goto f1
+	}
+	a("no file type,")
+	fd.Add("type", "no file type")
+	d[0] = t
+f1:
+	if !(m && rc == 1) {
+		goto f2
+	}
+	a("relocatable,")
+	fd.Add("type", "relocatable")
+	d[0] = t
+f2:
+	if !(m && rc == 2) {
+		goto f3
+	}
+	a("executable,")
+	fd.Add("type", "executable")
+	d[0] = t
+f3:
+	if !(m && rc == 3) {
+		goto f4
+	}
+	a("shared object,")
+	fd.Add("type", "shared object")
+	d[0] = t
+f4:
+	if !(m && rc == 4) {
+		goto f5
+	}
+	a("core file")
+	fd.Add("type", "core file")
+	d[0] = t
+f5:
+	if !(m && rc&65280 == 65280) {
+		goto f6
+	}
+	a("processor-specific,")
+	d[0] = t
+f6:
+	d[0] = f
+	d[0] = t
+	rc, m = w2l(st, r, hw, po, po+18)
+	if !(m && rc == 0) {
+		goto f8
+	}
+	a("no machine,")
+	fd.Add("arch", "no machine")
+	d[0] = t
+f8:
+	if !(m && rc == 1) {
+		goto f9
+	}
+	a("AT&T WE32100,")
+	fd.Add("arch", "AT&T WE32100")
+	d[0] = t
+f9:
+	if !(m && rc == 2) {
+		goto fa
+	}
+	a("SPARC,")
+	fd.Add("arch", "SPARC")
+	d[0] = t
+fa:
+	if !(m && rc == 3) {
+		goto fb
+	}
+	a("Intel 80386,")
+	fd.Add("arch", "Intel 80386")
+	d[0] = t
+fb:
+	if !(m && rc == 4) {
+		goto fc
+	}
+	a("Motorola m68k,")
+	fd.Add("arch", "Motorola m68k")
+	rc, m = w1l(st, r, hw, po, po+4)
+	if !(m && rc == 1) {
+		goto fd
+	}
+	rc, m = w4l(st, r, hw, po, po+36)
+	if !(m && rc&16777216 == 16777216) {
+		goto fe
+	}
+	a("68000,")
+fe:
+	if !(m && rc&8454144 == 8454144) {
+		goto ff
+	}
+	a("CPU32,")
+ff:
+	if !(m && rc == 0) {
+		goto f10
+	}
+	a("68020,")
+f10:
+fd:
+	d[0] = t
+fc:
+	if !(m && rc == 5) {
+		goto f11
+	}
+	a("Motorola m88k,")
+	fd.Add("arch", "Motorola m88k")
+	d[0] = t
+f11:
+	if !(m && rc == 6) {
+		goto f12
+	}
+	a("Intel 80486,")
+	fd.Add("arch", "Intel 80486")
+	d[0] = t
+f12:
+	if !(m && rc == 7) {
+		goto f13
+	}
+	a("Intel 80860,")
+	fd.Add("arch", "Intel 80860")
+	d[0] = t
+f13:
+	if !(m && rc == 8) {
+		goto f14
+	}
+	a("MIPS,")
+	fd.Add("arch", "MIPS")
+	rc, m = w1l(st, r, hw, po, po+4)
+	if !(m && rc == 1) {
+		goto f15
+	}
+	rc, m = w4l(st, r, hw, po, po+36)
+	if !(m && rc&32 == 32) {
+		goto f16
+	}
+	a("N32")
+f16:
+f15:
+	d[0] = t
+f14:
+	if !(m && rc == 10) {
+		goto f17
+	}
+	a("MIPS,")
+	fd.Add("arch", "MIPS")
+	rc, m = w1l(st, r, hw, po, po+4)
+	if !(m && rc == 1) {
+		goto f18
+	}
+	rc, m = w4l(st, r, hw, po, po+36)
+	if !(m && rc&32 == 32) {
+		goto f19
+	}
+	a("N32")
+f19:
+f18:
+	d[0] = t
+f17:
+	if !(m && rc == 8) {
+		goto f1a
+	}
+	rc, m = w1l(st, r, hw, po, po+4)
+	if !(m && rc == 1) {
+		goto f1b
+	}
+	rc, m = w4l(st, r, hw, po, po+36)
+	if !(m && rc&4026531840 == 0) {
+		goto f1c
+	}
+	a("MIPS-I")
+f1c:
+	if !(m && rc&4026531840 == 268435456) {
+		goto f1d
+	}
+	a("MIPS-II")
+f1d:
+	if !(m && rc&4026531840 == 536870912) {
+		goto f1e
+	}
+	a("MIPS-III")
+f1e:
+	if !(m && rc&4026531840 == 805306368) {
+		goto f1f
+	}
+	a("MIPS-IV")
+f1f:
+	if !(m && rc&4026531840 == 1073741824) {
+		goto f20
+	}
+	a("MIPS-V")
+f20:
+	if !(m && rc&4026531840 == 1342177280) {
+		goto f21
+	}
+	a("MIPS32")
+f21:
+	if !(m && rc&4026531840 == 1610612736) {
+		goto f22
+	}
+	a("MIPS64")
+f22:
+	if !(m && rc&4026531840 == 1879048192) {
+		goto f23
+	}
+	a("MIPS32 rel2")
+f23:
+	if !(m && rc&4026531840 == 2147483648) {
+		goto f24
+	}
+	a("MIPS64 rel2")
+f24:
+f1b:
+	if !(m && rc == 2) {
+		goto f25
+	}
+	rc, m = w4l(st, r, hw, po, po+48)
+	if !(m && rc&4026531840 == 0) {
+		goto f26
+	}
+	a("MIPS-I")
+f26:
+	if !(m && rc&4026531840 == 268435456) {
+		goto f27
+	}
+	a("MIPS-II")
+f27:
+	if !(m && rc&4026531840 == 536870912) {
+		goto f28
+	}
+	a("MIPS-III")
+f28:
+	if !(m && rc&4026531840 == 805306368) {
+		goto f29
+	}
+	a("MIPS-IV")
+f29:
+	if !(m && rc&4026531840 == 1073741824) {
+		goto f2a
+	}
+	a("MIPS-V")
+f2a:
+	if !(m && rc&4026531840 == 1342177280) {
+		goto f2b
+	}
+	a("MIPS32")
+f2b:
+	if !(m && rc&4026531840 == 1610612736) {
+		goto f2c
+	}
+	a("MIPS64")
+f2c:
+	if !(m && rc&4026531840 == 1879048192) {
+		goto f2d
+	}
+	a("MIPS32 rel2")
+f2d:
+	if !(m && rc&4026531840 == 2147483648) {
+		goto f2e
+	}
+	a("MIPS64 rel2")
+f2e:
+f25:
+	d[0] = t
+f1a:
+	if !(m && rc == 9) {
+		goto f2f
+	}
+	a("Amdahl,")
+	fd.Add("arch", "Amdahl")
+	d[0] = t
+f2f:
+	if !(m && rc == 10) {
+		goto f30
+	}
+	a("MIPS (deprecated),")
+	fd.Add("arch", "MIPS (deprecated)")
+	d[0] = t
+f30:
+	if !(m && rc == 11) {
+		goto f31
+	}
+	a("RS6000,")
+	fd.Add("arch", "RS6000")
+	d[0] = t
+f31:
+	if !(m && rc == 15) {
+		goto f32
+	}
+	a("PA-RISC,")
+	fd.Add("arch", "PA-RISC")
+	rc, m = w1l(st, r, hw, po, po+4)
+	if !(m && rc == 1) {
+		goto f33
+	}
+	rc, m = w2l(st, r, hw, po, po+38)
+	if !(m && rc == 532) {
+		goto f34
+	}
+	a("2.0")
+f34:
+	rc, m = w2l(st, r, hw, po, po+36)
+	if !(m && rc&8 == 8) {
+		goto f35
+	}
+	a("(LP64)")
+f35:
+f33:
+	if !(m && rc == 2) {
+		goto f36
+	}
+	rc, m = w2l(st, r, hw, po, po+50)
+	if !(m && rc == 532) {
+		goto f37
+	}
+	a("2.0")
+f37:
+	rc, m = w2l(st, r, hw, po, po+48)
+	if !(m && rc&8 == 8) {
+		goto f38
+	}
+	a("(LP64)")
+f38:
+f36:
+	d[0] = t
+f32:
+	if !(m && rc == 16) {
+		goto f39
+	}
+	a("nCUBE,")
+	fd.Add("arch", "nCUBE")
+	d[0] = t
+f39:
+	if !(m && rc == 17) {
+		goto f3a
+	}
+	a("Fujitsu VPP500,")
+	fd.Add("arch", "Fujitsu VPP500")
+	d[0] = t
+f3a:
+	if !(m && rc == 18) {
+		goto f3b
+	}
+	a("SPARC32PLUS,")
+	fd.Add("arch", "SPARC32PLUS")
+	rc, m = w1l(st, r, hw, po, po+4)
+	if !(m && rc == 1) {
+		goto f3c
+	}
+	rc, m = w4l(st, r, hw, po, po+36)
+	if !(m && rc&16776960 == 256) {
+		goto f3d
+	}
+	a("V8+ Required,")
+f3d:
+	if !(m && rc&16776960 == 512) {
+		goto f3e
+	}
+	a("Sun UltraSPARC1 Extensions Required,")
+f3e:
+	if !(m && rc&16776960 == 1024) {
+		goto f3f
+	}
+	a("HaL R1 Extensions Required,")
+f3f:
+	if !(m && rc&16776960 == 2048) {
+		goto f40
+	}
+	a("Sun UltraSPARC3 Extensions Required,")
+f40:
+f3c:
+	d[0] = t
+f3b:
+	if !(m && rc == 19) {
+		goto f41
+	}
+	a("Intel 80960,")
+	fd.Add("arch", "Intel 80960")
+	d[0] = t
+f41:
+	if !(m && rc == 20) {
+		goto f42
+	}
+	a("PowerPC or cisco 4500,")
+	fd.Add("arch", "PowerPC or cisco 4500")
+	d[0] = t
+f42:
+	if !(m && rc == 21) {
+		goto f43
+	}
+	a("64-bit PowerPC or cisco 7500,")
+	fd.Add("arch", "64-bit PowerPC or cisco 7500")
+	d[0] = t
+f43:
+	if !(m && rc == 22) {
+		goto f44
+	}
+	a("IBM S/390,")
+	fd.Add("arch", "IBM S/390")
+	d[0] = t
+f44:
+	if !(m && rc == 23) {
+		goto f45
+	}
+	a("Cell SPU,")
+	fd.Add("arch", "Cell SPU")
+	d[0] = t
+f45:
+	if !(m && rc == 24) {
+		goto f46
+	}
+	a("cisco SVIP,")
+	fd.Add("arch", "cisco SVIP")
+	d[0] = t
+f46:
+	if !(m && rc == 25) {
+		goto f47
+	}
+	a("cisco 7200,")
+	fd.Add("arch", "cisco 7200")
+	d[0] = t
+f47:
+	if !(m && rc == 36) {
+		goto f48
+	}
+	a("NEC V800 or cisco 12000,")
+	fd.Add("arch", "NEC V800 or cisco 12000")
+	d[0] = t
+f48:
+	if !(m && rc == 37) {
+		goto f49
+	}
+	a("Fujitsu FR20,")
+	fd.Add("arch", "Fujitsu FR20")
+	d[0] = t
+f49:
+	if !(m && rc == 38) {
+		goto f4a
+	}
+	a("TRW RH-32,")
+	fd.Add("arch", "TRW RH-32")
+	d[0] = t
+f4a:
+	if !(m && rc == 39) {
+		goto f4b
+	}
+	a("Motorola RCE,")
+	fd.Add("arch", "Motorola RCE")
+	d[0] = t
+f4b:
+	if !(m && rc == 40) {
+		goto f4c
+	}
+	a("ARM,")
+	fd.Add("arch", "ARM")
+	rc, m = w1l(st, r, hw, po, po+4)
+	if !(m && rc == 1) {
+		goto f4d
+	}
+	rc, m = w4l(st, r, hw, po, po+36)
+	if !(m && rc&4278190080 == 67108864) {
+		goto f4e
+	}
+	a("EABI4")
+f4e:
+	if !(m && rc&4278190080 == 83886080) {
+		goto f4f
+	}
+	a("EABI5")
+f4f:
+	if !(m && rc&8388608 == 8388608) {
+		goto f50
+	}
+	a("BE8")
+f50:
+	if !(m && rc&4194304 == 4194304) {
+		goto f51
+	}
+	a("LE8")
+f51:
+f4d:
+	d[0] = t
+f4c:
+	if !(m && rc == 41) {
+		goto f52
+	}
+	a("Alpha,")
+	fd.Add("arch", "Alpha")
+	d[0] = t
+f52:
+	if !(m && rc == 42) {
+		goto f53
+	}
+	a("Renesas SH,")
+	fd.Add("arch", "Renesas SH")
+	d[0] = t
+f53:
+	if !(m && rc == 43) {
+		goto f54
+	}
+	a("SPARC V9,")
+	fd.Add("arch", "SPARC V9")
+	rc, m = w1l(st, r, hw, po, po+4)
+	if !(m && rc == 2) {
+		goto f55
+	}
+	rc, m = w4l(st, r, hw, po, po+48)
+	if !(m && rc&16776960 == 512) {
+		goto f56
+	}
+	a("Sun UltraSPARC1 Extensions Required,")
+f56:
+	if !(m && rc&16776960 == 1024) {
+		goto f57
+	}
+	a("HaL R1 Extensions Required,")
+f57:
+	if !(m && rc&16776960 == 2048) {
+		goto f58
+	}
+	a("Sun UltraSPARC3 Extensions Required,")
+f58:
+	if !(m && rc&3 == 0) {
+		goto f59
+	}
+	a("total store ordering,")
+f59:
+	if !(m && rc&3 == 1) {
+		goto f5a
+	}
+	a("partial store ordering,")
+f5a:
+	if !(m && rc&3 == 2) {
+		goto f5b
+	}
+	a("relaxed memory ordering,")
+f5b:
+f55:
+	d[0] = t
+f54:
+	if !(m && rc == 44) {
+		goto f5c
+	}
+	a("Siemens Tricore Embedded Processor,")
+	fd.Add("arch", "Siemens Tricore Embedded Processor")
+	d[0] = t
+f5c:
+	if !(m && rc == 45) {
+		goto f5d
+	}
+	a("Argonaut RISC Core, Argonaut Technologies Inc.,")
+	fd.Add("arch", "Argonaut RISC Core, Argonaut Technologies Inc.")
+	d[0] = t
+f5d:
+	if !(m && rc == 46) {
+		goto f5e
+	}
+	a("Renesas H8/300,")
+	fd.Add("arch", "Renesas H8/300")
+	d[0] = t
+f5e:
+	if !(m && rc == 47) {
+		goto f5f
+	}
+	a("Renesas H8/300H,")
+	fd.Add("arch", "Renesas H8/300H")
+	d[0] = t
+f5f:
+	if !(m && rc == 48) {
+		goto f60
+	}
+	a("Renesas H8S,")
+	fd.Add("arch", "Renesas H8S")
+	d[0] = t
+f60:
+	if !(m && rc == 49) {
+		goto f61
+	}
+	a("Renesas H8/500,")
+	fd.Add("arch", "Renesas H8/500")
+	d[0] = t
+f61:
+	if !(m && rc == 50) {
+		goto f62
+	}
+	a("IA-64,")
+	fd.Add("arch", "IA-64")
+	d[0] = t
+f62:
+	if !(m && rc == 51) {
+		goto f63
+	}
+	a("Stanford MIPS-X,")
+	fd.Add("arch", "Stanford MIPS-X")
+	d[0] = t
+f63:
+	if !(m && rc == 52) {
+		goto f64
+	}
+	a("Motorola Coldfire,")
+	fd.Add("arch", "Motorola Coldfire")
+	d[0] = t
+f64:
+	if !(m && rc == 53) {
+		goto f65
+	}
+	a("Motorola M68HC12,")
+	fd.Add("arch", "Motorola M68HC12")
+	d[0] = t
+f65:
+	if !(m && rc == 54) {
+		goto f66
+	}
+	a("Fujitsu MMA,")
+	fd.Add("arch", "Fujitsu MMA")
+	d[0] = t
+f66:
+	if !(m && rc == 55) {
+		goto f67
+	}
+	a("Siemens PCP,")
+	fd.Add("arch", "Siemens PCP")
+	d[0] = t
+f67:
+	if !(m && rc == 56) {
+		goto f68
+	}
+	a("Sony nCPU,")
+	fd.Add("arch", "Sony nCPU")
+	d[0] = t
+f68:
+	if !(m && rc == 57) {
+		goto f69
+	}
+	a("Denso NDR1,")
+	fd.Add("arch", "Denso NDR1")
+	d[0] = t
+f69:
+	if !(m && rc == 58) {
+		goto f6a
+	}
+	a("Start*Core,")
+	fd.Add("arch", "Start*Core")
+	d[0] = t
+f6a:
+	if !(m && rc == 59) {
+		goto f6b
+	}
+	a("Toyota ME16,")
+	fd.Add("arch", "Toyota ME16")
+	d[0] = t
+f6b:
+	if !(m && rc == 60) {
+		goto f6c
+	}
+	a("ST100,")
+	fd.Add("arch", "ST100")
+	d[0] = t
+f6c:
+	if !(m && rc == 61) {
+		goto f6d
+	}
+	a("Tinyj emb.,")
+	fd.Add("arch", "Tinyj emb.")
+	d[0] = t
+f6d:
+	if !(m && rc == 62) {
+		goto f6e
+	}
+	a("x86-64,")
+	fd.Add("arch", "x86-64")
+	d[0] = t
+f6e:
+	if !(m && rc == 63) {
+		goto f6f
+	}
+	a("Sony DSP,")
+	fd.Add("arch", "Sony DSP")
+	d[0] = t
+f6f:
+	if !(m && rc == 64) {
+		goto f70
+	}
+	a("DEC PDP-10,")
+	fd.Add("arch", "DEC PDP-10")
+	d[0] = t
+f70:
+	if !(m && rc == 65) {
+		goto f71
+	}
+	a("DEC PDP-11,")
+	fd.Add("arch", "DEC PDP-11")
+	d[0] = t
+f71:
+	if !(m && rc == 66) {
+		goto f72
+	}
+	a("FX66,")
+	fd.Add("arch", "FX66")
+	d[0] = t
+f72:
+	if !(m && rc == 67) {
+		goto f73
+	}
+	a("ST9+ 8/16 bit,")
+	fd.Add("arch", "ST9+ 8/16 bit")
+	d[0] = t
+f73:
+	if !(m && rc == 68) {
+		goto f74
+	}
+	a("ST7 8 bit,")
+	fd.Add("arch", "ST7 8 bit")
+	d[0] = t
+f74:
+	if !(m && rc == 69) {
+		goto f75
+	}
+	a("MC68HC16,")
+	fd.Add("arch", "MC68HC16")
+	d[0] = t
+f75:
+	if !(m && rc == 70) {
+		goto f76
+	}
+	a("MC68HC11,")
+	fd.Add("arch", "MC68HC11")
+	d[0] = t
+f76:
+	if !(m && rc == 71) {
+		goto f77
+	}
+	a("MC68HC08,")
+	fd.Add("arch", "MC68HC08")
+	d[0] = t
+f77:
+	if !(m && rc == 72) {
+		goto f78
+	}
+	a("MC68HC05,")
+	fd.Add("arch", "MC68HC05")
+	d[0] = t
+f78:
+	if !(m && rc == 73) {
+		goto f79
+	}
+	a("SGI SVx or Cray NV1,")
+	fd.Add("arch", "SGI SVx or Cray NV1")
+	d[0] = t
+f79:
+	if !(m && rc == 74) {
+		goto f7a
+	}
+	a("ST19 8 bit,")
+	fd.Add("arch", "ST19 8 bit")
+	d[0] = t
+f7a:
+	if !(m && rc == 75) {
+		goto f7b
+	}
+	a("Digital VAX,")
+	fd.Add("arch", "Digital VAX")
+	d[0] = t
+f7b:
+	if !(m && rc == 76) {
+		goto f7c
+	}
+	a("Axis cris,")
+	fd.Add("arch", "Axis cris")
+	d[0] = t
+f7c:
+	if !(m && rc == 77) {
+		goto f7d
+	}
+	a("Infineon 32-bit embedded,")
+	fd.Add("arch", "Infineon 32-bit embedded")
+	d[0] = t
+f7d:
+	if !(m && rc == 78) {
+		goto f7e
+	}
+	a("Element 14 64-bit DSP,")
+	fd.Add("arch", "Element 14 64-bit DSP")
+	d[0] = t
+f7e:
+	if !(m && rc == 79) {
+		goto f7f
+	}
+	a("LSI Logic 16-bit DSP,")
+	fd.Add("arch", "LSI Logic 16-bit DSP")
+	d[0] = t
+f7f:
+	if !(m && rc == 80) {
+		goto f80
+	}
+	a("MMIX,")
+	fd.Add("arch", "MMIX")
+	d[0] = t
+f80:
+	if !(m && rc == 81) {
+		goto f81
+	}
+	a("Harvard machine-independent,")
+	fd.Add("arch", "Harvard machine-independent")
+	d[0] = t
+f81:
+	if !(m && rc == 82) {
+		goto f82
+	}
+	a("SiTera Prism,")
+	fd.Add("arch", "SiTera Prism")
+	d[0] = t
+f82:
+	if !(m && rc == 83) {
+		goto f83
+	}
+	a("Atmel AVR 8-bit,")
+	fd.Add("arch", "Atmel AVR 8-bit")
+	d[0] = t
+f83:
+	if !(m && rc == 84) {
+		goto f84
+	}
+	a("Fujitsu FR30,")
+	fd.Add("arch", "Fujitsu FR30")
+	d[0] = t
+f84:
+	if !(m && rc == 85) {
+		goto f85
+	}
+	a("Mitsubishi D10V,")
+	fd.Add("arch", "Mitsubishi D10V")
+	d[0] = t
+f85:
+	if !(m && rc == 86) {
+		goto f86
+	}
+	a("Mitsubishi D30V,")
+	fd.Add("arch", "Mitsubishi D30V")
+	d[0] = t
+f86:
+	if !(m && rc == 87) {
+		goto f87
+	}
+	a("NEC v850,")
+	fd.Add("arch", "NEC v850")
+	d[0] = t
+f87:
+	if !(m && rc == 88) {
+		goto f88
+	}
+	a("Renesas M32R,")
+	fd.Add("arch", "Renesas M32R")
+	d[0] = t
+f88:
+	if !(m && rc == 89) {
+		goto f89
+	}
+	a("Matsushita MN10300,")
+	fd.Add("arch", "Matsushita MN10300")
+	d[0] = t
+f89:
+	if !(m && rc == 90) {
+		goto f8a
+	}
+	a("Matsushita MN10200,")
+	fd.Add("arch", "Matsushita MN10200")
+	d[0] = t
+f8a:
+	if !(m && rc == 91) {
+		goto f8b
+	}
+	a("picoJava,")
+	fd.Add("arch", "picoJava")
+	d[0] = t
+f8b:
+	if !(m && rc == 92) {
+		goto f8c
+	}
+	a("OpenRISC,")
+	fd.Add("arch", "OpenRISC")
+	d[0] = t
+f8c:
+	if !(m && rc == 93) {
+		goto f8d
+	}
+	a("ARC Cores Tangent-A5,")
+	fd.Add("arch", "ARC Cores Tangent-A5")
+	d[0] = t
+f8d:
+	if !(m && rc == 94) {
+		goto f8e
+	}
+	a("Tensilica Xtensa,")
+	fd.Add("arch", "Tensilica Xtensa")
+	d[0] = t
+f8e:
+	if !(m && rc == 95) {
+		goto f8f
+	}
+	a("Alphamosaic VideoCore,")
+	fd.Add("arch", "Alphamosaic VideoCore")
+	d[0] = t
+f8f:
+	if !(m && rc == 96) {
+		goto f90
+	}
+	a("Thompson Multimedia,")
+	fd.Add("arch", "Thompson Multimedia")
+	d[0] = t
+f90:
+	if !(m && rc == 97) {
+		goto f91
+	}
+	a("NatSemi 32k,")
+	fd.Add("arch", "NatSemi 32k")
+	d[0] = t
+f91:
+	if !(m && rc == 98) {
+		goto f92
+	}
+	a("Tenor Network TPC,")
+	fd.Add("arch", "Tenor Network TPC")
+	d[0] = t
+f92:
+	if !(m && rc == 99) {
+		goto f93
+	}
+	a("Trebia SNP 1000,")
+	fd.Add("arch", "Trebia SNP 1000")
+	d[0] = t
+f93:
+	if !(m && rc == 100) {
+		goto f94
+	}
+	a("STMicroelectronics ST200,")
+	fd.Add("arch", "STMicroelectronics ST200")
+	d[0] = t
+f94:
+	if !(m && rc == 101) {
+		goto f95
+	}
+	a("Ubicom IP2022,")
+	fd.Add("arch", "Ubicom IP2022")
+	d[0] = t
+f95:
+	if !(m && rc == 102) {
+		goto f96
+	}
+	a("MAX Processor,")
+	fd.Add("arch", "MAX Processor")
+	d[0] = t
+f96:
+	if !(m && rc == 103) {
+		goto f97
+	}
+	a("NatSemi CompactRISC,")
+	fd.Add("arch", "NatSemi CompactRISC")
+	d[0] = t
+f97:
+	if !(m && rc == 104) {
+		goto f98
+	}
+	a("Fujitsu F2MC16,")
+	fd.Add("arch", "Fujitsu F2MC16")
+	d[0] = t
+f98:
+	if !(m && rc == 105) {
+		goto f99
+	}
+	a("TI msp430,")
+	fd.Add("arch", "TI msp430")
+	d[0] = t
+f99:
+	if !(m && rc == 106) {
+		goto f9a
+	}
+	a("Analog Devices Blackfin,")
+	fd.Add("arch", "Analog Devices Blackfin")
+	d[0] = t
+f9a:
+	if !(m && rc == 107) {
+		goto f9b
+	}
+	a("S1C33 Family of Seiko Epson,")
+	fd.Add("arch", "S1C33 Family of Seiko Epson")
+	d[0] = t
+f9b:
+	if !(m && rc == 108) {
+		goto f9c
+	}
+	a("Sharp embedded,")
+	fd.Add("arch", "Sharp embedded")
+	d[0] = t
+f9c:
+	if !(m && rc == 109) {
+		goto f9d
+	}
+	a("Arca RISC,")
+	fd.Add("arch", "Arca RISC")
+	d[0] = t
+f9d:
+	if !(m && rc == 110) {
+		goto f9e
+	}
+	a("PKU-Unity Ltd.,")
+	fd.Add("arch", "PKU-Unity Ltd.")
+	d[0] = t
+f9e:
+	if !(m && rc == 111) {
+		goto f9f
+	}
+	a("eXcess: 16/32/64-bit,")
+	fd.Add("arch", "eXcess: 16/32/64-bit")
+	d[0] = t
+f9f:
+	if !(m && rc == 112) {
+		goto fa0
+	}
+	a("Icera Deep Execution Processor,")
+	fd.Add("arch", "Icera Deep Execution Processor")
+	d[0] = t
+fa0:
+	if !(m && rc == 113) {
+		goto fa1
+	}
+	a("Altera Nios II,")
+	fd.Add("arch", "Altera Nios II")
+	d[0] = t
+fa1:
+	if !(m && rc == 114) {
+		goto fa2
+	}
+	a("NatSemi CRX,")
+	fd.Add("arch", "NatSemi CRX")
+	d[0] = t
+fa2:
+	if !(m && rc == 115) {
+		goto fa3
+	}
+	a("Motorola XGATE,")
+	fd.Add("arch", "Motorola XGATE")
+	d[0] = t
+fa3:
+	if !(m && rc == 116) {
+		goto fa4
+	}
+	a("Infineon C16x/XC16x,")
+	fd.Add("arch", "Infineon C16x/XC16x")
+	d[0] = t
+fa4:
+	if !(m && rc == 117) {
+		goto fa5
+	}
+	a("Renesas M16C series,")
+	fd.Add("arch", "Renesas M16C series")
+	d[0] = t
+fa5:
+	if !(m && rc == 118) {
+		goto fa6
+	}
+	a("Microchip dsPIC30F,")
+	fd.Add("arch", "Microchip dsPIC30F")
+	d[0] = t
+fa6:
+	if !(m && rc == 119) {
+		goto fa7
+	}
+	a("Freescale RISC core,")
+	fd.Add("arch", "Freescale RISC core")
+	d[0] = t
+fa7:
+	if !(m && rc == 120) {
+		goto fa8
+	}
+	a("Renesas M32C series,")
+	fd.Add("arch", "Renesas M32C series")
+	d[0] = t
+fa8:
+	if !(m && rc == 131) {
+		goto fa9
+	}
+	a("Altium TSK3000 core,")
+	fd.Add("arch", "Altium TSK3000 core")
+	d[0] = t
+fa9:
+	if !(m && rc == 132) {
+		goto faa
+	}
+	a("Freescale RS08,")
+	fd.Add("arch", "Freescale RS08")
+	d[0] = t
+faa:
+	if !(m && rc == 134) {
+		goto fab
+	}
+	a("Cyan Technology eCOG2,")
+	fd.Add("arch", "Cyan Technology eCOG2")
+	d[0] = t
+fab:
+	if !(m && rc == 135) {
+		goto fac
+	}
+	a("Sunplus S+core7 RISC,")
+	fd.Add("arch", "Sunplus S+core7 RISC")
+	d[0] = t
+fac:
+	if !(m && rc == 136) {
+		goto fad
+	}
+	a("New Japan Radio (NJR) 24-bit DSP,")
+	fd.Add("arch", "New Japan Radio (NJR) 24-bit DSP")
+	d[0] = t
+fad:
+	if !(m && rc == 137) {
+		goto fae
+	}
+	a("Broadcom VideoCore III,")
+	fd.Add("arch", "Broadcom VideoCore III")
+	d[0] = t
+fae:
+	if !(m && rc == 138) {
+		goto faf
+	}
+	a("LatticeMico32,")
+	fd.Add("arch", "LatticeMico32")
+	d[0] = t
+faf:
+	if !(m && rc == 139) {
+		goto fb0
+	}
+	a("Seiko Epson C17 family,")
+	fd.Add("arch", "Seiko Epson C17 family")
+	d[0] = t
+fb0:
+	if !(m && rc == 140) {
+		goto fb1
+	}
+	a("TI TMS320C6000 DSP family,")
+	fd.Add("arch", "TI TMS320C6000 DSP family")
+	d[0] = t
+fb1:
+	if !(m && rc == 141) {
+		goto fb2
+	}
+	a("TI TMS320C2000 DSP family,")
+	fd.Add("arch", "TI TMS320C2000 DSP family")
+	d[0] = t
+fb2:
+	if !(m && rc == 142) {
+		goto fb3
+	}
+	a("TI TMS320C55x DSP family,")
+	fd.Add("arch", "TI TMS320C55x DSP family")
+	d[0] = t
+fb3:
+	if !(m && rc == 160) {
+		goto fb4
+	}
+	a("STMicroelectronics 64bit VLIW DSP,")
+	fd.Add("arch", "STMicroelectronics 64bit VLIW DSP")
+	d[0] = t
+fb4:
+	if !(m && rc == 161) {
+		goto fb5
+	}
+	a("Cypress M8C,")
+	fd.Add("arch", "Cypress M8C")
+	d[0] = t
+fb5:
+	if !(m && rc == 162) {
+		goto fb6
+	}
+	a("Renesas R32C series,")
+	fd.Add("arch", "Renesas R32C series")
+	d[0] = t
+fb6:
+	if !(m && rc == 163) {
+		goto fb7
+	}
+	a("NXP TriMedia family,")
+	fd.Add("arch", "NXP TriMedia family")
+	d[0] = t
+fb7:
+	if !(m && rc == 164) {
+		goto fb8
+	}
+	a("QUALCOMM DSP6,")
+	fd.Add("arch", "QUALCOMM DSP6")
+	d[0] = t
+fb8:
+	if !(m && rc == 165) {
+		goto fb9
+	}
+	a("Intel 8051 and variants,")
+	fd.Add("arch", "Intel 8051 and variants")
+	d[0] = t
+fb9:
+	if !(m && rc == 166) {
+		goto fba
+	}
+	a("STMicroelectronics STxP7x family,")
+	fd.Add("arch", "STMicroelectronics STxP7x family")
+	d[0] = t
+fba:
+	if !(m && rc == 167) {
+		goto fbb
+	}
+	a("Andes embedded RISC,")
+	fd.Add("arch", "Andes embedded RISC")
+	d[0] = t
+fbb:
+	if !(m && rc == 168) {
+		goto fbc
+	}
+	a("Cyan eCOG1X family,")
+	fd.Add("arch", "Cyan eCOG1X family")
+	d[0] = t
+fbc:
+	if !(m && rc == 169) {
+		goto fbd
+	}
+	a("Dallas MAXQ30,")
+	fd.Add("arch", "Dallas MAXQ30")
+	d[0] = t
+fbd:
+	if !(m && rc == 170) {
+		goto fbe
+	}
+	a("New Japan Radio (NJR) 16-bit DSP,")
+	fd.Add("arch", "New Japan Radio (NJR) 16-bit DSP")
+	d[0] = t
+fbe:
+	if !(m && rc == 171) {
+		goto fbf
+	}
+	a("M2000 Reconfigurable RISC,")
+	fd.Add("arch", "M2000 Reconfigurable RISC")
+	d[0] = t
+fbf:
+	if !(m && rc == 172) {
+		goto fc0
+	}
+	a("Cray NV2 vector architecture,")
+	fd.Add("arch", "Cray NV2 vector architecture")
+	d[0] = t
+fc0:
+	if !(m && rc == 173) {
+		goto fc1
+	}
+	a("Renesas RX family,")
+	fd.Add("arch", "Renesas RX family")
+	d[0] = t
+fc1:
+	if !(m && rc == 174) {
+		goto fc2
+	}
+	a("META,")
+	fd.Add("arch", "META")
+	d[0] = t
+fc2:
+	if !(m && rc == 175) {
+		goto fc3
+	}
+	a("MCST Elbrus,")
+	fd.Add("arch", "MCST Elbrus")
+	d[0] = t
+fc3:
+	if !(m && rc == 176) {
+		goto fc4
+	}
+	a("Cyan Technology eCOG16 family,")
+	fd.Add("arch", "Cyan Technology eCOG16 family")
+	d[0] = t
+fc4:
+	if !(m && rc == 177) {
+		goto fc5
+	}
+	a("NatSemi CompactRISC,")
+	fd.Add("arch", "NatSemi CompactRISC")
+	d[0] = t
+fc5:
+	if !(m && rc == 178) {
+		goto fc6
+	}
+	a("Freescale Extended Time Processing Unit,")
+	fd.Add("arch", "Freescale Extended Time Processing Unit")
+	d[0] = t
+fc6:
+	if !(m && rc == 179) {
+		goto fc7
+	}
+	a("Infineon SLE9X,")
+	fd.Add("arch", "Infineon SLE9X")
+	d[0] = t
+fc7:
+	if !(m && rc == 180) {
+		goto fc8
+	}
+	a("Intel L1OM,")
+	fd.Add("arch", "Intel L1OM")
+	d[0] = t
+fc8:
+	if !(m && rc == 181) {
+		goto fc9
+	}
+	a("Intel K1OM,")
+	fd.Add("arch", "Intel K1OM")
+	d[0] = t
+fc9:
+	if !(m && rc == 183) {
+		goto fca
+	}
+	a("ARM aarch64,")
+	fd.Add("arch", "ARM aarch64")
+	d[0] = t
+fca:
+	if !(m && rc == 185) {
+		goto fcb
+	}
+	a("Atmel 32-bit family,")
+	fd.Add("arch", "Atmel 32-bit family")
+	d[0] = t
+fcb:
+	if !(m && rc == 186) {
+		goto fcc
+	}
+	a("STMicroeletronics STM8 8-bit,")
+	fd.Add("arch", "STMicroeletronics STM8 8-bit")
+	d[0] = t
+fcc:
+	if !(m && rc == 187) {
+		goto fcd
+	}
+	a("Tilera TILE64,")
+	fd.Add("arch", "Tilera TILE64")
+	d[0] = t
+fcd:
+	if !(m && rc == 188) {
+		goto fce
+	}
+	a("Tilera TILEPro,")
+	fd.Add("arch", "Tilera TILEPro")
+	d[0] = t
+fce:
+	if !(m && rc == 189) {
+		goto fcf
+	}
+	a("Xilinx MicroBlaze 32-bit RISC,")
+	fd.Add("arch", "Xilinx MicroBlaze 32-bit RISC")
+	d[0] = t
+fcf:
+	if !(m && rc == 190) {
+		goto fd0
+	}
+	a("NVIDIA CUDA architecture,")
+	fd.Add("arch", "NVIDIA CUDA architecture")
+	d[0] = t
+fd0:
+	if !(m && rc == 191) {
+		goto fd1
+	}
+	a("Tilera TILE-Gx,")
+	fd.Add("arch", "Tilera TILE-Gx")
+	d[0] = t
+fd1:
+	if !(m && rc == 197) {
+		goto fd2
+	}
+	a("Renesas RL78 family,")
+	fd.Add("arch", "Renesas RL78 family")
+	d[0] = t
+fd2:
+	if !(m && rc == 199) {
+		goto fd3
+	}
+	a("Renesas 78K0R,")
+	fd.Add("arch", "Renesas 78K0R")
+	d[0] = t
+fd3:
+	rc, m = w2l(st, r, hw, po, po+18)
+	switch rc {
+	case 4183:
+		a("AVR (unofficial),")
+	case 4185:
+		a("MSP430 (unofficial),")
+	case 4643:
+		a("Adapteva Epiphany (unofficial),")
+	case 9520:
+		a("Morpho MT (unofficial),")
+	case 13104:
+		a("FR30 (unofficial),")
+	case 13350:
+		a("OpenRISC (obsolete),")
+	case 18056:
+		a("Infineon C166 (unofficial),")
+	case 21569:
+		a("Cygnus FRV (unofficial),")
+	case 23205:
+		a("DLX (unofficial),")
+	case 30288:
+		a("Cygnus D10V (unofficial),")
+	case 30326:
+		a("Cygnus D30V (unofficial),")
+	case 33303:
+		a("Ubicom IP2xxx (unofficial),")
+	case 33906:
+		a("OpenRISC (obsolete),")
+	case 36901:
+		a("Cygnus PowerPC (unofficial),")
+	case 36902:
+		a("Alpha (unofficial),")
+	case 36929:
+		a("Cygnus M32R (unofficial),")
+	case 36992:
+		a("Cygnus V850 (unofficial),")
+	case 41872:
+		a("IBM S/390 (obsolete),")
+	case 43975:
+		a("Old Xtensa (unofficial),")
+	case 44357:
+		a("xstormy16 (unofficial),")
+	case 47787:
+		a("Old MicroBlaze (unofficial),,")
+	case 48879:
+		a("Cygnus MN10300 (unofficial),")
+	case 57005:
+		a("Cygnus MN10200 (unofficial),")
+	case 61453:
+		a("Toshiba MeP (unofficial),")
+	case 65200:
+		a("Renesas M32C (unofficial),")
+	case 65210:
+		a("Vitesse IQ2000 (unofficial),")
+	case 65211:
+		a("NIOS (unofficial),")
+	case 65261:
+		a("Moxie (unofficial),")
+	default:
+		{
+			goto fd4
+		}
+	}
+	d[0] = t
+fd4:
+	if d[0] {
+		goto ff0
+	}
+	rc, m = w2l(st, r, hw, po, po+18)
+	if !m {
+		goto ff1
+	}
+	a(wizardry.FormatDescription("*unknown arch 0x%x*", int64(int16(rc))))
+	fd.Add("arch", wizardry.FormatDescription("0x%x", int64(int16(rc))))
+ff1:
+	d[0] = t
+ff0:
+	rc, m = w4l(st, r, hw, po, po+20)
+	switch rc {
+	case 0:
+		a("invalid version")
+	case 1:
+		a("version 1")
+	default:
+		{
+			goto ff2
+		}
+	}
+	d[0] = t
+ff2:
+	return out
+}
+
+func identifyElfLe__Swapped(st *state, r *wizutil.SliceReader, po int64, fd wizardry.Fields) []string {
+	var out []string
+	var ss []string
+	ss = ss[0:]
+	var gf [32]int64
+	gf[0] = po
+	var ra uint64
+	ra &= ra
+	var rb uint64
+	rb &= rb
+	var rc uint64
+	rc &= rc
+	var rA int64
+	rA &= rA
+	var rB int64
+	rB &= rB
+	var sv string
+	sv += ""
+	var sb []byte
+	sb = sb[0:]
+	var sm [32]uint64
+	sm[0] &= sm[0]
+	var k bool
+	k = !!k
+	var l bool
+	l = !!l
+	var m bool
+	m = !!m
+	var d = make([]bool, 32)
+	d[0] = !!d[0]
+	var hw = gp(r, po, 52)
+
+	a := func(args ...string) {
+		out = append(out, args...)
+	}
+	d[0] = f
+	rc, m = w2b(st, r, hw, po, po+16)
+	if !(m && rc == 0) {
+		goto f1
+	}
+	a("no file type,")
+	fd.Add("type", "no file type")
+	d[0] = t
+f1:
+	if !(m && rc == 1) {
+		goto f2
+	}
+	a("relocatable,")
+	fd.Add("type", "relocatable")
+	d[0] = t
+f2:
+	if !(m && rc == 2) {
+		goto f3
+	}
+	a("executable,")
+	fd.Add("type", "executable")
+	d[0] = t
+f3:
+	if !(m && rc == 3) {
+		goto f4
+	}
+	a("shared object,")
+	fd.Add("type", "shared object")
+	d[0] = t
+f4:
+	if !(m && rc == 4) {
+		goto f5
+	}
+	a("core file")
+	fd.Add("type", "core file")
+	d[0] = t
+f5:
+	if !(m && rc&65280 == 65280) {
+		goto f6
+	}
+	a("processor-specific,")
+	d[0] = t
+f6:
+	d[0] = f
+	d[0] = t
+	rc, m = w2b(st, r, hw, po, po+18)
+	if !(m && rc == 0) {
+		goto f8
+	}
+	a("no machine,")
+	fd.Add("arch", "no machine")
+	d[0] = t
+f8:
+	if !(m && rc == 1) {
+		goto f9
+	}
+	a("AT&T WE32100,")
+	fd.Add("arch", "AT&T WE32100")
+	d[0] = t
+f9:
+	if !(m && rc == 2) {
+		goto fa
+	}
+	a("SPARC,")
+	fd.Add("arch", "SPARC")
+	d[0] = t
+fa:
+	if !(m && rc == 3) {
+		goto fb
+	}
+	a("Intel 80386,")
+	fd.Add("arch", "Intel 80386")
+	d[0] = t
+fb:
+	if !(m && rc == 4) {
+		goto fc
+	}
+	a("Motorola m68k,")
+	fd.Add("arch", "Motorola m68k")
+	rc, m = w1b(st, r, hw, po, po+4)
+	if !(m && rc == 1) {
+		goto fd
+	}
+	rc, m = w4b(st, r, hw, po, po+36)
+	if !(m && rc&16777216 == 16777216) {
+		goto fe
+	}
+	a("68000,")
+fe:
+	if !(m && rc&8454144 == 8454144) {
+		goto ff
+	}
+	a("CPU32,")
+ff:
+	if !(m && rc == 0) {
+		goto f10
+	}
+	a("68020,")
+f10:
+fd:
+	d[0] = t
+fc:
+	if !(m && rc == 5) {
+		goto f11
+	}
+	a("Motorola m88k,")
+	fd.Add("arch", "Motorola m88k")
+	d[0] = t
+f11:
+	if !(m && rc == 6) {
+		goto f12
+	}
+	a("Intel 80486,")
+	fd.Add("arch", "Intel 80486")
+	d[0] = t
+f12:
+	if !(m && rc == 7) {
+		goto f13
+	}
+	a("Intel 80860,")
+	fd.Add("arch", "Intel 80860")
+	d[0] = t
+f13:
+	if !(m && rc == 8) {
+		goto f14
+	}
+	a("MIPS,")
+	fd.Add("arch", "MIPS")
+	rc, m = w1b(st, r, hw, po, po+4)
+	if !(m && rc == 1) {
+		goto f15
+	}
+	rc, m = w4b(st, r, hw, po, po+36)
+	if !(m && rc&32 == 32) {
+		goto f16
+	}
+	a("N32")
+f16:
+f15:
+	d[0] = t
+f14:
+	if !(m && rc == 10) {
+		goto f17
+	}
+	a("MIPS,")
+	fd.Add("arch", "MIPS")
+	rc, m = w1b(st, r, hw, po, po+4)
+	if !(m && rc == 1) {
+		goto f18
+	}
+	rc, m = w4b(st, r, hw, po, po+36)
+	if !(m && rc&32 == 32) {
+		goto f19
+	}
+	a("N32")
+f19:
+f18:
+	d[0] = t
+f17:
+	if !(m && rc == 8) {
+		goto f1a
+	}
+	rc, m = w1b(st, r, hw, po, po+4)
+	if !(m && rc == 1) {
+		goto f1b
+	}
+	rc, m = w4b(st, r, hw, po, po+36)
+	if !(m && rc&4026531840 == 0) {
+		goto f1c
+	}
+	a("MIPS-I")
+f1c:
+	if !(m && rc&4026531840 == 268435456) {
+		goto f1d
+	}
+	a("MIPS-II")
+f1d:
+	if !(m && rc&4026531840 == 536870912) {
+		goto f1e
+	}
+	a("MIPS-III")
+f1e:
+	if !(m && rc&4026531840 == 805306368) {
+		goto f1f
+	}
+	a("MIPS-IV")
+f1f:
+	if !(m && rc&4026531840 == 1073741824) {
+		goto f20
+	}
+	a("MIPS-V")
+f20:
+	if !(m && rc&4026531840 == 1342177280) {
+		goto f21
+	}
+	a("MIPS32")
+f21:
+	if !(m && rc&4026531840 == 1610612736) {
+		goto f22
+	}
+	a("MIPS64")
+f22:
+	if !(m && rc&4026531840 == 1879048192) {
+		goto f23
+	}
+	a("MIPS32 rel2")
+f23:
+	if !(m && rc&4026531840 == 2147483648) {
+		goto f24
+	}
+	a("MIPS64 rel2")
+f24:
+f1b:
+	if !(m && rc == 2) {
+		goto f25
+	}
+	rc, m = w4b(st, r, hw, po, po+48)
+	if !(m && rc&4026531840 == 0) {
+		goto f26
+	}
+	a("MIPS-I")
+f26:
+	if !(m && rc&4026531840 == 268435456) {
+		goto f27
+	}
+	a("MIPS-II")
+f27:
+	if !(m && rc&4026531840 == 536870912) {
+		goto f28
+	}
+	a("MIPS-III")
+f28:
+	if !(m && rc&4026531840 == 805306368) {
+		goto f29
+	}
+	a("MIPS-IV")
+f29:
+	if !(m && rc&4026531840 == 1073741824) {
+		goto f2a
+	}
+	a("MIPS-V")
+f2a:
+	if !(m && rc&4026531840 == 1342177280) {
+		goto f2b
+	}
+	a("MIPS32")
+f2b:
+	if !(m && rc&4026531840 == 1610612736) {
+		goto f2c
+	}
+	a("MIPS64")
+f2c:
+	if !(m && rc&4026531840 == 1879048192) {
+		goto f2d
+	}
+	a("MIPS32 rel2")
+f2d:
+	if !(m && rc&4026531840 == 2147483648) {
+		goto f2e
+	}
+	a("MIPS64 rel2")
+f2e:
+f25:
+	d[0] = t
+f1a:
+	if !(m && rc == 9) {
+		goto f2f
+	}
+	a("Amdahl,")
+	fd.Add("arch", "Amdahl")
+	d[0] = t
+f2f:
+	if !(m && rc == 10) {
+		goto f30
+	}
+	a("MIPS (deprecated),")
+	fd.Add("arch", "MIPS (deprecated)")
+	d[0] = t
+f30:
+	if !(m && rc == 11) {
+		goto f31
+	}
+	a("RS6000,")
+	fd.Add("arch", "RS6000")
+	d[0] = t
+f31:
+	if !(m && rc == 15) {
+		goto f32
+	}
+	a("PA-RISC,")
+	fd.Add("arch", "PA-RISC")
+	rc, m = w1b(st, r, hw, po, po+4)
+	if !(m && rc == 1) {
+		goto f33
+	}
+	rc, m = w2b(st, r, hw, po, po+38)
+	if !(m && rc == 532) {
+		goto f34
+	}
+	a("2.0")
+f34:
+	rc, m = w2b(st, r, hw, po, po+36)
+	if !(m && rc&8 == 8) {
+		goto f35
+	}
+	a("(LP64)")
+f35:
+f33:
+	if !(m && rc == 2) {
+		goto f36
+	}
+	rc, m = w2b(st, r, hw, po, po+50)
+	if !(m && rc == 532) {
+		goto f37
+	}
+	a("2.0")
+f37:
+	rc, m = w2b(st, r, hw, po, po+48)
+	if !(m && rc&8 == 8) {
+		goto f38
+	}
+	a("(LP64)")
+f38:
+f36:
+	d[0] = t
+f32:
+	if !(m && rc == 16) {
+		goto f39
+	}
+	a("nCUBE,")
+	fd.Add("arch", "nCUBE")
+	d[0] = t
+f39:
+	if !(m && rc == 17) {
+		goto f3a
+	}
+	a("Fujitsu VPP500,")
+	fd.Add("arch", "Fujitsu VPP500")
+	d[0] = t
+f3a:
+	if !(m && rc == 18) {
+		goto f3b
+	}
+	a("SPARC32PLUS,")
+	fd.Add("arch", "SPARC32PLUS")
+	rc, m = w1b(st, r, hw, po, po+4)
+	if !(m && rc == 1) {
+		goto f3c
+	}
+	rc, m = w4b(st, r, hw, po, po+36)
+	if !(m && rc&16776960 == 256) {
+		goto f3d
+	}
+	a("V8+ Required,")
+f3d:
+	if !(m && rc&16776960 == 512) {
+		goto f3e
+	}
+	a("Sun UltraSPARC1 Extensions Required,")
+f3e:
+	if !(m && rc&16776960 == 1024) {
+		goto f3f
+	}
+	a("HaL R1 Extensions Required,")
+f3f:
+	if !(m && rc&16776960 == 2048) {
+		goto f40
+	}
+	a("Sun UltraSPARC3 Extensions Required,")
+f40:
+f3c:
+	d[0] = t
+f3b:
+	if !(m && rc == 19) {
+		goto f41
+	}
+	a("Intel 80960,")
+	fd.Add("arch", "Intel 80960")
+	d[0] = t
+f41:
+	if !(m && rc == 20) {
+		goto f42
+	}
+	a("PowerPC or cisco 4500,")
+	fd.Add("arch", "PowerPC or cisco 4500")
+	d[0] = t
+f42:
+	if !(m && rc == 21) {
+		goto f43
+	}
+	a("64-bit PowerPC or cisco 7500,")
+	fd.Add("arch", "64-bit PowerPC or cisco 7500")
+	d[0] = t
+f43:
+	if !(m && rc == 22) {
+		goto f44
+	}
+	a("IBM S/390,")
+	fd.Add("arch", "IBM S/390")
+	d[0] = t
+f44:
+	if !(m && rc == 23) {
+		goto f45
+	}
+	a("Cell SPU,")
+	fd.Add("arch", "Cell SPU")
+	d[0] = t
+f45:
+	if !(m && rc == 24) {
+		goto f46
+	}
+	a("cisco SVIP,")
+	fd.Add("arch", "cisco SVIP")
+	d[0] = t
+f46:
+	if !(m && rc == 25) {
+		goto f47
+	}
+	a("cisco 7200,")
+	fd.Add("arch", "cisco 7200")
+	d[0] = t
+f47:
+	if !(m && rc == 36) {
+		goto f48
+	}
+	a("NEC V800 or cisco 12000,")
+	fd.Add("arch", "NEC V800 or cisco 12000")
+	d[0] = t
+f48:
+	if !(m && rc == 37) {
+		goto f49
+	}
+	a("Fujitsu FR20,")
+	fd.Add("arch", "Fujitsu FR20")
+	d[0] = t
+f49:
+	if !(m && rc == 38) {
+		goto f4a
+	}
+	a("TRW RH-32,")
+	fd.Add("arch", "TRW RH-32")
+	d[0] = t
+f4a:
+	if !(m && rc == 39) {
+		goto f4b
+	}
+	a("Motorola RCE,")
+	fd.Add("arch", "Motorola RCE")
+	d[0] = t
+f4b:
+	if !(m && rc == 40) {
+		goto f4c
+	}
+	a("ARM,")
+	fd.Add("arch", "ARM")
+	rc, m = w1b(st, r, hw, po, po+4)
+	if !(m && rc == 1) {
+		goto f4d
+	}
+	rc, m = w4b(st, r, hw, po, po+36)
+	if !(m && rc&4278190080 == 67108864) {
+		goto f4e
+	}
+	a("EABI4")
+f4e:
+	if !(m && rc&4278190080 == 83886080) {
+		goto f4f
+	}
+	a("EABI5")
+f4f:
+	if !(m && rc&8388608 == 8388608) {
+		goto f50
+	}
+	a("BE8")
+f50:
+	if !(m && rc&4194304 == 4194304) {
+		goto f51
+	}
+	a("LE8")
+f51:
+f4d:
+	d[0] = t
+f4c:
+	if !(m && rc == 41) {
+		goto f52
+	}
+	a("Alpha,")
+	fd.Add("arch", "Alpha")
+	d[0] = t
+f52:
+	if !(m && rc == 42) {
+		goto f53
+	}
+	a("Renesas SH,")
+	fd.Add("arch", "Renesas SH")
+	d[0] = t
+f53:
+	if !(m && rc == 43) {
+		goto f54
+	}
+	a("SPARC V9,")
+	fd.Add("arch", "SPARC V9")
+	rc, m = w1b(st, r, hw, po, po+4)
+	if !(m && rc == 2) {
+		goto f55
+	}
+	rc, m = w4b(st, r, hw, po, po+48)
+	if !(m && rc&16776960 == 512) {
+		goto f56
+	}
+	a("Sun UltraSPARC1 Extensions Required,")
+f56:
+	if !(m && rc&16776960 == 1024) {
+		goto f57
+	}
+	a("HaL R1 Extensions Required,")
+f57:
+	if !(m && rc&16776960 == 2048) {
+		goto f58
+	}
+	a("Sun UltraSPARC3 Extensions Required,")
+f58:
+	if !(m && rc&3 == 0) {
+		goto f59
+	}
+	a("total store ordering,")
+f59:
+	if !(m && rc&3 == 1) {
+		goto f5a
+	}
+	a("partial store ordering,")
+f5a:
+	if !(m && rc&3 == 2) {
+		goto f5b
+	}
+	a("relaxed memory ordering,")
+f5b:
+f55:
+	d[0] = t
+f54:
+	if !(m && rc == 44) {
+		goto f5c
+	}
+	a("Siemens Tricore Embedded Processor,")
+	fd.Add("arch", "Siemens Tricore Embedded Processor")
+	d[0] = t
+f5c:
+	if !(m && rc == 45) {
+		goto f5d
+	}
+	a("Argonaut RISC Core, Argonaut Technologies Inc.,")
+	fd.Add("arch", "Argonaut RISC Core, Argonaut Technologies Inc.")
+	d[0] = t
+f5d:
+	if !(m && rc == 46) {
+		goto f5e
+	}
+	a("Renesas H8/300,")
+	fd.Add("arch", "Renesas H8/300")
+	d[0] = t
+f5e:
+	if !(m && rc == 47) {
+		goto f5f
+	}
+	a("Renesas H8/300H,")
+	fd.Add("arch", "Renesas H8/300H")
+	d[0] = t
+f5f:
+	if !(m && rc == 48) {
+		goto f60
+	}
+	a("Renesas H8S,")
+	fd.Add("arch", "Renesas H8S")
+	d[0] = t
+f60:
+	if !(m && rc == 49) {
+		goto f61
+	}
+	a("Renesas H8/500,")
+	fd.Add("arch", "Renesas H8/500")
+	d[0] = t
+f61:
+	if !(m && rc == 50) {
+		goto f62
+	}
+	a("IA-64,")
+	fd.Add("arch", "IA-64")
+	d[0] = t
+f62:
+	if !(m && rc == 51) {
+		goto f63
+	}
+	a("Stanford MIPS-X,")
+	fd.Add("arch", "Stanford MIPS-X")
+	d[0] = t
+f63:
+	if !(m && rc == 52) {
+		goto f64
+	}
+	a("Motorola Coldfire,")
+	fd.Add("arch", "Motorola Coldfire")
+	d[0] = t
+f64:
+	if !(m && rc == 53) {
+		goto f65
+	}
+	a("Motorola M68HC12,")
+	fd.Add("arch", "Motorola M68HC12")
+	d[0] = t
+f65:
+	if !(m && rc == 54) {
+		goto f66
+	}
+	a("Fujitsu MMA,")
+	fd.Add("arch", "Fujitsu MMA")
+	d[0] = t
+f66:
+	if !(m && rc == 55) {
+		goto f67
+	}
+	a("Siemens PCP,")
+	fd.Add("arch", "Siemens PCP")
+	d[0] = t
+f67:
+	if !(m && rc == 56) {
+		goto f68
+	}
+	a("Sony nCPU,")
+	fd.Add("arch", "Sony nCPU")
+	d[0] = t
+f68:
+	if !(m && rc == 57) {
+		goto f69
+	}
+	a("Denso NDR1,")
+	fd.Add("arch", "Denso NDR1")
+	d[0] = t
+f69:
+	if !(m && rc == 58) {
+		goto f6a
+	}
+	a("Start*Core,")
+	fd.Add("arch", "Start*Core")
+	d[0] = t
+f6a:
+	if !(m && rc == 59) {
+		goto f6b
+	}
+	a("Toyota ME16,")
+	fd.Add("arch", "Toyota ME16")
+	d[0] = t
+f6b:
+	if !(m && rc == 60) {
+		goto f6c
+	}
+	a("ST100,")
+	fd.Add("arch", "ST100")
+	d[0] = t
+f6c:
+	if !(m && rc == 61) {
+		goto f6d
+	}
+	a("Tinyj emb.,")
+	fd.Add("arch", "Tinyj emb.")
+	d[0] = t
+f6d:
+	if !(m && rc == 62) {
+		goto f6e
+	}
+	a("x86-64,")
+	fd.Add("arch", "x86-64")
+	d[0] = t
+f6e:
+	if !(m && rc == 63) {
+		goto f6f
+	}
+	a("Sony DSP,")
+	fd.Add("arch", "Sony DSP")
+	d[0] = t
+f6f:
+	if !(m && rc == 64) {
+		goto f70
+	}
+	a("DEC PDP-10,")
+	fd.Add("arch", "DEC PDP-10")
+	d[0] = t
+f70:
+	if !(m && rc == 65) {
+		goto f71
+	}
+	a("DEC PDP-11,")
+	fd.Add("arch", "DEC PDP-11")
+	d[0] = t
+f71:
+	if !(m && rc == 66) {
+		goto f72
+	}
+	a("FX66,")
+	fd.Add("arch", "FX66")
+	d[0] = t
+f72:
+	if !(m && rc == 67) {
+		goto f73
+	}
+	a("ST9+ 8/16 bit,")
+	fd.Add("arch", "ST9+ 8/16 bit")
+	d[0] = t
+f73:
+	if !(m && rc == 68) {
+		goto f74
+	}
+	a("ST7 8 bit,")
+	fd.Add("arch", "ST7 8 bit")
+	d[0] = t
+f74:
+	if !(m && rc == 69) {
+		goto f75
+	}
+	a("MC68HC16,")
+	fd.Add("arch", "MC68HC16")
+	d[0] = t
+f75:
+	if !(m && rc == 70) {
+		goto f76
+	}
+	a("MC68HC11,")
+	fd.Add("arch", "MC68HC11")
+	d[0] = t
+f76:
+	if !(m && rc == 71) {
+		goto f77
+	}
+	a("MC68HC08,")
+	fd.Add("arch", "MC68HC08")
+	d[0] = t
+f77:
+	if !(m && rc == 72) {
+		goto f78
+	}
+	a("MC68HC05,")
+	fd.Add("arch", "MC68HC05")
+	d[0] = t
+f78:
+	if !(m && rc == 73) {
+		goto f79
+	}
+	a("SGI SVx or Cray NV1,")
+	fd.Add("arch", "SGI SVx or Cray NV1")
+	d[0] = t
+f79:
+	if !(m && rc == 74) {
+		goto f7a
+	}
+	a("ST19 8 bit,")
+	fd.Add("arch", "ST19 8 bit")
+	d[0] = t
+f7a:
+	if !(m && rc == 75) {
+		goto f7b
+	}
+	a("Digital VAX,")
+	fd.Add("arch", "Digital VAX")
+	d[0] = t
+f7b:
+	if !(m && rc == 76) {
+		goto f7c
+	}
+	a("Axis cris,")
+	fd.Add("arch", "Axis cris")
+	d[0] = t
+f7c:
+	if !(m && rc == 77) {
+		goto f7d
+	}
+	a("Infineon 32-bit embedded,")
+	fd.Add("arch", "Infineon 32-bit embedded")
+	d[0] = t
+f7d:
+	if !(m && rc == 78) {
+		goto f7e
+	}
+	a("Element 14 64-bit DSP,")
+	fd.Add("arch", "Element 14 64-bit DSP")
+	d[0] = t
+f7e:
+	if !(m && rc == 79) {
+		goto f7f
+	}
+	a("LSI Logic 16-bit DSP,")
+	fd.Add("arch", "LSI Logic 16-bit DSP")
+	d[0] = t
+f7f:
+	if !(m && rc == 80) {
+		goto f80
+	}
+	a("MMIX,")
+	fd.Add("arch", "MMIX")
+	d[0] = t
+f80:
+	if !(m && rc == 81) {
+		goto f81
+	}
+	a("Harvard machine-independent,")
+	fd.Add("arch", "Harvard machine-independent")
+	d[0] = t
+f81:
+	if !(m && rc == 82) {
+		goto f82
+	}
+	a("SiTera Prism,")
+	fd.Add("arch", "SiTera Prism")
+	d[0] = t
+f82:
+	if !(m && rc == 83) {
+		goto f83
+	}
+	a("Atmel AVR 8-bit,")
+	fd.Add("arch", "Atmel AVR 8-bit")
+	d[0] = t
+f83:
+	if !(m && rc == 84) {
+		goto f84
+	}
+	a("Fujitsu FR30,")
+	fd.Add("arch", "Fujitsu FR30")
+	d[0] = t
+f84:
+	if !(m && rc == 85) {
+		goto f85
+	}
+	a("Mitsubishi D10V,")
+	fd.Add("arch", "Mitsubishi D10V")
+	d[0] = t
+f85:
+	if !(m && rc == 86) {
+		goto f86
+	}
+	a("Mitsubishi D30V,")
+	fd.Add("arch", "Mitsubishi D30V")
+	d[0] = t
+f86:
+	if !(m && rc == 87) {
+		goto f87
+	}
+	a("NEC v850,")
+	fd.Add("arch", "NEC v850")
+	d[0] = t
+f87:
+	if !(m && rc == 88) {
+		goto f88
+	}
+	a("Renesas M32R,")
+	fd.Add("arch", "Renesas M32R")
+	d[0] = t
+f88:
+	if !(m && rc == 89) {
+		goto f89
+	}
+	a("Matsushita MN10300,")
+	fd.Add("arch", "Matsushita MN10300")
+	d[0] = t
+f89:
+	if !(m && rc == 90) {
+		goto f8a
+	}
+	a("Matsushita MN10200,")
+	fd.Add("arch", "Matsushita MN10200")
+	d[0] = t
+f8a:
+	if !(m && rc == 91) {
+		goto f8b
+	}
+	a("picoJava,")
+	fd.Add("arch", "picoJava")
+	d[0] = t
+f8b:
+	if !(m && rc == 92) {
+		goto f8c
+	}
+	a("OpenRISC,")
+	fd.Add("arch", "OpenRISC")
+	d[0] = t
+f8c:
+	if !(m && rc == 93) {
+		goto f8d
+	}
+	a("ARC Cores Tangent-A5,")
+	fd.Add("arch", "ARC Cores Tangent-A5")
+	d[0] = t
+f8d:
+	if !(m && rc == 94) {
+		goto f8e
+	}
+	a("Tensilica Xtensa,")
+	fd.Add("arch", "Tensilica Xtensa")
+	d[0] = t
+f8e:
+	if !(m && rc == 95) {
+		goto f8f
+	}
+	a("Alphamosaic VideoCore,")
+	fd.Add("arch", "Alphamosaic VideoCore")
+	d[0] = t
+f8f:
+	if !(m && rc == 96) {
+		goto f90
+	}
+	a("Thompson Multimedia,")
+	fd.Add("arch", "Thompson Multimedia")
+	d[0] = t
+f90:
+	if !(m && rc == 97) {
+		goto f91
+	}
+	a("NatSemi 32k,")
+	fd.Add("arch", "NatSemi 32k")
+	d[0] = t
+f91:
+	if !(m && rc == 98) {
+		goto f92
+	}
+	a("Tenor Network TPC,")
+	fd.Add("arch", "Tenor Network TPC")
+	d[0] = t
+f92:
+	if !(m && rc == 99) {
+		goto f93
+	}
+	a("Trebia SNP 1000,")
+	fd.Add("arch", "Trebia SNP 1000")
+	d[0] = t
+f93:
+	if !(m && rc == 100) {
+		goto f94
+	}
+	a("STMicroelectronics ST200,")
+	fd.Add("arch", "STMicroelectronics ST200")
+	d[0] = t
+f94:
+	if !(m && rc == 101) {
+		goto f95
+	}
+	a("Ubicom IP2022,")
+	fd.Add("arch", "Ubicom IP2022")
+	d[0] = t
+f95:
+	if !(m && rc == 102) {
+		goto f96
+	}
+	a("MAX Processor,")
+	fd.Add("arch", "MAX Processor")
+	d[0] = t
+f96:
+	if !(m && rc == 103) {
+		goto f97
+	}
+	a("NatSemi CompactRISC,")
+	fd.Add("arch", "NatSemi CompactRISC")
+	d[0] = t
+f97:
+	if !(m && rc == 104) {
+		goto f98
+	}
+	a("Fujitsu F2MC16,")
+	fd.Add("arch", "Fujitsu F2MC16")
+	d[0] = t
+f98:
+	if !(m && rc == 105) {
+		goto f99
+	}
+	a("TI msp430,")
+	fd.Add("arch", "TI msp430")
+	d[0] = t
+f99:
+	if !(m && rc == 106) {
+		goto f9a
+	}
+	a("Analog Devices Blackfin,")
+	fd.Add("arch", "Analog Devices Blackfin")
+	d[0] = t
+f9a:
+	if !(m && rc == 107) {
+		goto f9b
+	}
+	a("S1C33 Family of Seiko Epson,")
+	fd.Add("arch", "S1C33 Family of Seiko Epson")
+	d[0] = t
+f9b:
+	if !(m && rc == 108) {
+		goto f9c
+	}
+	a("Sharp embedded,")
+	fd.Add("arch", "Sharp embedded")
+	d[0] = t
+f9c:
+	if !(m && rc == 109) {
+		goto f9d
+	}
+	a("Arca RISC,")
+	fd.Add("arch", "Arca RISC")
+	d[0] = t
+f9d:
+	if !(m && rc == 110) {
+		goto f9e
+	}
+	a("PKU-Unity Ltd.,")
+	fd.Add("arch", "PKU-Unity Ltd.")
+	d[0] = t
+f9e:
+	if !(m && rc == 111) {
+		goto f9f
+	}
+	a("eXcess: 16/32/64-bit,")
+	fd.Add("arch", "eXcess: 16/32/64-bit")
+	d[0] = t
+f9f:
+	if !(m && rc == 112) {
+		goto fa0
+	}
+	a("Icera Deep Execution Processor,")
+	fd.Add("arch", "Icera Deep Execution Processor")
+	d[0] = t
+fa0:
+	if !(m && rc == 113) {
+		goto fa1
+	}
+	a("Altera Nios II,")
+	fd.Add("arch", "Altera Nios II")
+	d[0] = t
+fa1:
+	if !(m && rc == 114) {
+		goto fa2
+	}
+	a("NatSemi CRX,")
+	fd.Add("arch", "NatSemi CRX")
+	d[0] = t
+fa2:
+	if !(m && rc == 115) {
+		goto fa3
+	}
+	a("Motorola XGATE,")
+	fd.Add("arch", "Motorola XGATE")
+	d[0] = t
+fa3:
+	if !(m && rc == 116) {
+		goto fa4
+	}
+	a("Infineon C16x/XC16x,")
+	fd.Add("arch", "Infineon C16x/XC16x")
+	d[0] = t
+fa4:
+	if !(m && rc == 117) {
+		goto fa5
+	}
+	a("Renesas M16C series,")
+	fd.Add("arch", "Renesas M16C series")
+	d[0] = t
+fa5:
+	if !(m && rc == 118) {
+		goto fa6
+	}
+	a("Microchip dsPIC30F,")
+	fd.Add("arch", "Microchip dsPIC30F")
+	d[0] = t
+fa6:
+	if !(m && rc == 119) {
+		goto fa7
+	}
+	a("Freescale RISC core,")
+	fd.Add("arch", "Freescale RISC core")
+	d[0] = t
+fa7:
+	if !(m && rc == 120) {
+		goto fa8
+	}
+	a("Renesas M32C series,")
+	fd.Add("arch", "Renesas M32C series")
+	d[0] = t
+fa8:
+	if !(m && rc == 131) {
+		goto fa9
+	}
+	a("Altium TSK3000 core,")
+	fd.Add("arch", "Altium TSK3000 core")
+	d[0] = t
+fa9:
+	if !(m && rc == 132) {
+		goto faa
+	}
+	a("Freescale RS08,")
+	fd.Add("arch", "Freescale RS08")
+	d[0] = t
+faa:
+	if !(m && rc == 134) {
+		goto fab
+	}
+	a("Cyan Technology eCOG2,")
+	fd.Add("arch", "Cyan Technology eCOG2")
+	d[0] = t
+fab:
+	if !(m && rc == 135) {
+		goto fac
+	}
+	a("Sunplus S+core7 RISC,")
+	fd.Add("arch", "Sunplus S+core7 RISC")
+	d[0] = t
+fac:
+	if !(m && rc == 136) {
+		goto fad
+	}
+	a("New Japan Radio (NJR) 24-bit DSP,")
+	fd.Add("arch", "New Japan Radio (NJR) 24-bit DSP")
+	d[0] = t
+fad:
+	if !(m && rc == 137) {
+		goto fae
+	}
+	a("Broadcom VideoCore III,")
+	fd.Add("arch", "Broadcom VideoCore III")
+	d[0] = t
+fae:
+	if !(m && rc == 138) {
+		goto faf
+	}
+	a("LatticeMico32,")
+	fd.Add("arch", "LatticeMico32")
+	d[0] = t
+faf:
+	if !(m && rc == 139) {
+		goto fb0
+	}
+	a("Seiko Epson C17 family,")
+	fd.Add("arch", "Seiko Epson C17 family")
+	d[0] = t
+fb0:
+	if !(m && rc == 140) {
+		goto fb1
+	}
+	a("TI TMS320C6000 DSP family,")
+	fd.Add("arch", "TI TMS320C6000 DSP family")
+	d[0] = t
+fb1:
+	if !(m && rc == 141) {
+		goto fb2
+	}
+	a("TI TMS320C2000 DSP family,")
+	fd.Add("arch", "TI TMS320C2000 DSP family")
+	d[0] = t
+fb2:
+	if !(m && rc == 142) {
+		goto fb3
+	}
+	a("TI TMS320C55x DSP family,")
+	fd.Add("arch", "TI TMS320C55x DSP family")
+	d[0] = t
+fb3:
+	if !(m && rc == 160) {
+		goto fb4
+	}
+	a("STMicroelectronics 64bit VLIW DSP,")
+	fd.Add("arch", "STMicroelectronics 64bit VLIW DSP")
+	d[0] = t
+fb4:
+	if !(m && rc == 161) {
+		goto fb5
+	}
+	a("Cypress M8C,")
+	fd.Add("arch", "Cypress M8C")
+	d[0] = t
+fb5:
+	if !(m && rc == 162) {
+		goto fb6
+	}
+	a("Renesas R32C series,")
+	fd.Add("arch", "Renesas R32C series")
+	d[0] = t
+fb6:
+	if !(m && rc == 163) {
+		goto fb7
+	}
+	a("NXP TriMedia family,")
+	fd.Add("arch", "NXP TriMedia family")
+	d[0] = t
+fb7:
+	if !(m && rc == 164) {
+		goto fb8
+	}
+	a("QUALCOMM DSP6,")
+	fd.Add("arch", "QUALCOMM DSP6")
+	d[0] = t
+fb8:
+	if !(m && rc == 165) {
+		goto fb9
+	}
+	a("Intel 8051 and variants,")
+	fd.Add("arch", "Intel 8051 and variants")
+	d[0] = t
+fb9:
+	if !(m && rc == 166) {
+		goto fba
+	}
+	a("STMicroelectronics STxP7x family,")
+	fd.Add("arch", "STMicroelectronics STxP7x family")
+	d[0] = t
+fba:
+	if !(m && rc == 167) {
+		goto fbb
+	}
+	a("Andes embedded RISC,")
+	fd.Add("arch", "Andes embedded RISC")
+	d[0] = t
+fbb:
+	if !(m && rc == 168) {
+		goto fbc
+	}
+	a("Cyan eCOG1X family,")
+	fd.Add("arch", "Cyan eCOG1X family")
+	d[0] = t
+fbc:
+	if !(m && rc == 169) {
+		goto fbd
+	}
+	a("Dallas MAXQ30,")
+	fd.Add("arch", "Dallas MAXQ30")
+	d[0] = t
+fbd:
+	if !(m && rc == 170) {
+		goto fbe
+	}
+	a("New Japan Radio (NJR) 16-bit DSP,")
+	fd.Add("arch", "New Japan Radio (NJR) 16-bit DSP")
+	d[0] = t
+fbe:
+	if !(m && rc == 171) {
+		goto fbf
+	}
+	a("M2000 Reconfigurable RISC,")
+	fd.Add("arch", "M2000 Reconfigurable RISC")
+	d[0] = t
+fbf:
+	if !(m && rc == 172) {
+		goto fc0
+	}
+	a("Cray NV2 vector architecture,")
+	fd.Add("arch", "Cray NV2 vector architecture")
+	d[0] = t
+fc0:
+	if !(m && rc == 173) {
+		goto fc1
+	}
+	a("Renesas RX family,")
+	fd.Add("arch", "Renesas RX family")
+	d[0] = t
+fc1:
+	if !(m && rc == 174) {
+		goto fc2
+	}
+	a("META,")
+	fd.Add("arch", "META")
+	d[0] = t
+fc2:
+	if !(m && rc == 175) {
+		goto fc3
+	}
+	a("MCST Elbrus,")
+	fd.Add("arch", "MCST Elbrus")
+	d[0] = t
+fc3:
+	if !(m && rc == 176) {
+		goto fc4
+	}
+	a("Cyan Technology eCOG16 family,")
+	fd.Add("arch", "Cyan Technology eCOG16 family")
+	d[0] = t
+fc4:
+	if !(m && rc == 177) {
+		goto fc5
+	}
+	a("NatSemi CompactRISC,")
+	fd.Add("arch", "NatSemi CompactRISC")
+	d[0] = t
+fc5:
+	if !(m && rc == 178) {
+		goto fc6
+	}
+	a("Freescale Extended Time Processing Unit,")
+	fd.Add("arch", "Freescale Extended Time Processing Unit")
+	d[0] = t
+fc6:
+	if !(m && rc == 179) {
+		goto fc7
+	}
+	a("Infineon SLE9X,")
+	fd.Add("arch", "Infineon SLE9X")
+	d[0] = t
+fc7:
+	if !(m && rc == 180) {
+		goto fc8
+	}
+	a("Intel L1OM,")
+	fd.Add("arch", "Intel L1OM")
+	d[0] = t
+fc8:
+	if !(m && rc == 181) {
+		goto fc9
+	}
+	a("Intel K1OM,")
+	fd.Add("arch", "Intel K1OM")
+	d[0] = t
+fc9:
+	if !(m && rc == 183) {
+		goto fca
+	}
+	a("ARM aarch64,")
+	fd.Add("arch", "ARM aarch64")
+	d[0] = t
+fca:
+	if !(m && rc == 185) {
+		goto fcb
+	}
+	a("Atmel 32-bit family,")
+	fd.Add("arch", "Atmel 32-bit family")
+	d[0] = t
+fcb:
+	if !(m && rc == 186) {
+		goto fcc
+	}
+	a("STMicroeletronics STM8 8-bit,")
+	fd.Add("arch", "STMicroeletronics STM8 8-bit")
+	d[0] = t
+fcc:
+	if !(m && rc == 187) {
+		goto fcd
+	}
+	a("Tilera TILE64,")
+	fd.Add("arch", "Tilera TILE64")
+	d[0] = t
+fcd:
+	if !(m && rc == 188) {
+		goto fce
+	}
+	a("Tilera TILEPro,")
+	fd.Add("arch", "Tilera TILEPro")
+	d[0] = t
+fce:
+	if !(m && rc == 189) {
+		goto fcf
+	}
+	a("Xilinx MicroBlaze 32-bit RISC,")
+	fd.Add("arch", "Xilinx MicroBlaze 32-bit RISC")
+	d[0] = t
+fcf:
+	if !(m && rc == 190) {
+		goto fd0
+	}
+	a("NVIDIA CUDA architecture,")
+	fd.Add("arch", "NVIDIA CUDA architecture")
+	d[0] = t
+fd0:
+	if !(m && rc == 191) {
+		goto fd1
+	}
+	a("Tilera TILE-Gx,")
+	fd.Add("arch", "Tilera TILE-Gx")
+	d[0] = t
+fd1:
+	if !(m && rc == 197) {
+		goto fd2
+	}
+	a("Renesas RL78 family,")
+	fd.Add("arch", "Renesas RL78 family")
+	d[0] = t
+fd2:
+	if !(m && rc == 199) {
+		goto fd3
+	}
+	a("Renesas 78K0R,")
+	fd.Add("arch", "Renesas 78K0R")
+	d[0] = t
+fd3:
+	rc, m = w2b(st, r, hw, po, po+18)
+	switch rc {
+	case 4183:
+		a("AVR (unofficial),")
+	case 4185:
+		a("MSP430 (unofficial),")
+	case 4643:
+		a("Adapteva Epiphany (unofficial),")
+	case 9520:
+		a("Morpho MT (unofficial),")
+	case 13104:
+		a("FR30 (unofficial),")
+	case 13350:
+		a("OpenRISC (obsolete),")
+	case 18056:
+		a("Infineon C166 (unofficial),")
+	case 21569:
+		a("Cygnus FRV (unofficial),")
+	case 23205:
+		a("DLX (unofficial),")
+	case 30288:
+		a("Cygnus D10V (unofficial),")
+	case 30326:
+		a("Cygnus D30V (unofficial),")
+	case 33303:
+		a("Ubicom IP2xxx (unofficial),")
+	case 33906:
+		a("OpenRISC (obsolete),")
+	case 36901:
+		a("Cygnus PowerPC (unofficial),")
+	case 36902:
+		a("Alpha (unofficial),")
+	case 36929:
+		a("Cygnus M32R (unofficial),")
+	case 36992:
+		a("Cygnus V850 (unofficial),")
+	case 41872:
+		a("IBM S/390 (obsolete),")
+	case 43975:
+		a("Old Xtensa (unofficial),")
+	case 44357:
+		a("xstormy16 (unofficial),")
+	case 47787:
+		a("Old MicroBlaze (unofficial),,")
+	case 48879:
+		a("Cygnus MN10300 (unofficial),")
+	case 57005:
+		a("Cygnus MN10200 (unofficial),")
+	case 61453:
+		a("Toshiba MeP (unofficial),")
+	case 65200:
+		a("Renesas M32C (unofficial),")
+	case 65210:
+		a("Vitesse IQ2000 (unofficial),")
+	case 65211:
+		a("NIOS (unofficial),")
+	case 65261:
+		a("Moxie (unofficial),")
+	default:
+		{
+			goto fd4
+		}
+	}
+	d[0] = t
+fd4:
+	if d[0] {
+		goto ff0
+	}
+	rc, m = w2b(st, r, hw, po, po+18)
+	if !m {
+		goto ff1
+	}
+	a(wizardry.FormatDescription("*unknown arch 0x%x*", int64(int16(rc))))
+	fd.Add("arch", wizardry.FormatDescription("0x%x", int64(int16(rc))))
+ff1:
+	d[0] = t
+ff0:
+	rc, m = w4b(st, r, hw, po, po+20)
+	switch rc {
+	case 0:
+		a("invalid version")
+	case 1:
+		a("version 1")
+	default:
+		{
+			goto ff2
+		}
+	}
+	d[0] = t
+ff2:
+	return out
+}
+
+func identifyIcoEntry(st *state, r *wizutil.SliceReader, po int64, fd wizardry.Fields) []string {
+	var out []string
+	var ss []string
+	ss = ss[0:]
+	var gf [32]int64
+	gf[0] = po
+	var ra uint64
+	ra &= ra
+	var rb uint64
+	rb &= rb
+	var rc uint64
+	rc &= rc
+	var rA int64
+	rA &= rA
+	var rB int64
+	rB &= rB
+	var sv string
+	sv += ""
+	var sb []byte
+	sb = sb[0:]
+	var sm [32]uint64
+	sm[0] &= sm[0]
+	var k bool
+	k = !!k
+	var l bool
+	l = !!l
+	var m bool
+	m = !!m
+	var d = make([]bool, 32)
+	d[0] = !!d[0]
+	var hw = gp(r, po, 8)
+
+	a := func(args ...string) {
+		out = append(out, args...)
+	}
+	if !st.gw.EnterUse() {
+		goto f1
+	}
+	ss = identifyCurIcoEntry(st, r, po, fd)
+	st.gw.ExitUse()
+	if len(ss) == 0 {
+		goto f1
+	}
+	a(ss...)
+f1:
+	rc, m = w2l(st, r, hw, po, po+4)
+	if !(m && rc > 1) {
+		goto f2
+	}
+	a(wizardry.FormatDescription("\\b, %d planes", rc))
+f2:
+	rc, m = w2l(st, r, hw, po, po+6)
+	if !(m && rc > 1) {
+		goto f3
+	}
+	a(wizardry.FormatDescription("\\b, %d bits/pixel", rc))
+f3:
+	return out
+}
+
+func identifyLotusCells(st *state, r *wizutil.SliceReader, po int64, fd wizardry.Fields) []string {
+	var out []string
+	var ss []string
+	ss = ss[0:]
+	var gf [32]int64
+	gf[0] = po
+	var ra uint64
+	ra &= ra
+	var rb uint64
+	rb &= rb
+	var rc uint64
+	rc &= rc
+	var rA int64
+	rA &= rA
+	var rB int64
+	rB &= rB
+	var sv string
+	sv += ""
+	var sb []byte
+	sb = sb[0:]
+	var sm [32]uint64
+	sm[0] &= sm[0]
+	var k bool
+	k = !!k
+	var l bool
+	l = !!l
+	var m bool
+	m = !!m
+	var d = make([]bool, 32)
+	d[0] = !!d[0]
+	var hw = gp(r, po, 12)
+
+	a := func(args ...string) {
+		out = append(out, args...)
+	}
+	rc, m = w4b(st, r, hw, po, po)
+	if !(m && rc == 100665344) {
+		goto f1
+	}
+	a("\\b, cell range")
+	rc, m = w4l(st, r, hw, po, po+4)
+	if !(m && rc != 0) {
+		goto f2
+	}
+	rc, m = w2l(st, r, hw, po, po+4)
+	if !m {
+		goto f3
+	}
+	a(wizardry.FormatDescription("\\b%d,", rc))
+f3:
+	rc, m = w2l(st, r, hw, po, po+6)
+	if !m {
+		goto f4
+	}
+	a(wizardry.FormatDescription("\\b%d-", rc))
+f4:
+f2:
+	rc, m = w2l(st, r, hw, po, po+8)
+	if !m {
+		goto f5
+	}
+	a(wizardry.FormatDescription("\\b%d,", rc))
+f5:
+	rc, m = w2l(st, r, hw, po, po+10)
+	if !m {
+		goto f6
+	}
+	a(wizardry.FormatDescription("\\b%d", rc))
+f6:
+f1:
+	return out
+}
+
+func identifyMachO(st *state, r *wizutil.SliceReader, po int64, fd wizardry.Fields) []string {
+	var out []string
+	var ss []string
+	ss = ss[0:]
+	var gf [32]int64
+	gf[0] = po
+	var ra uint64
+	ra &= ra
+	var rb uint64
+	rb &= rb
+	var rc uint64
+	rc &= rc
+	var rA int64
+	rA &= rA
+	var rB int64
+	rB &= rB
+	var sv string
+	sv += ""
+	var sb []byte
+	sb = sb[0:]
+	var sm [32]uint64
+	sm[0] &= sm[0]
+	var k bool
+	k = !!k
+	var l bool
+	l = !!l
+	var m bool
+	m = !!m
+	var d = make([]bool, 32)
+	d[0] = !!d[0]
+
+	a := func(args ...string) {
+		out = append(out, args...)
+	}
+	gf[0] = po
+	a("\\b [")
+	if !st.gw.EnterUse() {
+		goto f1
+	}
+	ss = identifyMachOCpu(st, r, po, fd)
+	st.gw.ExitUse()
+	if len(ss) == 0 {
+		goto f1
+	}
+	a(ss...)
+	a("\\b")
+f1:
+	ra, k = f4b(st, r, 8)
+	if !k {
+		goto f2
+	}
+	if st.ic >= 15 || int64(ra)+gf[0] <= 0 || int64(ra)+gf[0] >= r.Size() {
+		goto f2
+	}
+	st.ic++
+	ss = identify__Root(st, r.Slice(int64(ra)+gf[0]), 0, fd)
+	st.ic--
+	if len(ss) == 0 {
+		goto f2
+	}
+	a(ss...)
+f2:
+	a("\\b]")
+	return out
+}
+
+func identifyMachOBe(st *state, r *wizutil.SliceReader, po int64, fd wizardry.Fields) []string {
+	var out []string
+	var ss []string
+	ss = ss[0:]
+	var gf [32]int64
+	gf[0] = po
+	var ra uint64
+	ra &= ra
+	var rb uint64
+	rb &= rb
+	var rc uint64
+	rc &= rc
+	var rA int64
+	rA &= rA
+	var rB int64
+	rB &= rB
+	var sv string
+	sv += ""
+	var sb []byte
+	sb = sb[0:]
+	var sm [32]uint64
+	sm[0] &= sm[0]
+	var k bool
+	k = !!k
+	var l bool
+	l = !!l
+	var m bool
+	m = !!m
+	var d = make([]bool, 32)
+	d[0] = !!d[0]
+	var hw = gp(r, po, 16)
+
+	a := func(args ...string) {
+		out = append(out, args...)
+	}
+	rc, m = w1l(st, r, hw, po, po)
+	if !(m && rc == 207) {
+		goto f1
+	}
+	a("64-bit")
+f1:
+	rc, m = w4b(st, r, hw, po, po)
+	if !(m && rc&1 == 0) {
+		goto f2
+	}
+	fd.Add("bits", "32")
+f2:
+	if !(m && rc&1 == 1) {
+		goto f3
+	}
+	fd.Add("bits", "64")
+f3:
+	if !st.gw.EnterUse() {
+		goto f4
+	}
+	ss = identifyMachOCpu(st, r, po+4, fd)
+	st.gw.ExitUse()
+	if len(ss) == 0 {
+		goto f4
+	}
+	a(ss...)
+f4:
+	rc, m = w4b(st, r, hw, po, po+12)
+	if !(m && rc == 1) {
+		goto f5
+	}
+	a("object")
+	fd.Add("type", "object")
+f5:
+	if !(m && rc == 2) {
+		goto f6
+	}
+	a("executable")
+	fd.Add("type", "executable")
+f6:
+	if !(m && rc == 3) {
+		goto f7
+	}
+	a("fixed virtual memory shared library")
+	fd.Add("type", "fixed virtual memory shared library")
+f7:
+	if !(m && rc == 4) {
+		goto f8
+	}
+	a("core")
+	fd.Add("type", "core")
+f8:
+	if !(m && rc == 5) {
+		goto f9
+	}
+	a("preload executable")
+	fd.Add("type", "preload executable")
+f9:
+	if !(m && rc == 6) {
+		goto fa
+	}
+	a("dynamically linked shared library")
+	fd.Add("type", "dynamically linked shared library")
+fa:
+	if !(m && rc == 7) {
+		goto fb
+	}
+	a("dynamic linker")
+	fd.Add("type", "dynamic linker")
+fb:
+	if !(m && rc == 8) {
+		goto fc
+	}
+	a("bundle")
+	fd.Add("type", "bundle")
+fc:
+	if !(m && rc == 9) {
+		goto fd
+	}
+	a("dynamically linked shared library stub")
+	fd.Add("type", "dynamically linked shared library stub")
+fd:
+	if !(m && rc == 10) {
+		goto fe
+	}
+	a("dSYM companion file")
+	fd.Add("type", "dSYM companion file")
+fe:
+	if !(m && rc == 11) {
+		goto ff
+	}
+	a("kext bundle")
+	fd.Add("type", "kext bundle")
+ff:
+	if !(m && int64(int32(rc)) > 11) {
+		goto f10
+	}
+	if !m {
+		goto f11
+	}
+	a(wizardry.FormatDescription("filetype=%ld", int64(int32(rc))))
+	fd.Add("type", wizardry.FormatDescription("%ld", int64(int32(rc))))
+f11:
+f10:
+	return out
+}
+
+func identifyMachOBe__Swapped(st *state, r *wizutil.SliceReader, po int64, fd wizardry.Fields) []string {
+	var out []string
+	var ss []string
+	ss = ss[0:]
+	var gf [32]int64
+	gf[0] = po
+	var ra uint64
+	ra &= ra
+	var rb uint64
+	rb &= rb
+	var rc uint64
+	rc &= rc
+	var rA int64
+	rA &= rA
+	var rB int64
+	rB &= rB
+	var sv string
+	sv += ""
+	var sb []byte
+	sb = sb[0:]
+	var sm [32]uint64
+	sm[0] &= sm[0]
+	var k bool
+	k = !!k
+	var l bool
+	l = !!l
+	var m bool
+	m = !!m
+	var d = make([]bool, 32)
+	d[0] = !!d[0]
+	var hw = gp(r, po, 16)
+
+	a := func(args ...string) {
+		out = append(out, args...)
+	}
+	rc, m = w1b(st, r, hw, po, po)
+	if !(m && rc == 207) {
+		goto f1
+	}
+	a("64-bit")
+f1:
+	rc, m = w4l(st, r, hw, po, po)
+	if !(m && rc&1 == 0) {
+		goto f2
+	}
+	fd.Add("bits", "32")
+f2:
+	if !(m && rc&1 == 1) {
+		goto f3
+	}
+	fd.Add("bits", "64")
+f3:
+	if !st.gw.EnterUse() {
+		goto f4
+	}
+	ss = identifyMachOCpu__Swapped(st, r, po+4, fd)
+	st.gw.ExitUse()
+	if len(ss) == 0 {
+		goto f4
+	}
+	a(ss...)
+f4:
+	rc, m = w4l(st, r, hw, po, po+12)
+	if !(m && rc == 1) {
+		goto f5
+	}
+	a("object")
+	fd.Add("type", "object")
+f5:
+	if !(m && rc == 2) {
+		goto f6
+	}
+	a("executable")
+	fd.Add("type", "executable")
+f6:
+	if !(m && rc == 3) {
+		goto f7
+	}
+	a("fixed virtual memory shared library")
+	fd.Add("type", "fixed virtual memory shared library")
+f7:
+	if !(m && rc == 4) {
+		goto f8
+	}
+	a("core")
+	fd.Add("type", "core")
+f8:
+	if !(m && rc == 5) {
+		goto f9
+	}
+	a("preload executable")
+	fd.Add("type", "preload executable")
+f9:
+	if !(m && rc == 6) {
+		goto fa
+	}
+	a("dynamically linked shared library")
+	fd.Add("type", "dynamically linked shared library")
+fa:
+	if !(m && rc == 7) {
+		goto fb
+	}
+	a("dynamic linker")
+	fd.Add("type", "dynamic linker")
+fb:
+	if !(m && rc == 8) {
+		goto fc
+	}
+	a("bundle")
+	fd.Add("type", "bundle")
+fc:
+	if !(m && rc == 9) {
+		goto fd
+	}
+	a("dynamically linked shared library stub")
+	fd.Add("type", "dynamically linked shared library stub")
+fd:
+	if !(m && rc == 10) {
+		goto fe
+	}
+	a("dSYM companion file")
+	fd.Add("type", "dSYM companion file")
+fe:
+	if !(m && rc == 11) {
+		goto ff
+	}
+	a("kext bundle")
+	fd.Add("type", "kext bundle")
+ff:
+	if !(m && int64(int32(rc)) > 11) {
+		goto f10
+	}
+	if !m {
+		goto f11
+	}
+	a(wizardry.FormatDescription("filetype=%ld", int64(int32(rc))))
+	fd.Add("type", wizardry.FormatDescription("%ld", int64(int32(rc))))
+f11:
+f10:
+	return out
+}
+
+func identifyMachOCpu(st *state, r *wizutil.SliceReader, po int64, fd wizardry.Fields) []string {
+	var out []string
+	var ss []string
+	ss = ss[0:]
+	var gf [32]int64
+	gf[0] = po
+	var ra uint64
+	ra &= ra
+	var rb uint64
+	rb &= rb
+	var rc uint64
+	rc &= rc
+	var rA int64
+	rA &= rA
+	var rB int64
+	rB &= rB
+	var sv string
+	sv += ""
+	var sb []byte
+	sb = sb[0:]
+	var sm [32]uint64
+	sm[0] &= sm[0]
+	var k bool
+	k = !!k
+	var l bool
+	l = !!l
+	var m bool
+	m = !!m
+	var d = make([]bool, 32)
+	d[0] = !!d[0]
+	var hw = gp(r, po, 8)
+
+	a := func(args ...string) {
+		out = append(out, args...)
+	}
+	rc, m = w4b(st, r, hw, po, po)
+	if !(m && rc&16777216 == 0) {
+		goto f1
+	}
+	if !(m && rc&16777215 == 1) {
+		goto f2
+	}
+	fd.Add("arch", "vax")
+	rc, m = w4b(st, r, hw, po, po+4)
+	if !(m && rc&16777215 == 0) {
+		goto f3
+	}
+	a("vax")
+f3:
+	if !(m && rc&16777215 == 1) {
+		goto f4
+	}
+	a("vax11/780")
+f4:
+	if !(m && rc&16777215 == 2) {
+		goto f5
+	}
+	a("vax11/785")
+f5:
+	if !(m && rc&16777215 == 3) {
+		goto f6
+	}
+	a("vax11/750")
+f6:
+	if !(m && rc&16777215 == 4) {
+		goto f7
+	}
+	a("vax11/730")
+f7:
+	if !(m && rc&16777215 == 5) {
+		goto f8
+	}
+	a("uvaxI")
+f8:
+	if !(m && rc&16777215 == 6) {
+		goto f9
+	}
+	a("uvaxII")
+f9:
+	if !(m && rc&16777215 == 7) {
+		goto fa
+	}
+	a("vax8200")
+fa:
+	if !(m && rc&16777215 == 8) {
+		goto fb
+	}
+	a("vax8500")
+fb:
+	if !(m && rc&16777215 == 9) {
+		goto fc
+	}
+	a("vax8600")
+fc:
+	if !(m && rc&16777215 == 10) {
+		goto fd
+	}
+	a("vax8650")
+fd:
+	if !(m && rc&16777215 == 11) {
+		goto fe
+	}
+	a("vax8800")
+fe:
+	if !(m && rc&16777215 == 12) {
+		goto ff
+	}
+	a("uvaxIII")
+ff:
+	if !(m && int64(int32(rc&16777215)) > 12) {
+		goto f10
+	}
+	a(wizardry.FormatDescription("vax subarchitecture=%ld", int64(int32(rc&16777215))))
+f10:
+f2:
+	if !(m && rc&16777215 == 2) {
+		goto f11
+	}
+	a("romp")
+	fd.Add("arch", "romp")
+f11:
+	if !(m && rc&16777215 == 3) {
+		goto f12
+	}
+	a("architecture=3")
+	fd.Add("arch", "architecture=3")
+f12:
+	if !(m && rc&16777215 == 4) {
+		goto f13
+	}
+	a("ns32032")
+	fd.Add("arch", "ns32032")
+f13:
+	if !(m && rc&16777215 == 5) {
+		goto f14
+	}
+	a("ns32332")
+	fd.Add("arch", "ns32332")
+f14:
+	if !(m && rc&16777215 == 6) {
+		goto f15
+	}
+	a("m68k")
+	fd.Add("arch", "m68k")
+f15:
+	if !(m && rc&16777215 == 7) {
+		goto f16
+	}
+	fd.Add("arch", "i386")
+	rc, m = w4b(st, r, hw, po, po+4)
+	if !(m && rc&15 == 3) {
+		goto f17
+	}
+	a("i386")
+f17:
+	if !(m && rc&15 == 4) {
+		goto f18
+	}
+	a("i486")
+	if !(m && rc&16777200 == 0) {
+		goto f19
+	}
+f19:
+	if !(m && rc&16777200 == 128) {
+		goto f1a
+	}
+	a("\\bsx")
+f1a:
+f18:
+	if !(m && rc&15 == 5) {
+		goto f1b
+	}
+	a("i586")
+f1b:
+	if !(m && rc&15 == 6) {
+		goto f1c
+	}
+	if !(m && rc&16777200 == 0) {
+		goto f1d
+	}
+	a("p6")
+f1d:
+	if !(m && rc&16777200 == 16) {
+		goto f1e
+	}
+	a("pentium_pro")
+f1e:
+	if !(m && rc&16777200 == 32) {
+		goto f1f
+	}
+	a("pentium_2_m0x20")
+f1f:
+	if !(m && rc&16777200 == 48) {
+		goto f20
+	}
+	a("pentium_2_m3")
+f20:
+	if !(m && rc&16777200 == 64) {
+		goto f21
+	}
+	a("pentium_2_m0x40")
+f21:
+	if !(m && rc&16777200 == 80) {
+		goto f22
+	}
+	a("pentium_2_m5")
+f22:
+	if !(m && int64(int32(rc&16777200)) > 80) {
+		goto f23
+	}
+	a(wizardry.FormatDescription("pentium_2_m0x%lx", int64(int32(rc&16777200))))
+f23:
+f1c:
+	if !(m && rc&15 == 7) {
+		goto f24
+	}
+	a("celeron")
+	if !(m && rc&16777200 == 0) {
+		goto f25
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f25:
+	if !(m && rc&16777200 == 16) {
+		goto f26
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f26:
+	if !(m && rc&16777200 == 32) {
+		goto f27
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f27:
+	if !(m && rc&16777200 == 48) {
+		goto f28
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f28:
+	if !(m && rc&16777200 == 64) {
+		goto f29
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f29:
+	if !(m && rc&16777200 == 80) {
+		goto f2a
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f2a:
+	if !(m && rc&16777200 == 96) {
+		goto f2b
+	}
+f2b:
+	if !(m && rc&16777200 == 112) {
+		goto f2c
+	}
+	a("\\b_mobile")
+f2c:
+	if !(m && int64(int32(rc&16777200)) > 112) {
+		goto f2d
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f2d:
+f24:
+	if !(m && rc&15 == 8) {
+		goto f2e
+	}
+	a("pentium_3")
+	if !(m && rc&16777200 == 0) {
+		goto f2f
+	}
+f2f:
+	if !(m && rc&16777200 == 16) {
+		goto f30
+	}
+	a("\\b_m")
+f30:
+	if !(m && rc&16777200 == 32) {
+		goto f31
+	}
+	a("\\b_xeon")
+f31:
+	if !(m && int64(int32(rc&16777200)) > 32) {
+		goto f32
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f32:
+f2e:
+	if !(m && rc&15 == 9) {
+		goto f33
+	}
+	a("pentiumM")
+	if !(m && rc&16777200 == 0) {
+		goto f34
+	}
+f34:
+	if !(m && int64(int32(rc&16777200)) > 0) {
+		goto f35
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f35:
+f33:
+	if !(m && rc&15 == 10) {
+		goto f36
+	}
+	a("pentium_4")
+	if !(m && rc&16777200 == 0) {
+		goto f37
+	}
+f37:
+	if !(m && rc&16777200 == 16) {
+		goto f38
+	}
+	a("\\b_m")
+f38:
+	if !(m && int64(int32(rc&16777200)) > 16) {
+		goto f39
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f39:
+f36:
+	if !(m && rc&15 == 11) {
+		goto f3a
+	}
+	a("itanium")
+	if !(m && rc&16777200 == 0) {
+		goto f3b
+	}
+f3b:
+	if !(m && rc&16777200 == 16) {
+		goto f3c
+	}
+	a("\\b_2")
+f3c:
+	if !(m && int64(int32(rc&16777200)) > 16) {
+		goto f3d
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f3d:
+f3a:
+	if !(m && rc&15 == 12) {
+		goto f3e
+	}
+	a("xeon")
+	if !(m && rc&16777200 == 0) {
+		goto f3f
+	}
+f3f:
+	if !(m && rc&16777200 == 16) {
+		goto f40
+	}
+	a("\\b_mp")
+f40:
+	if !(m && int64(int32(rc&16777200)) > 16) {
+		goto f41
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f41:
+f3e:
+	if !(m && int64(int32(rc&15)) > 12) {
+		goto f42
+	}
+	a(wizardry.FormatDescription("ia32 family=%ld", int64(int32(rc&15))))
+	if !(m && rc&16777200 == 0) {
+		goto f43
+	}
+f43:
+	if !(m && int64(int32(rc&16777200)) > 0) {
+		goto f44
+	}
+	a(wizardry.FormatDescription("model=%lx", int64(int32(rc&16777200))))
+f44:
+f42:
+f16:
+	if !(m && rc&16777215 == 8) {
+		goto f45
+	}
+	a("mips")
+	fd.Add("arch", "mips")
+	rc, m = w4b(st, r, hw, po, po+4)
+	if !(m && rc&16777215 == 1) {
+		goto f46
+	}
+	a("R2300")
+f46:
+	if !(m && rc&16777215 == 2) {
+		goto f47
+	}
+	a("R2600")
+f47:
+	if !(m && rc&16777215 == 3) {
+		goto f48
+	}
+	a("R2800")
+f48:
+	if !(m && rc&16777215 == 4) {
+		goto f49
+	}
+	a("R2000a")
+f49:
+	if !(m && rc&16777215 == 5) {
+		goto f4a
+	}
+	a("R2000")
+f4a:
+	if !(m && rc&16777215 == 6) {
+		goto f4b
+	}
+	a("R3000a")
+f4b:
+	if !(m && rc&16777215 == 7) {
+		goto f4c
+	}
+	a("R3000")
+f4c:
+	if !(m && int64(int32(rc&16777215)) > 7) {
+		goto f4d
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f4d:
+f45:
+	if !(m && rc&16777215 == 9) {
+		goto f4e
+	}
+	a("ns32532")
+	fd.Add("arch", "ns32532")
+f4e:
+	if !(m && rc&16777215 == 10) {
+		goto f4f
+	}
+	a("mc98000")
+	fd.Add("arch", "mc98000")
+f4f:
+	if !(m && rc&16777215 == 11) {
+		goto f50
+	}
+	a("hppa")
+	fd.Add("arch", "hppa")
+	rc, m = w4b(st, r, hw, po, po+4)
+	if !(m && rc&16777215 == 0) {
+		goto f51
+	}
+	a("7100")
+f51:
+	if !(m && rc&16777215 == 1) {
+		goto f52
+	}
+	a("7100LC")
+f52:
+	if !(m && int64(int32(rc&16777215)) > 1) {
+		goto f53
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f53:
+f50:
+	if !(m && rc&16777215 == 12) {
+		goto f54
+	}
+	a("arm")
+	fd.Add("arch", "arm")
+	rc, m = w4b(st, r, hw, po, po+4)
+	if !(m && rc&16777215 == 0) {
+		goto f55
+	}
+f55:
+	if !(m && rc&16777215 == 1) {
+		goto f56
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f56:
+	if !(m && rc&16777215 == 2) {
+		goto f57
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f57:
+	if !(m && rc&16777215 == 3) {
+		goto f58
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f58:
+	if !(m && rc&16777215 == 4) {
+		goto f59
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f59:
+	if !(m && rc&16777215 == 5) {
+		goto f5a
+	}
+	a("\\b_v4t")
+f5a:
+	if !(m && rc&16777215 == 6) {
+		goto f5b
+	}
+	a("\\b_v6")
+f5b:
+	if !(m && rc&16777215 == 7) {
+		goto f5c
+	}
+	a("\\b_v5tej")
+f5c:
+	if !(m && rc&16777215 == 8) {
+		goto f5d
+	}
+	a("\\b_xscale")
+f5d:
+	if !(m && rc&16777215 == 9) {
+		goto f5e
+	}
+	a("\\b_v7")
+f5e:
+	if !(m && rc&16777215 == 10) {
+		goto f5f
+	}
+	a("\\b_v7f")
+f5f:
+	if !(m && rc&16777215 == 11) {
+		goto f60
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f60:
+	if !(m && rc&16777215 == 12) {
+		goto f61
+	}
+	a("\\b_v7k")
+f61:
+	if !(m && int64(int32(rc&16777215)) > 12) {
+		goto f62
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f62:
+f54:
+	if !(m && rc&16777215 == 13) {
+		goto f63
+	}
+	fd.Add("arch", "mc88000")
+	rc, m = w4b(st, r, hw, po, po+4)
+	if !(m && rc&16777215 == 0) {
+		goto f64
+	}
+	a("mc88000")
+f64:
+	if !(m && rc&16777215 == 1) {
+		goto f65
+	}
+	a("mc88100")
+f65:
+	if !(m && rc&16777215 == 2) {
+		goto f66
+	}
+	a("mc88110")
+f66:
+	if !(m && int64(int32(rc&16777215)) > 2) {
+		goto f67
+	}
+	a(wizardry.FormatDescription("mc88000 subarchitecture=%ld", int64(int32(rc&16777215))))
+f67:
+f63:
+	if !(m && rc&16777215 == 14) {
+		goto f68
+	}
+	a("sparc")
+	fd.Add("arch", "sparc")
+f68:
+	if !(m && rc&16777215 == 15) {
+		goto f69
+	}
+	a("i860g")
+	fd.Add("arch", "i860g")
+f69:
+	if !(m && rc&16777215 == 16) {
+		goto f6a
+	}
+	a("alpha")
+	fd.Add("arch", "alpha")
+f6a:
+	if !(m && rc&16777215 == 17) {
+		goto f6b
+	}
+	a("rs6000")
+	fd.Add("arch", "rs6000")
+f6b:
+	if !(m && rc&16777215 == 18) {
+		goto f6c
+	}
+	a("ppc")
+	fd.Add("arch", "ppc")
+	rc, m = w4b(st, r, hw, po, po+4)
+	if !(m && rc&16777215 == 0) {
+		goto f6d
+	}
+f6d:
+	if !(m && rc&16777215 == 1) {
+		goto f6e
+	}
+	a("\\b_601")
+f6e:
+	if !(m && rc&16777215 == 2) {
+		goto f6f
+	}
+	a("\\b_602")
+f6f:
+	if !(m && rc&16777215 == 3) {
+		goto f70
+	}
+	a("\\b_603")
+f70:
+	if !(m && rc&16777215 == 4) {
+		goto f71
+	}
+	a("\\b_603e")
+f71:
+	if !(m && rc&16777215 == 5) {
+		goto f72
+	}
+	a("\\b_603ev")
+f72:
+	if !(m && rc&16777215 == 6) {
+		goto f73
+	}
+	a("\\b_604")
+f73:
+	if !(m && rc&16777215 == 7) {
+		goto f74
+	}
+	a("\\b_604e")
+f74:
+	if !(m && rc&16777215 == 8) {
+		goto f75
+	}
+	a("\\b_620")
+f75:
+	if !(m && rc&16777215 == 9) {
+		goto f76
+	}
+	a("\\b_650")
+f76:
+	if !(m && rc&16777215 == 10) {
+		goto f77
+	}
+	a("\\b_7400")
+f77:
+	if !(m && rc&16777215 == 11) {
+		goto f78
+	}
+	a("\\b_7450")
+f78:
+	if !(m && rc&16777215 == 100) {
+		goto f79
+	}
+	a("\\b_970")
+f79:
+	if !(m && int64(int32(rc&16777215)) > 100) {
+		goto f7a
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f7a:
+f6c:
+	if !(m && int64(int32(rc&16777215)) > 18) {
+		goto f7b
+	}
+	a(wizardry.FormatDescription("architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FormatDescription("%ld", int64(int32(rc&16777215))))
+f7b:
+f1:
+	if !(m && rc&16777216 == 16777216) {
+		goto f7c
+	}
+	if !(m && rc&16777215 == 0) {
+		goto f7d
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f7d:
+	if !(m && rc&16777215 == 1) {
+		goto f7e
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f7e:
+	if !(m && rc&16777215 == 2) {
+		goto f7f
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f7f:
+	if !(m && rc&16777215 == 3) {
+		goto f80
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f80:
+	if !(m && rc&16777215 == 4) {
+		goto f81
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f81:
+	if !(m && rc&16777215 == 5) {
+		goto f82
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f82:
+	if !(m && rc&16777215 == 6) {
+		goto f83
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f83:
+	if !(m && rc&16777215 == 7) {
+		goto f84
+	}
+	a("x86_64")
+	fd.Add("arch", "x86_64")
+	rc, m = w4b(st, r, hw, po, po+4)
+	if !(m && rc&16777215 == 0) {
+		goto f85
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f85:
+	if !(m && rc&16777215 == 1) {
+		goto f86
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f86:
+	if !(m && rc&16777215 == 2) {
+		goto f87
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f87:
+	if !(m && rc&16777215 == 3) {
+		goto f88
+	}
+f88:
+	if !(m && rc&16777215 == 4) {
+		goto f89
+	}
+	a("\\b_arch1")
+f89:
+	if !(m && int64(int32(rc&16777215)) > 4) {
+		goto f8a
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f8a:
+f84:
+	if !(m && rc&16777215 == 8) {
+		goto f8b
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f8b:
+	if !(m && rc&16777215 == 9) {
+		goto f8c
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f8c:
+	if !(m && rc&16777215 == 10) {
+		goto f8d
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f8d:
+	if !(m && rc&16777215 == 11) {
+		goto f8e
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f8e:
+	if !(m && rc&16777215 == 12) {
+		goto f8f
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", "arm64")
+f8f:
+	if !(m && rc&16777215 == 13) {
+		goto f90
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f90:
+	if !(m && rc&16777215 == 14) {
+		goto f91
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f91:
+	if !(m && rc&16777215 == 15) {
+		goto f92
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f92:
+	if !(m && rc&16777215 == 16) {
+		goto f93
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f93:
+	if !(m && rc&16777215 == 17) {
+		goto f94
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f94:
+	if !(m && rc&16777215 == 18) {
+		goto f95
+	}
+	a("ppc64")
+	fd.Add("arch", "ppc64")
+	rc, m = w4b(st, r, hw, po, po+4)
+	if !(m && rc&16777215 == 0) {
+		goto f96
+	}
+f96:
+	if !(m && rc&16777215 == 1) {
+		goto f97
+	}
+	a("\\b_601")
+f97:
+	if !(m && rc&16777215 == 2) {
+		goto f98
+	}
+	a("\\b_602")
+f98:
+	if !(m && rc&16777215 == 3) {
+		goto f99
+	}
+	a("\\b_603")
+f99:
+	if !(m && rc&16777215 == 4) {
+		goto f9a
+	}
+	a("\\b_603e")
+f9a:
+	if !(m && rc&16777215 == 5) {
+		goto f9b
+	}
+	a("\\b_603ev")
+f9b:
+	if !(m && rc&16777215 == 6) {
+		goto f9c
+	}
+	a("\\b_604")
+f9c:
+	if !(m && rc&16777215 == 7) {
+		goto f9d
+	}
+	a("\\b_604e")
+f9d:
+	if !(m && rc&16777215 == 8) {
+		goto f9e
+	}
+	a("\\b_620")
+f9e:
+	if !(m && rc&16777215 == 9) {
+		goto f9f
+	}
+	a("\\b_650")
+f9f:
+	if !(m && rc&16777215 == 10) {
+		goto fa0
+	}
+	a("\\b_7400")
+fa0:
+	if !(m && rc&16777215 == 11) {
+		goto fa1
+	}
+	a("\\b_7450")
+fa1:
+	if !(m && rc&16777215 == 100) {
+		goto fa2
+	}
+	a("\\b_970")
+fa2:
+	if !(m && int64(int32(rc&16777215)) > 100) {
+		goto fa3
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+fa3:
+f95:
+	if !(m && int64(int32(rc&16777215)) > 18) {
+		goto fa4
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FormatDescription("%ld", int64(int32(rc&16777215))))
+fa4:
+f7c:
+	return out
+}
+
+func identifyMachOCpu__Swapped(st *state, r *wizutil.SliceReader, po int64, fd wizardry.Fields) []string {
+	var out []string
+	var ss []string
+	ss = ss[0:]
+	var gf [32]int64
+	gf[0] = po
+	var ra uint64
+	ra &= ra
+	var rb uint64
+	rb &= rb
+	var rc uint64
+	rc &= rc
+	var rA int64
+	rA &= rA
+	var rB int64
+	rB &= rB
+	var sv string
+	sv += ""
+	var sb []byte
+	sb = sb[0:]
+	var sm [32]uint64
+	sm[0] &= sm[0]
+	var k bool
+	k = !!k
+	var l bool
+	l = !!l
+	var m bool
+	m = !!m
+	var d = make([]bool, 32)
+	d[0] = !!d[0]
+	var hw = gp(r, po, 8)
+
+	a := func(args ...string) {
+		out = append(out, args...)
+	}
+	rc, m = w4l(st, r, hw, po, po)
+	if !(m && rc&16777216 == 0) {
+		goto f1
+	}
+	if !(m && rc&16777215 == 1) {
+		goto f2
+	}
+	fd.Add("arch", "vax")
+	rc, m = w4l(st, r, hw, po, po+4)
+	if !(m && rc&16777215 == 0) {
+		goto f3
+	}
+	a("vax")
+f3:
+	if !(m && rc&16777215 == 1) {
+		goto f4
+	}
+	a("vax11/780")
+f4:
+	if !(m && rc&16777215 == 2) {
+		goto f5
+	}
+	a("vax11/785")
+f5:
+	if !(m && rc&16777215 == 3) {
+		goto f6
+	}
+	a("vax11/750")
+f6:
+	if !(m && rc&16777215 == 4) {
+		goto f7
+	}
+	a("vax11/730")
+f7:
+	if !(m && rc&16777215 == 5) {
+		goto f8
+	}
+	a("uvaxI")
+f8:
+	if !(m && rc&16777215 == 6) {
+		goto f9
+	}
+	a("uvaxII")
+f9:
+	if !(m && rc&16777215 == 7) {
+		goto fa
+	}
+	a("vax8200")
+fa:
+	if !(m && rc&16777215 == 8) {
+		goto fb
+	}
+	a("vax8500")
+fb:
+	if !(m && rc&16777215 == 9) {
+		goto fc
+	}
+	a("vax8600")
+fc:
+	if !(m && rc&16777215 == 10) {
+		goto fd
+	}
+	a("vax8650")
+fd:
+	if !(m && rc&16777215 == 11) {
+		goto fe
+	}
+	a("vax8800")
+fe:
+	if !(m && rc&16777215 == 12) {
+		goto ff
+	}
+	a("uvaxIII")
+ff:
+	if !(m && int64(int32(rc&16777215)) > 12) {
+		goto f10
+	}
+	a(wizardry.FormatDescription("vax subarchitecture=%ld", int64(int32(rc&16777215))))
+f10:
+f2:
+	if !(m && rc&16777215 == 2) {
+		goto f11
+	}
+	a("romp")
+	fd.Add("arch", "romp")
+f11:
+	if !(m && rc&16777215 == 3) {
+		goto f12
+	}
+	a("architecture=3")
+	fd.Add("arch", "architecture=3")
+f12:
+	if !(m && rc&16777215 == 4) {
+		goto f13
+	}
+	a("ns32032")
+	fd.Add("arch", "ns32032")
+f13:
+	if !(m && rc&16777215 == 5) {
+		goto f14
+	}
+	a("ns32332")
+	fd.Add("arch", "ns32332")
+f14:
+	if !(m && rc&16777215 == 6) {
+		goto f15
+	}
+	a("m68k")
+	fd.Add("arch", "m68k")
+f15:
+	if !(m && rc&16777215 == 7) {
+		goto f16
+	}
+	fd.Add("arch", "i386")
+	rc, m = w4l(st, r, hw, po, po+4)
+	if !(m && rc&15 == 3) {
+		goto f17
+	}
+	a("i386")
+f17:
+	if !(m && rc&15 == 4) {
+		goto f18
+	}
+	a("i486")
+	if !(m && rc&16777200 == 0) {
+		goto f19
+	}
+f19:
+	if !(m && rc&16777200 == 128) {
+		goto f1a
+	}
+	a("\\bsx")
+f1a:
+f18:
+	if !(m && rc&15 == 5) {
+		goto f1b
+	}
+	a("i586")
+f1b:
+	if !(m && rc&15 == 6) {
+		goto f1c
+	}
+	if !(m && rc&16777200 == 0) {
+		goto f1d
+	}
+	a("p6")
+f1d:
+	if !(m && rc&16777200 == 16) {
+		goto f1e
+	}
+	a("pentium_pro")
+f1e:
+	if !(m && rc&16777200 == 32) {
+		goto f1f
+	}
+	a("pentium_2_m0x20")
+f1f:
+	if !(m && rc&16777200 == 48) {
+		goto f20
+	}
+	a("pentium_2_m3")
+f20:
+	if !(m && rc&16777200 == 64) {
+		goto f21
+	}
+	a("pentium_2_m0x40")
+f21:
+	if !(m && rc&16777200 == 80) {
+		goto f22
+	}
+	a("pentium_2_m5")
+f22:
+	if !(m && int64(int32(rc&16777200)) > 80) {
+		goto f23
+	}
+	a(wizardry.FormatDescription("pentium_2_m0x%lx", int64(int32(rc&16777200))))
+f23:
+f1c:
+	if !(m && rc&15 == 7) {
+		goto f24
+	}
+	a("celeron")
+	if !(m && rc&16777200 == 0) {
+		goto f25
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f25:
+	if !(m && rc&16777200 == 16) {
+		goto f26
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f26:
+	if !(m && rc&16777200 == 32) {
+		goto f27
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f27:
+	if !(m && rc&16777200 == 48) {
+		goto f28
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f28:
+	if !(m && rc&16777200 == 64) {
+		goto f29
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f29:
+	if !(m && rc&16777200 == 80) {
+		goto f2a
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f2a:
+	if !(m && rc&16777200 == 96) {
+		goto f2b
+	}
+f2b:
+	if !(m && rc&16777200 == 112) {
+		goto f2c
+	}
+	a("\\b_mobile")
+f2c:
+	if !(m && int64(int32(rc&16777200)) > 112) {
+		goto f2d
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f2d:
+f24:
+	if !(m && rc&15 == 8) {
+		goto f2e
+	}
+	a("pentium_3")
+	if !(m && rc&16777200 == 0) {
+		goto f2f
+	}
+f2f:
+	if !(m && rc&16777200 == 16) {
+		goto f30
+	}
+	a("\\b_m")
+f30:
+	if !(m && rc&16777200 == 32) {
+		goto f31
+	}
+	a("\\b_xeon")
+f31:
+	if !(m && int64(int32(rc&16777200)) > 32) {
+		goto f32
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f32:
+f2e:
+	if !(m && rc&15 == 9) {
+		goto f33
+	}
+	a("pentiumM")
+	if !(m && rc&16777200 == 0) {
+		goto f34
+	}
+f34:
+	if !(m && int64(int32(rc&16777200)) > 0) {
+		goto f35
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f35:
+f33:
+	if !(m && rc&15 == 10) {
+		goto f36
+	}
+	a("pentium_4")
+	if !(m && rc&16777200 == 0) {
+		goto f37
+	}
+f37:
+	if !(m && rc&16777200 == 16) {
+		goto f38
+	}
+	a("\\b_m")
+f38:
+	if !(m && int64(int32(rc&16777200)) > 16) {
+		goto f39
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f39:
+f36:
+	if !(m && rc&15 == 11) {
+		goto f3a
+	}
+	a("itanium")
+	if !(m && rc&16777200 == 0) {
+		goto f3b
+	}
+f3b:
+	if !(m && rc&16777200 == 16) {
+		goto f3c
+	}
+	a("\\b_2")
+f3c:
+	if !(m && int64(int32(rc&16777200)) > 16) {
+		goto f3d
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f3d:
+f3a:
+	if !(m && rc&15 == 12) {
+		goto f3e
+	}
+	a("xeon")
+	if !(m && rc&16777200 == 0) {
+		goto f3f
+	}
+f3f:
+	if !(m && rc&16777200 == 16) {
+		goto f40
+	}
+	a("\\b_mp")
+f40:
+	if !(m && int64(int32(rc&16777200)) > 16) {
+		goto f41
+	}
+	a(wizardry.FormatDescription("\\b_m0x%lx", int64(int32(rc&16777200))))
+f41:
+f3e:
+	if !(m && int64(int32(rc&15)) > 12) {
+		goto f42
+	}
+	a(wizardry.FormatDescription("ia32 family=%ld", int64(int32(rc&15))))
+	if !(m && rc&16777200 == 0) {
+		goto f43
+	}
+f43:
+	if !(m && int64(int32(rc&16777200)) > 0) {
+		goto f44
+	}
+	a(wizardry.FormatDescription("model=%lx", int64(int32(rc&16777200))))
+f44:
+f42:
+f16:
+	if !(m && rc&16777215 == 8) {
+		goto f45
+	}
+	a("mips")
+	fd.Add("arch", "mips")
+	rc, m = w4l(st, r, hw, po, po+4)
+	if !(m && rc&16777215 == 1) {
+		goto f46
+	}
+	a("R2300")
+f46:
+	if !(m && rc&16777215 == 2) {
+		goto f47
+	}
+	a("R2600")
+f47:
+	if !(m && rc&16777215 == 3) {
+		goto f48
+	}
+	a("R2800")
+f48:
+	if !(m && rc&16777215 == 4) {
+		goto f49
+	}
+	a("R2000a")
+f49:
+	if !(m && rc&16777215 == 5) {
+		goto f4a
+	}
+	a("R2000")
+f4a:
+	if !(m && rc&16777215 == 6) {
+		goto f4b
+	}
+	a("R3000a")
+f4b:
+	if !(m && rc&16777215 == 7) {
+		goto f4c
+	}
+	a("R3000")
+f4c:
+	if !(m && int64(int32(rc&16777215)) > 7) {
+		goto f4d
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f4d:
+f45:
+	if !(m && rc&16777215 == 9) {
+		goto f4e
+	}
+	a("ns32532")
+	fd.Add("arch", "ns32532")
+f4e:
+	if !(m && rc&16777215 == 10) {
+		goto f4f
+	}
+	a("mc98000")
+	fd.Add("arch", "mc98000")
+f4f:
+	if !(m && rc&16777215 == 11) {
+		goto f50
+	}
+	a("hppa")
+	fd.Add("arch", "hppa")
+	rc, m = w4l(st, r, hw, po, po+4)
+	if !(m && rc&16777215 == 0) {
+		goto f51
+	}
+	a("7100")
+f51:
+	if !(m && rc&16777215 == 1) {
+		goto f52
+	}
+	a("7100LC")
+f52:
+	if !(m && int64(int32(rc&16777215)) > 1) {
+		goto f53
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f53:
+f50:
+	if !(m && rc&16777215 == 12) {
+		goto f54
+	}
+	a("arm")
+	fd.Add("arch", "arm")
+	rc, m = w4l(st, r, hw, po, po+4)
+	if !(m && rc&16777215 == 0) {
+		goto f55
+	}
+f55:
+	if !(m && rc&16777215 == 1) {
+		goto f56
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f56:
+	if !(m && rc&16777215 == 2) {
+		goto f57
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f57:
+	if !(m && rc&16777215 == 3) {
+		goto f58
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f58:
+	if !(m && rc&16777215 == 4) {
+		goto f59
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f59:
+	if !(m && rc&16777215 == 5) {
+		goto f5a
+	}
+	a("\\b_v4t")
+f5a:
+	if !(m && rc&16777215 == 6) {
+		goto f5b
+	}
+	a("\\b_v6")
+f5b:
+	if !(m && rc&16777215 == 7) {
+		goto f5c
+	}
+	a("\\b_v5tej")
+f5c:
+	if !(m && rc&16777215 == 8) {
+		goto f5d
+	}
+	a("\\b_xscale")
+f5d:
+	if !(m && rc&16777215 == 9) {
+		goto f5e
+	}
+	a("\\b_v7")
+f5e:
+	if !(m && rc&16777215 == 10) {
+		goto f5f
+	}
+	a("\\b_v7f")
+f5f:
+	if !(m && rc&16777215 == 11) {
+		goto f60
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f60:
+	if !(m && rc&16777215 == 12) {
+		goto f61
+	}
+	a("\\b_v7k")
+f61:
+	if !(m && int64(int32(rc&16777215)) > 12) {
+		goto f62
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f62:
+f54:
+	if !(m && rc&16777215 == 13) {
+		goto f63
+	}
+	fd.Add("arch", "mc88000")
+	rc, m = w4l(st, r, hw, po, po+4)
+	if !(m && rc&16777215 == 0) {
+		goto f64
+	}
+	a("mc88000")
+f64:
+	if !(m && rc&16777215 == 1) {
+		goto f65
+	}
+	a("mc88100")
+f65:
+	if !(m && rc&16777215 == 2) {
+		goto f66
+	}
+	a("mc88110")
+f66:
+	if !(m && int64(int32(rc&16777215)) > 2) {
+		goto f67
+	}
+	a(wizardry.FormatDescription("mc88000 subarchitecture=%ld", int64(int32(rc&16777215))))
+f67:
+f63:
+	if !(m && rc&16777215 == 14) {
+		goto f68
+	}
+	a("sparc")
+	fd.Add("arch", "sparc")
+f68:
+	if !(m && rc&16777215 == 15) {
+		goto f69
+	}
+	a("i860g")
+	fd.Add("arch", "i860g")
+f69:
+	if !(m && rc&16777215 == 16) {
+		goto f6a
+	}
+	a("alpha")
+	fd.Add("arch", "alpha")
+f6a:
+	if !(m && rc&16777215 == 17) {
+		goto f6b
+	}
+	a("rs6000")
+	fd.Add("arch", "rs6000")
+f6b:
+	if !(m && rc&16777215 == 18) {
+		goto f6c
+	}
+	a("ppc")
+	fd.Add("arch", "ppc")
+	rc, m = w4l(st, r, hw, po, po+4)
+	if !(m && rc&16777215 == 0) {
+		goto f6d
+	}
+f6d:
+	if !(m && rc&16777215 == 1) {
+		goto f6e
+	}
+	a("\\b_601")
+f6e:
+	if !(m && rc&16777215 == 2) {
+		goto f6f
+	}
+	a("\\b_602")
+f6f:
+	if !(m && rc&16777215 == 3) {
+		goto f70
+	}
+	a("\\b_603")
+f70:
+	if !(m && rc&16777215 == 4) {
+		goto f71
+	}
+	a("\\b_603e")
+f71:
+	if !(m && rc&16777215 == 5) {
+		goto f72
+	}
+	a("\\b_603ev")
+f72:
+	if !(m && rc&16777215 == 6) {
+		goto f73
+	}
+	a("\\b_604")
+f73:
+	if !(m && rc&16777215 == 7) {
+		goto f74
+	}
+	a("\\b_604e")
+f74:
+	if !(m && rc&16777215 == 8) {
+		goto f75
+	}
+	a("\\b_620")
+f75:
+	if !(m && rc&16777215 == 9) {
+		goto f76
+	}
+	a("\\b_650")
+f76:
+	if !(m && rc&16777215 == 10) {
+		goto f77
+	}
+	a("\\b_7400")
+f77:
+	if !(m && rc&16777215 == 11) {
+		goto f78
+	}
+	a("\\b_7450")
+f78:
+	if !(m && rc&16777215 == 100) {
+		goto f79
+	}
+	a("\\b_970")
+f79:
+	if !(m && int64(int32(rc&16777215)) > 100) {
+		goto f7a
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f7a:
+f6c:
+	if !(m && int64(int32(rc&16777215)) > 18) {
+		goto f7b
+	}
+	a(wizardry.FormatDescription("architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FormatDescription("%ld", int64(int32(rc&16777215))))
+f7b:
+f1:
+	if !(m && rc&16777216 == 16777216) {
+		goto f7c
+	}
+	if !(m && rc&16777215 == 0) {
+		goto f7d
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f7d:
+	if !(m && rc&16777215 == 1) {
+		goto f7e
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f7e:
+	if !(m && rc&16777215 == 2) {
+		goto f7f
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f7f:
+	if !(m && rc&16777215 == 3) {
+		goto f80
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f80:
+	if !(m && rc&16777215 == 4) {
+		goto f81
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f81:
+	if !(m && rc&16777215 == 5) {
+		goto f82
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f82:
+	if !(m && rc&16777215 == 6) {
+		goto f83
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f83:
+	if !(m && rc&16777215 == 7) {
+		goto f84
+	}
+	a("x86_64")
+	fd.Add("arch", "x86_64")
+	rc, m = w4l(st, r, hw, po, po+4)
+	if !(m && rc&16777215 == 0) {
+		goto f85
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f85:
+	if !(m && rc&16777215 == 1) {
+		goto f86
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f86:
+	if !(m && rc&16777215 == 2) {
+		goto f87
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f87:
+	if !(m && rc&16777215 == 3) {
+		goto f88
+	}
+f88:
+	if !(m && rc&16777215 == 4) {
+		goto f89
+	}
+	a("\\b_arch1")
+f89:
+	if !(m && int64(int32(rc&16777215)) > 4) {
+		goto f8a
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+f8a:
+f84:
+	if !(m && rc&16777215 == 8) {
+		goto f8b
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f8b:
+	if !(m && rc&16777215 == 9) {
+		goto f8c
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f8c:
+	if !(m && rc&16777215 == 10) {
+		goto f8d
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f8d:
+	if !(m && rc&16777215 == 11) {
+		goto f8e
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f8e:
+	if !(m && rc&16777215 == 12) {
+		goto f8f
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", "arm64")
+f8f:
+	if !(m && rc&16777215 == 13) {
+		goto f90
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f90:
+	if !(m && rc&16777215 == 14) {
+		goto f91
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f91:
+	if !(m && rc&16777215 == 15) {
+		goto f92
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f92:
+	if !(m && rc&16777215 == 16) {
+		goto f93
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f93:
+	if !(m && rc&16777215 == 17) {
+		goto f94
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FieldValue(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215)))))
+f94:
+	if !(m && rc&16777215 == 18) {
+		goto f95
+	}
+	a("ppc64")
+	fd.Add("arch", "ppc64")
+	rc, m = w4l(st, r, hw, po, po+4)
+	if !(m && rc&16777215 == 0) {
+		goto f96
+	}
+f96:
+	if !(m && rc&16777215 == 1) {
+		goto f97
+	}
+	a("\\b_601")
+f97:
+	if !(m && rc&16777215 == 2) {
+		goto f98
+	}
+	a("\\b_602")
+f98:
+	if !(m && rc&16777215 == 3) {
+		goto f99
+	}
+	a("\\b_603")
+f99:
+	if !(m && rc&16777215 == 4) {
+		goto f9a
+	}
+	a("\\b_603e")
+f9a:
+	if !(m && rc&16777215 == 5) {
+		goto f9b
+	}
+	a("\\b_603ev")
+f9b:
+	if !(m && rc&16777215 == 6) {
+		goto f9c
+	}
+	a("\\b_604")
+f9c:
+	if !(m && rc&16777215 == 7) {
+		goto f9d
+	}
+	a("\\b_604e")
+f9d:
+	if !(m && rc&16777215 == 8) {
+		goto f9e
+	}
+	a("\\b_620")
+f9e:
+	if !(m && rc&16777215 == 9) {
+		goto f9f
+	}
+	a("\\b_650")
+f9f:
+	if !(m && rc&16777215 == 10) {
+		goto fa0
+	}
+	a("\\b_7400")
+fa0:
+	if !(m && rc&16777215 == 11) {
+		goto fa1
+	}
+	a("\\b_7450")
+fa1:
+	if !(m && rc&16777215 == 100) {
+		goto fa2
+	}
+	a("\\b_970")
+fa2:
+	if !(m && int64(int32(rc&16777215)) > 100) {
+		goto fa3
+	}
+	a(wizardry.FormatDescription("subarchitecture=%ld", int64(int32(rc&16777215))))
+fa3:
+f95:
+	if !(m && int64(int32(rc&16777215)) > 18) {
+		goto fa4
+	}
+	a(wizardry.FormatDescription("64-bit architecture=%ld", int64(int32(rc&16777215))))
+	fd.Add("arch", wizardry.FormatDescription("%ld", int64(int32(rc&16777215))))
+fa4:
+f7c:
+	return out
+}
+
+func identifyMsdosCom(st *state, r *wizutil.SliceReader, po int64, fd wizardry.Fields) []string {
+	var out []string
+	var ss []string
+	ss = ss[0:]
+	var gf [32]int64
+	gf[0] = po
+	var ra uint64
+	ra &= ra
+	var rb uint64
+	rb &= rb
+	var rc uint64
+	rc &= rc
+	var rA int64
+	rA &= rA
+	var rB int64
+	rB &= rB
+	var sv string
+	sv += ""
+	var sb []byte
+	sb = sb[0:]
+	var sm [32]uint64
+	sm[0] &= sm[0]
+	var k bool
+	k = !!k
+	var l bool
+	l = !!l
+	var m bool
+	m = !!m
+	var d = make([]bool, 32)
+	d[0] = !!d[0]
+
+	a := func(args ...string) {
+		out = append(out, args...)
+	}
+	a("DOS executable (COM)")
+	sv = gs(r, po+6, 96)
+	rA = gt(r, po+6, "SFX of LHarc", 0, 0)
+	if rA < 0 {
+		goto f2
+	}
+	a(wizardry.FormatDescription("\\b, %s", wizardry.StringTestFlags(0).Printable(sv)))
+f2:
+	rc, m = f2l(st, r, po+510)
+	if !(m && rc == 43605) {
+		goto f3
+	}
+	a("\\b, boot code")
+f3:
+	rA = gt(r, po+85, "UPX", 0, 0)
+	if rA < 0 {
+		goto f4
+	}
+	a("\\b, UPX compressed")
+f4:
+	sb = gp(r, po+4, 7)
+	sm[1] = 0
+	if len(sb) >= 2 && string(sb[0:2]) == " $" {
+		if len(sb) > 2 {
+			switch sb[2] {
+			case 65:
+				if len(sb) >= 5 && string(sb[3:5]) == "RX" {
+					sm[1] |= 0x1
+				}
+			case 76:
+				if len(sb) >= 7 && string(sb[3:7]) == "Harc" {
+					sm[1] |= 0x2
+				}
+			}
+		}
+	}
+	if sm[1]&0x1 == 0 {
+		goto f5
+	}
+	rA = gt(r, po+4, " $ARX", 0, 0)
+	if rA < 0 {
+		goto f5
+	}
+	a("\\b, ARX self-extracting archive")
+f5:
+	if sm[1]&0x2 == 0 {
+		goto f6
+	}
+	rA = gt(r, po+4, " $LHarc", 0, 0)
+	if rA < 0 {
+		goto f6
+	}
+	a("\\b, LHarc self-extracting archive")
+f6:
+	rA = gt(r, po+526, "SFX by LARC", 0, 0)
+	if rA < 0 {
+		goto f7
+	}
+	a("\\b, LARC self-extracting archive")
+f7:
+	return out
+}
+
+func identifyMsdosDriver(st *state, r *wizutil.SliceReader, po int64, fd wizardry.Fields) []string {
+	var out []string
+	var ss []string
+	ss = ss[0:]
+	var gf [32]int64
+	gf[0] = po
+	var ra uint64
+	ra &= ra
+	var rb uint64
+	rb &= rb
+	var rc uint64
+	rc &= rc
+	var rA int64
+	rA &= rA
+	var rB int64
+	rB &= rB
+	var sv string
+	sv += ""
+	var sb []byte
+	sb = sb[0:]
+	var sm [32]uint64
+	sm[0] &= sm[0]
+	var k bool
+	k = !!k
+	var l bool
+	l = !!l
+	var m bool
+	m = !!m
+	var d = make([]bool, 32)
+	d[0] = !!d[0]
+	var hw = gp(r, po, 18)
+
+	a := func(args ...string) {
+		out = append(out, args...)
+	}
+	a("DOS executable (")
+	rA, rB = ht(r, po+40, 7, "UPX!", 0)
+	if rA < 0 {
+		goto f1
+	}
+	a("\\bUPX compressed")
+f1:
+	rc, m = w2l(st, r, hw, po, po+4)
+	if !(m && rc&32768 == 0) {
+		goto f2
+	}
+	a("\\bblock device driver")
+f2:
+	if !(m && rc&32768 == 32768) {
+		goto f3
+	}
+	a("\\b")
+	if !(m && rc&8 == 8) {
+		goto f4
+	}
+	a("\\bclock")
+f4:
+	if !(m && rc&16 == 16) {
+		goto f5
+	}
+	a("\\bfast")
+f5:
+	if !(m && rc&3 > 0) {
+		goto f6
+	}
+	a("\\bstandard")
+	if !(m && rc&1 == 1) {
+		goto f7
+	}
+	a("\\binput")
+f7:
+	if !(m && rc&3 == 3) {
+		goto f8
+	}
+	a("\\b/")
+f8:
+	if !(m && rc&2 == 2) {
+		goto f9
+	}
+	a("\\boutput")
+f9:
+f6:
+	if !(m && rc&32768 == 32768) {
+		goto fa
+	}
+	a("\\bcharacter device driver")
+fa:
+f3:
+	d[1] = f
+	rA, rB = ht(r, po+40, 7, "UPX!", 0)
+	if rA < 0 {
+		goto fc
+	}
+	d[1] = t
+fc:
+	if d[1] {
+		goto fd
+	}
+	rc, m = w1l(st, r, hw, po, po+12)
+	if !(m && rc > 46) {
+		goto fe
+	}
+	a("\\b")
+	rc, m = w1l(st, r, hw, po, po+10)
+	if !(m && rc > 32) {
+		goto ff
+	}
+	if !(m && rc != 46) {
+		goto f10
+	}
+	if !(m && rc != 42) {
+		goto f11
+	}
+	a(wizardry.FormatDescription("\\b%c", rc))
+f11:
+f10:
+ff:
+	rc, m = w1l(st, r, hw, po, po+11)
+	if !(m && rc > 32) {
+		goto f12
+	}
+	if !(m && rc != 46) {
+		goto f13
+	}
+	a(wizardry.FormatDescription("\\b%c", rc))
+f13:
+f12:
+	rc, m = w1l(st, r, hw, po, po+12)
+	if !(m && rc > 32) {
+		goto f14
+	}
+	if !(m && rc != 57) {
+		goto f15
+	}
+	if !(m && rc != 46) {
+		goto f16
+	}
+	a(wizardry.FormatDescription("\\b%c", rc))
+f16:
+f15:
+f14:
+fe:
+	rc, m = w1l(st, r, hw, po, po+13)
+	if !(m && rc > 32) {
+		goto f17
+	}
+	if !(m && rc != 46) {
+		goto f18
+	}
+	a(wizardry.FormatDescription("\\b%c", rc))
+f18:
+	rc, m = w1l(st, r, hw, po, po+14)
+	if !(m && rc > 32) {
+		goto f19
+	}
+	if !(m && rc != 46) {
+		goto f1a
+	}
+	a(wizardry.FormatDescription("\\b%c", rc))
+f1a:
+f19:
+	rc, m = w1l(st, r, hw, po, po+15)
+	if !(m && rc > 32) {
+		goto f1b
+	}
+	if !(m && rc != 46) {
+		goto f1c
+	}
+	a(wizardry.FormatDescription("\\b%c", rc))
+f1c:
+f1b:
+	rc, m = w1l(st, r, hw, po, po+16)
+	if !(m && rc > 32) {
+		goto f1d
+	}
+	if !(m && rc != 46) {
+		goto f1e
+	}
+	if !(m && rc < 203) {
+		goto f1f
+	}
+	a(wizardry.FormatDescription("\\b%c", rc))
+f1f:
+f1e:
+f1d:
+	rc, m = w1l(st, r, hw, po, po+17)
+	if !(m && rc > 32) {
+		goto f20
+	}
+	if !(m && rc != 46) {
+		goto f21
+	}
+	if !(m && rc < 144) {
+		goto f22
+	}
+	a(wizardry.FormatDescription("\\b%c", rc))
+f22:
+f21:
+f20:
+f17:
+	rc, m = w1l(st, r, hw, po, po+12)
+	if !(m && rc < 47) {
+		goto f23
+	}
+	sv = gs(r, po+22, 96)
+	rA = gt(r, po+22, ".", 0, 2)
+	if rA < 0 {
+		goto f24
+	}
+	a(wizardry.FormatDescription("%-.6s", wizardry.StringTestFlags(0).Printable(sv)))
+f24:
+f23:
+	d[1] = t
+fd:
+	rc, m = w2l(st, r, hw, po, po+4)
+	if !(m && rc&32768 == 0) {
+		goto f25
+	}
+	if !(m && rc&2 == 2) {
+		goto f26
+	}
+	a("\\b,32-bit sector-")
+f26:
+f25:
+	if !(m && rc&64 == 64) {
+		goto f27
+	}
+	a("\\b,IOCTL-")
+f27:
+	if !(m && rc&2048 == 2048) {
+		goto f28
+	}
+	a("\\b,close media-")
+f28:
+	if !(m && rc&32768 == 32768) {
+		goto f29
+	}
+	if !(m && rc&8192 == 8192) {
+		goto f2a
+	}
+	a("\\b,until busy-")
+f2a:
+f29:
+	if !(m && rc&16384 == 16384) {
+		goto f2b
+	}
+	a("\\b,control strings-")
+f2b:
+	if !(m && rc&32768 == 32768) {
+		goto f2c
+	}
+	if !(m && rc&26688 > 0) {
+		goto f2d
+	}
+	a("\\bsupport")
+f2d:
+f2c:
+	if !(m && rc&32768 == 0) {
+		goto f2e
+	}
+	if !(m && rc&18498 > 0) {
+		goto f2f
+	}
+	a("\\bsupport")
+f2f:
+f2e:
+	a("\\b)")
+	return out
+}
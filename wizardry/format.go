@@ -0,0 +1,97 @@
+package wizardry
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FormatDescription expands the C printf-style verbs found in a magic rule's
+// description (like "version %d" or "(%s)") with the value the rule read.
+// Length modifiers such as "l" or "ll" are accepted and ignored.
+func FormatDescription(format string, value interface{}) string {
+	if !strings.ContainsRune(format, '%') {
+		return format
+	}
+
+	var out bytes.Buffer
+	input := []byte(format)
+	inputSize := len(input)
+
+	for j := 0; j < inputSize; j++ {
+		if input[j] != '%' {
+			out.WriteByte(input[j])
+			continue
+		}
+
+		if j+1 < inputSize && input[j+1] == '%' {
+			out.WriteByte('%')
+			j++
+			continue
+		}
+
+		// flags, width and precision are passed to fmt as-is
+		verbStart := j
+		j++
+		for j < inputSize && strings.IndexByte("-+ #0123456789.", input[j]) >= 0 {
+			j++
+		}
+		spec := string(input[verbStart:j])
+
+		// length modifiers don't matter to us
+		for j < inputSize && strings.IndexByte("hlLqjzt", input[j]) >= 0 {
+			j++
+		}
+
+		if j >= inputSize {
+			out.WriteString(spec)
+			break
+		}
+
+		out.WriteString(formatVerb(spec, input[j], value))
+	}
+
+	return out.String()
+}
+
+func formatVerb(spec string, verb byte, value interface{}) string {
+	switch verb {
+	case 'd', 'i', 'u':
+		verb = 'd'
+	case 'x', 'X', 'o', 'c', 'e', 'f', 'g', 's':
+		// same meaning in go
+	default:
+		return spec + string(verb)
+	}
+
+	switch v := value.(type) {
+	case string:
+		verb = 's'
+	case []byte:
+		value = string(v)
+		verb = 's'
+	default:
+		switch verb {
+		case 's':
+			verb = 'd'
+		case 'e', 'f', 'g':
+			value = floatValue(value)
+		}
+	}
+
+	return fmt.Sprintf(spec+string(verb), value)
+}
+
+// floatValue converts integers to float64, so that floating-point verbs
+// print them as numbers rather than as a formatting error
+func floatValue(value interface{}) interface{} {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	}
+	return value
+}
@@ -0,0 +1,38 @@
+package wizardry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FormatDescription(t *testing.T) {
+	tests := []struct {
+		format   string
+		value    interface{}
+		expected string
+	}{
+		{"no verbs", int64(1), "no verbs"},
+		{"version %d", int64(3), "version 3"},
+		{"version %ld.%u", int64(3), "version 3.3"},
+		{"%i%%", uint64(50), "50%"},
+		{"at 0x%08lx", uint64(0xbeef), "at 0x0000beef"},
+		{"%X", int64(255), "FF"},
+		{"%o", int64(8), "10"},
+		{"drive %c:", int64('C'), "drive C:"},
+		{"(%s)", "name", "(name)"},
+		{"(%-6s)", []byte("name"), "(name  )"},
+		{"%d", "name", "name"},
+		{"count %s", int64(7), "count 7"},
+		{"%.1f", float64(1.25), "1.2"},
+		{"%.2f", int64(3), "3.00"},
+		{"%e", uint64(1500), "1.500000e+03"},
+		{"%g", int32(-2), "-2"},
+		{"unknown %y", int64(1), "unknown %y"},
+		{"dangling %", int64(1), "dangling %"},
+	}
+
+	for _, test := range tests {
+		assert.EqualValues(t, test.expected, FormatDescription(test.format, test.value), "for %q", test.format)
+	}
+}
@@ -0,0 +1,159 @@
+package wizardry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/itchio/wizardry/wizardry/wizutil"
+)
+
+// Limits bounds the resources a single identification may use, for targets
+// that can't be trusted. Zero values mean no limit, except for MaxUseDepth,
+// which defaults to the MaxUseDepth constant.
+type Limits struct {
+	// MaxBytesRead caps how many bytes may be read from the target,
+	// counting bytes read more than once. The sample read to classify the
+	// target as text doesn't count: it's bounded by TextMaxLen instead.
+	MaxBytesRead int64
+	// MaxUseDepth caps how deeply "use" rules may be nested
+	MaxUseDepth int
+	// Timeout caps how long identification may take
+	Timeout time.Duration
+}
+
+// LimitError is returned when identification is stopped because it
+// exceeded one of its Limits
+type LimitError struct {
+	// Limit is the name of the field of Limits that was exceeded
+	Limit string
+	// Value is the value of that limit
+	Value interface{}
+}
+
+func (le *LimitError) Error() string {
+	return fmt.Sprintf("wizardry: identification exceeded %s (%v)", le.Limit, le.Value)
+}
+
+// Guard enforces Limits and cancellation during an identification. It
+// reads from the target on behalf of the rules, and keeps the first error
+// it encountered: once it has failed, all reads fail, so rules stop matching
+// quickly, and the identification returns Err instead of its result.
+// All of its methods but ReadAt accept a nil Guard, which enforces nothing:
+// it has no target to read from.
+type Guard struct {
+	ctx      context.Context
+	reader   io.ReaderAt
+	limits   Limits
+	deadline time.Time
+
+	bytesRead int64
+	useDepth  int
+	err       error
+	// uncounted is set while reads don't count against MaxBytesRead
+	uncounted bool
+}
+
+var _ io.ReaderAt = (*Guard)(nil)
+
+// NewGuard returns a guard for reading from r, until ctx is done or
+// limits are exceeded
+func NewGuard(ctx context.Context, r io.ReaderAt, limits Limits) *Guard {
+	g := &Guard{
+		ctx:    ctx,
+		reader: r,
+		limits: limits,
+	}
+	if g.limits.MaxUseDepth <= 0 {
+		g.limits.MaxUseDepth = MaxUseDepth
+	}
+	if limits.Timeout > 0 {
+		g.deadline = time.Now().Add(limits.Timeout)
+	}
+	return g
+}
+
+// ReadAt reads from the target, unless the guard has failed, or
+// fails it if that read would exceed the limits. The guard must not be nil.
+func (g *Guard) ReadAt(p []byte, off int64) (int, error) {
+	if g.err != nil {
+		return 0, g.err
+	}
+
+	if g.limits.MaxBytesRead > 0 && !g.uncounted && g.bytesRead+int64(len(p)) > g.limits.MaxBytesRead {
+		return 0, g.fail(&LimitError{Limit: "MaxBytesRead", Value: g.limits.MaxBytesRead})
+	}
+	if !g.deadline.IsZero() && time.Now().After(g.deadline) {
+		return 0, g.fail(&LimitError{Limit: "Timeout", Value: g.limits.Timeout})
+	}
+	if err := g.ctx.Err(); err != nil {
+		return 0, g.fail(err)
+	}
+
+	n, err := g.reader.ReadAt(p, off)
+	if !g.uncounted {
+		g.bytesRead += int64(n)
+	}
+	return n, err
+}
+
+// ClassifyText is like the ClassifyText function, for a target read through
+// the guard, except its sample doesn't count against MaxBytesRead, so that
+// text larger than the limit can still be classified.
+func (g *Guard) ClassifyText(sr *wizutil.SliceReader) *TextInfo {
+	if g != nil {
+		g.uncounted = true
+		defer func() { g.uncounted = false }()
+	}
+	return ClassifyText(sr)
+}
+
+// EnterUse is called before evaluating the page of a "use" rule. It returns
+// false, and fails the guard, if that would nest too deeply.
+func (g *Guard) EnterUse() bool {
+	if g == nil {
+		return true
+	}
+	if g.err != nil {
+		return false
+	}
+	if g.useDepth >= g.limits.MaxUseDepth {
+		g.fail(&LimitError{Limit: "MaxUseDepth", Value: g.limits.MaxUseDepth})
+		return false
+	}
+	g.useDepth++
+	return true
+}
+
+// ExitUse is called after evaluating the page of a "use" rule
+func (g *Guard) ExitUse() {
+	if g == nil {
+		return
+	}
+	g.useDepth--
+}
+
+// Err returns why the guard failed, or nil if it didn't. It's either a
+// *LimitError, or the error of the guard's context.
+func (g *Guard) Err() error {
+	if g == nil {
+		return nil
+	}
+	return g.err
+}
+
+// BytesRead returns how many bytes were read from the target so far
+func (g *Guard) BytesRead() int64 {
+	if g == nil {
+		return 0
+	}
+	return g.bytesRead
+}
+
+func (g *Guard) fail(err error) error {
+	if g.err == nil {
+		g.err = err
+	}
+	return g.err
+}
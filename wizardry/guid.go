@@ -0,0 +1,64 @@
+package wizardry
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/itchio/wizardry/wizardry/wizutil"
+)
+
+// GUIDSize is the number of bytes a GUID spans in the target
+const GUIDSize = 16
+
+// ParseGUID turns the textual form of a GUID, like
+// "00020906-0000-0000-C000-000000000046", into its 16-byte binary form:
+// the first three groups are stored little-endian, the rest as-is.
+func ParseGUID(s string) ([]byte, error) {
+	groups := strings.Split(s, "-")
+	if len(groups) != 5 {
+		return nil, fmt.Errorf("invalid GUID %s: expected 5 groups, got %d", s, len(groups))
+	}
+
+	groupLens := []int{8, 4, 4, 4, 12}
+	var raw []byte
+	for i, group := range groups {
+		if len(group) != groupLens[i] {
+			return nil, fmt.Errorf("invalid GUID %s: group %d should have %d digits", s, i+1, groupLens[i])
+		}
+		decoded, err := hex.DecodeString(group)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GUID %s: %s", s, err.Error())
+		}
+		raw = append(raw, decoded...)
+	}
+
+	guid := make([]byte, GUIDSize)
+	binary.LittleEndian.PutUint32(guid[0:], binary.BigEndian.Uint32(raw[0:]))
+	binary.LittleEndian.PutUint16(guid[4:], binary.BigEndian.Uint16(raw[4:]))
+	binary.LittleEndian.PutUint16(guid[6:], binary.BigEndian.Uint16(raw[6:]))
+	copy(guid[8:], raw[8:])
+	return guid, nil
+}
+
+// FormatGUID returns the textual form of a 16-byte binary GUID
+func FormatGUID(guid []byte) string {
+	return fmt.Sprintf("%08X-%04X-%04X-%04X-%012X",
+		binary.LittleEndian.Uint32(guid[0:]),
+		binary.LittleEndian.Uint16(guid[4:]),
+		binary.LittleEndian.Uint16(guid[6:]),
+		guid[8:10],
+		guid[10:16])
+}
+
+// ReadGUID returns the textual form of the GUID at given index, and
+// false if the target is too short to contain one
+func ReadGUID(sr *wizutil.SliceReader, targetIndex int64) (string, bool) {
+	buf := make([]byte, GUIDSize)
+	n, _ := sr.ReadAt(buf, targetIndex)
+	if n < GUIDSize || targetIndex < 0 {
+		return "", false
+	}
+	return FormatGUID(buf), true
+}
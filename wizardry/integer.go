@@ -0,0 +1,16 @@
+package wizardry
+
+// DecodeID3 turns a synchsafe integer, as found in ID3 tags, into its
+// actual value: only the low 7 bits of each byte are significant.
+func DecodeID3(v uint64) uint64 {
+	return (v & 0x7f) |
+		((v & 0x7f00) >> 1) |
+		((v & 0x7f0000) >> 2) |
+		((v & 0x7f000000) >> 3)
+}
+
+// MiddleEndianUint32 decodes a PDP-11 style 32-bit integer: two
+// little-endian 16-bit halves, most significant half first.
+func MiddleEndianUint32(b []byte) uint32 {
+	return uint32(b[1])<<24 | uint32(b[0])<<16 | uint32(b[3])<<8 | uint32(b[2])
+}
@@ -0,0 +1,12 @@
+package wizardry
+
+// MaxIndirections is how many indirect rules can be nested before giving up,
+// so that targets can't make identification recurse forever
+const MaxIndirections = 15
+
+// MaxLevels is the deepest level of nesting a rule may have
+const MaxLevels = 32
+
+// MaxUseDepth is how deeply "use" rules can be nested when Limits don't
+// say otherwise, so that a page using itself can't recurse forever
+const MaxUseDepth = 64
@@ -0,0 +1,138 @@
+package wizardry
+
+import (
+	"sort"
+	"strings"
+)
+
+// Result is what identifying a target yields
+type Result struct {
+	// Strings are the descriptions of the rules that matched, in order,
+	// see wizutil.MergeStrings to turn them into a single line
+	Strings []string
+	// Fields holds the values captured by rules annotated with "!:field"
+	Fields Fields
+	// Candidates lists every top-level rule that printed something, best
+	// first. It's only filled when ranking is requested, see
+	// wizinterpreter.InterpretContext.RankCandidates
+	Candidates []Candidate
+	// Truncations lists the rules that couldn't be evaluated because the
+	// target ended too early. Only the interpreter records them.
+	Truncations []Truncation
+	// NeedMoreBytes is how many bytes past the end of the target the
+	// truncated rules needed, 0 if there were none
+	NeedMoreBytes int64
+}
+
+// Truncated returns true if some rules couldn't be evaluated because the
+// target ended too early. Callers that only have the start of a file can
+// fetch at least NeedMoreBytes more bytes, and identify it again.
+func (r Result) Truncated() bool {
+	return len(r.Truncations) > 0
+}
+
+// Truncation describes a rule that couldn't be evaluated because the target
+// ended too early - as opposed to a rule that didn't match
+type Truncation struct {
+	// Rule is the line the rule was parsed from
+	Rule string
+	// Missing is how many bytes past the end of the target the rule needed
+	Missing int64
+}
+
+// Candidate is one of the ways a target could be identified: the output of
+// a single top-level rule, and how confident we are in it.
+type Candidate struct {
+	Strings []string
+	Fields  Fields
+	// Strength is how specific the top-level rule is, see wizparser.Rule.Strength
+	Strength int
+	// Levels is how deep the matching went, 1 if only the top-level rule matched
+	Levels int
+	// Bytes is how many bytes of the target were compared against a
+	// pattern or value, rather than merely read
+	Bytes int64
+	// Score ranks candidates, the higher the better, see CandidateScore
+	Score int
+}
+
+// CandidateScore combines a candidate's strength, levels and bytes into a
+// score. Every level below the top-level rule, and every byte verified,
+// makes a candidate more trustworthy.
+func CandidateScore(strength int, levels int, bytes int64) int {
+	score := strength + int(bytes)
+	if levels > 1 {
+		score += (levels - 1) * 10
+	}
+	return score
+}
+
+// SortCandidates orders candidates by decreasing score. Candidates with
+// the same score keep their order.
+func SortCandidates(candidates []Candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+}
+
+const (
+	// MIMEField holds the MIME types of the rules that matched, from their
+	// "!:mime" annotations - see Result.MIME
+	MIMEField = "mime"
+	// ExtensionField holds the file extensions of the rules that matched,
+	// from their "!:ext" annotations - see Result.Extensions
+	ExtensionField = "ext"
+)
+
+// MIME returns the MIME type of the target, as given by the first rule
+// that matched and had one, or "" if none did
+func (r Result) MIME() string {
+	return r.Fields.Get(MIMEField)
+}
+
+// Extensions returns the extensions files like the target usually have
+func (r Result) Extensions() []string {
+	return r.Fields[ExtensionField]
+}
+
+// Fields maps field names to the values captured for them, in the order
+// they were captured. Universal binaries, for example, have several "arch"
+// values, one per slice.
+type Fields map[string][]string
+
+// Add records a value for a field. Empty values, and values already
+// captured for that field, are ignored.
+func (f Fields) Add(name string, value string) {
+	if value == "" {
+		return
+	}
+	for _, v := range f[name] {
+		if v == value {
+			return
+		}
+	}
+	f[name] = append(f[name], value)
+}
+
+// Get returns the first value captured for a field, or "" if there are none
+func (f Fields) Get(name string) string {
+	values := f[name]
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// FieldValue turns a rule's description into a field value, by removing
+// backspaces, surrounding whitespace, trailing commas and enclosing
+// parentheses: "\b, (GNU/Linux)," becomes "GNU/Linux".
+func FieldValue(description string) string {
+	s := strings.Replace(description, "\\b", "", -1)
+	s = strings.TrimSpace(s)
+	s = strings.TrimLeft(s, ", ")
+	s = strings.TrimRight(s, ", ")
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") && strings.Count(s, "(") == 1 {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	return s
+}
@@ -2,10 +2,55 @@ package wizardry
 
 import "github.com/itchio/wizardry/wizardry/wizutil"
 
-// SearchTest looks for a fixed pattern at any position within a certain length
+// SearchTest looks for a fixed pattern at any position within a certain length.
+// It returns the position of the match, relative to targetIndex, or -1 if the
+// pattern wasn't found.
 func SearchTest(sr *wizutil.SliceReader, targetIndex int64, maxLen int64, pattern string) int64 {
-	sf := MakeStringFinder(pattern)
+	pos, _ := SearchTestWith(sr, targetIndex, maxLen, pattern, 0)
+	return pos
+}
 
+// SearchTestWith is like SearchTest, with flags. It returns the position of
+// the match, relative to targetIndex, and the number of target bytes the match
+// spans - or -1 if the pattern wasn't found.
+func SearchTestWith(sr *wizutil.SliceReader, targetIndex int64, maxLen int64, pattern string, flags StringTestFlags) (int64, int64) {
 	sr = sr.Slice(targetIndex).Cap(maxLen)
-	return sf.next(sr)
+
+	if flags&(CompactWhitespace|OptionalBlanks) > 0 {
+		// matches don't have a fixed length, try every position
+		for pos := int64(0); pos < sr.Size(); pos++ {
+			if matchLen := StringTestWith(sr, pos, pattern, flags, StringEqual); matchLen >= 0 {
+				return pos, matchLen
+			}
+		}
+		return -1, 0
+	}
+
+	sf := MakeStringFinderWith(pattern, flags)
+	matchLen := int64(len(pattern))
+
+	for base := int64(0); base < sr.Size(); {
+		pos := sf.next(sr.Slice(base))
+		if pos < 0 {
+			return -1, 0
+		}
+		pos += base
+
+		if flags&FullWord == 0 || !followedByWord(sr, pos+matchLen) {
+			return pos, matchLen
+		}
+		base = pos + 1
+	}
+	return -1, 0
+}
+
+// followedByWord returns true if there's a word character at given index
+func followedByWord(sr *wizutil.SliceReader, index int64) bool {
+	if index >= sr.Size() {
+		return false
+	}
+
+	buf := make([]byte, 1)
+	n, _ := sr.ReadAt(buf, index)
+	return n == 1 && wizutil.IsWordCharacter(buf[0])
 }
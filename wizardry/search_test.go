@@ -0,0 +1,82 @@
+package wizardry
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/itchio/wizardry/wizardry/wizutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func searchIn(target string, pattern string, flags StringTestFlags) (int64, int64) {
+	sr := wizutil.NewSliceReader(bytes.NewReader([]byte(target)), 0, int64(len(target)))
+	return SearchTestWith(sr, 0, int64(len(target)), pattern, flags)
+}
+
+func Test_SearchTest(t *testing.T) {
+	pos, matchLen := searchIn("the Mississippi", "ssi", 0)
+	assert.EqualValues(t, 6, pos)
+	assert.EqualValues(t, 3, matchLen)
+
+	// the signatures that predate flags still work
+	sr := wizutil.NewSliceReader(bytes.NewReader([]byte("the Mississippi")), 0, 15)
+	assert.EqualValues(t, 6, SearchTest(sr, 0, 15, "ssi"))
+	assert.EqualValues(t, 3, StringTest(sr, 6, "ssi", 0))
+	assert.EqualValues(t, 6, MakeStringFinder("ssi").next(sr))
+
+	pos, _ = searchIn("the MISSISSIPPI", "ssi", 0)
+	assert.EqualValues(t, -1, pos)
+	pos, _ = searchIn("the MISSISSIPPI", "ssi", LowerMatchesBoth)
+	assert.EqualValues(t, 6, pos)
+	pos, _ = searchIn("the mississippi", "SSI", UpperMatchesBoth)
+	assert.EqualValues(t, 6, pos)
+	// only letters of the given case match both
+	pos, _ = searchIn("the mississippi", "SSI", LowerMatchesBoth)
+	assert.EqualValues(t, -1, pos)
+
+	// whitespace flags match a varying number of bytes
+	pos, matchLen = searchIn("x = a  b", "a b", CompactWhitespace)
+	assert.EqualValues(t, 4, pos)
+	assert.EqualValues(t, 4, matchLen)
+
+	// full words skip matches followed by a word character
+	pos, _ = searchIn("words word", "word", FullWord)
+	assert.EqualValues(t, 6, pos)
+	pos, _ = searchIn("words", "word", FullWord)
+	assert.EqualValues(t, -1, pos)
+}
+
+func Test_SearchTestCaseFolding(t *testing.T) {
+	// the Boyer-Moore finder must find the same matches as trying every
+	// position, when its skip tables account for both cases
+	rng := rand.New(rand.NewSource(0x5eed))
+	randomString := func(alphabet string, n int) string {
+		buf := make([]byte, n)
+		for i := range buf {
+			buf[i] = alphabet[rng.Intn(len(alphabet))]
+		}
+		return string(buf)
+	}
+
+	for i := 0; i < 2000; i++ {
+		target := randomString("aAbB.", 40)
+		pattern := randomString("aAbB", 1+rng.Intn(5))
+
+		for _, flags := range []StringTestFlags{0, LowerMatchesBoth, UpperMatchesBoth, LowerMatchesBoth | UpperMatchesBoth} {
+			sr := wizutil.NewSliceReader(bytes.NewReader([]byte(target)), 0, int64(len(target)))
+			expected := int64(-1)
+			for pos := int64(0); pos < sr.Size(); pos++ {
+				if StringTestWith(sr, pos, pattern, flags, StringEqual) >= 0 {
+					expected = pos
+					break
+				}
+			}
+
+			pos, _ := searchIn(target, pattern, flags)
+			if !assert.EqualValues(t, expected, pos, "searching %q in %q with flags %d", pattern, target, flags) {
+				return
+			}
+		}
+	}
+}
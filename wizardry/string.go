@@ -1,6 +1,10 @@
 package wizardry
 
-import "github.com/itchio/wizardry/wizardry/wizutil"
+import (
+	"strings"
+
+	"github.com/itchio/wizardry/wizardry/wizutil"
+)
 
 // StringTestFlags describes how to perform a string test
 type StringTestFlags int64
@@ -23,10 +27,73 @@ const (
 	ForceText
 	// ForceBinary ("b" flag) forces the test to be done for binary files
 	ForceBinary
+	// TrimWhitespace ("T" flag) trims leading and trailing whitespace
+	// from the value printed in the description
+	TrimWhitespace
+	// FullWord ("f" flag) requires the match to be followed by a
+	// non-word character, or the end of the target
+	FullWord
+	// NoOffsetUpdate ("s" flag) leaves the offset used by relative rules
+	// at the start of the match, instead of advancing it past the match
+	NoOffsetUpdate
+)
+
+// AppliesTo returns false if the "t" or "b" flags restrict a test to
+// text or binary targets, and the target is of the other kind
+func (flags StringTestFlags) AppliesTo(isText bool) bool {
+	if flags&ForceText > 0 && !isText {
+		return false
+	}
+	if flags&ForceBinary > 0 && isText {
+		return false
+	}
+	return true
+}
+
+// Printable returns a string value read from the target the way it should
+// appear in a description: with whitespace trimmed if the "T" flag is set,
+// and otherwise without a trailing newline, like file(1)
+func (flags StringTestFlags) Printable(value string) string {
+	if flags&TrimWhitespace > 0 {
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSuffix(value, "\n")
+}
+
+// StringOperator describes how a string test compares the target to the pattern
+type StringOperator int
+
+const (
+	// StringEqual ("=", or no operator) tests that the target starts with the pattern
+	StringEqual StringOperator = iota
+	// StringLessThan ("<") tests that the target sorts before the pattern
+	StringLessThan
+	// StringGreaterThan (">") tests that the target sorts after the pattern
+	StringGreaterThan
 )
 
-// StringTest looks for a string pattern in target, at given index
+// StringTest looks for a string pattern in target, at given index. It returns
+// the number of target bytes matched, or -1 if it didn't match.
 func StringTest(sr *wizutil.SliceReader, targetIndex int64, patternString string, flags StringTestFlags) int64 {
+	return StringTestWith(sr, targetIndex, patternString, flags, StringEqual)
+}
+
+// StringTestWith is like StringTest, with an operator other than equality.
+// It returns the number of target bytes the test spans, or -1 if it didn't match.
+func StringTestWith(sr *wizutil.SliceReader, targetIndex int64, patternString string, flags StringTestFlags, op StringOperator) int64 {
+	switch op {
+	case StringLessThan:
+		if stringCompare(sr, targetIndex, patternString, flags) < 0 {
+			return int64(len(patternString))
+		}
+		return -1
+	case StringGreaterThan:
+		if stringCompare(sr, targetIndex, patternString, flags) > 0 {
+			return int64(len(patternString))
+		}
+		return -1
+	}
+
 	bv := &wizutil.ByteView{
 		Input:    sr,
 		LookBack: 0,
@@ -35,6 +102,7 @@ func StringTest(sr *wizutil.SliceReader, targetIndex int64, patternString string
 	pattern := []byte(patternString)
 	patternSize := len(pattern)
 	patternIndex := 0
+	startIndex := targetIndex
 
 	for {
 		patternByte := pattern[patternIndex]
@@ -82,7 +150,72 @@ func StringTest(sr *wizutil.SliceReader, targetIndex int64, patternString string
 
 		if patternIndex >= patternSize {
 			// hey it matched all the way!
-			return targetIndex
+			if flags&FullWord > 0 {
+				if nextInt := bv.Get(targetIndex); nextInt != -1 && wizutil.IsWordCharacter(byte(nextInt)) {
+					return -1
+				}
+			}
+			return targetIndex - startIndex
 		}
 	}
 }
+
+// stringCompare compares the target, at given index, lexically with a pattern,
+// over the pattern's length. Bytes past the end of the target read as NUL.
+func stringCompare(sr *wizutil.SliceReader, targetIndex int64, pattern string, flags StringTestFlags) int {
+	bv := &wizutil.ByteView{
+		Input:    sr,
+		LookBack: 0,
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		patternByte := pattern[i]
+		targetByte := byte(0)
+		if targetInt := bv.Get(targetIndex + int64(i)); targetInt != -1 {
+			targetByte = byte(targetInt)
+		}
+
+		if flags&LowerMatchesBoth > 0 && wizutil.IsLowerLetter(patternByte) {
+			targetByte = wizutil.ToLower(targetByte)
+		} else if flags&UpperMatchesBoth > 0 && wizutil.IsUpperLetter(patternByte) {
+			targetByte = wizutil.ToUpper(targetByte)
+		}
+
+		if targetByte != patternByte {
+			return int(targetByte) - int(patternByte)
+		}
+	}
+
+	return 0
+}
+
+// ReadString returns the NUL-terminated string at given index, reading
+// at most maxLen bytes.
+func ReadString(sr *wizutil.SliceReader, targetIndex int64, maxLen int64) string {
+	buf := ReadBytes(sr, targetIndex, maxLen)
+
+	for i, c := range buf {
+		if c == 0 {
+			return string(buf[:i])
+		}
+	}
+	return string(buf)
+}
+
+// ReadBytes returns at most maxLen bytes at given index, fewer if the
+// target ends before that.
+func ReadBytes(sr *wizutil.SliceReader, targetIndex int64, maxLen int64) []byte {
+	if targetIndex < 0 {
+		return nil
+	}
+	if targetIndex+maxLen > sr.Size() {
+		maxLen = sr.Size() - targetIndex
+	}
+	if maxLen <= 0 {
+		return nil
+	}
+
+	buf := make([]byte, maxLen)
+	n, _ := sr.ReadAt(buf, targetIndex)
+	return buf[:n]
+}
@@ -0,0 +1,70 @@
+package wizardry
+
+import (
+	"unicode/utf16"
+
+	"github.com/itchio/wizardry/wizardry/wizutil"
+)
+
+// MaxStringLen is the maximum number of bytes read from the target when
+// printing a string value
+const MaxStringLen = 96
+
+// String16Test looks for a pattern (ASCII or UTF-8) in UTF-16 target text,
+// at given index. It returns the number of bytes matched, or -1 if it didn't match.
+// An empty pattern never matches.
+func String16Test(sr *wizutil.SliceReader, targetIndex int64, patternString string, bigEndian bool) int64 {
+	pattern := utf16.Encode([]rune(patternString))
+	if len(pattern) == 0 {
+		return -1
+	}
+
+	buf := make([]byte, len(pattern)*2)
+	n, _ := sr.ReadAt(buf, targetIndex)
+	if n < len(buf) {
+		return -1
+	}
+
+	units := unitsFor(bigEndian)
+	for i, u := range pattern {
+		if units.unit(buf, i*2) != u {
+			return -1
+		}
+	}
+
+	return int64(len(buf))
+}
+
+// ReadString16 decodes the NUL-terminated UTF-16 string at given index,
+// reading at most maxLen bytes. It returns the decoded string, and the number
+// of bytes it spans in the target, not counting the terminator.
+func ReadString16(sr *wizutil.SliceReader, targetIndex int64, maxLen int64, bigEndian bool) (string, int64) {
+	if targetIndex+maxLen > sr.Size() {
+		maxLen = sr.Size() - targetIndex
+	}
+	if maxLen < 2 {
+		return "", 0
+	}
+
+	buf := make([]byte, maxLen)
+	n, _ := sr.ReadAt(buf, targetIndex)
+
+	units := unitsFor(bigEndian)
+	var decoded []uint16
+	for i := 0; i+1 < n; i += 2 {
+		u := units.unit(buf, i)
+		if u == 0 {
+			break
+		}
+		decoded = append(decoded, u)
+	}
+
+	return string(utf16.Decode(decoded)), int64(len(decoded) * 2)
+}
+
+func unitsFor(bigEndian bool) utf16Units {
+	if bigEndian {
+		return bigEndianUnits
+	}
+	return littleEndianUnits
+}
@@ -0,0 +1,44 @@
+package wizardry
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/itchio/wizardry/wizardry/wizutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_String16(t *testing.T) {
+	le := []byte("..h\x00\xe9\x00l\x00l\x00o\x00\x00\x00rest")
+	be := []byte("..\x00h\x00\xe9\x00l\x00l\x00o\x00\x00")
+	reader := func(target []byte) *wizutil.SliceReader {
+		return wizutil.NewSliceReader(bytes.NewReader(target), 0, int64(len(target)))
+	}
+
+	assert.EqualValues(t, 10, String16Test(reader(le), 2, "héllo", false))
+	assert.EqualValues(t, 4, String16Test(reader(le), 2, "hé", false))
+	assert.EqualValues(t, -1, String16Test(reader(le), 2, "hé", true))
+	assert.EqualValues(t, -1, String16Test(reader(le), 2, "help", false))
+	assert.EqualValues(t, 10, String16Test(reader(be), 2, "héllo", true))
+	// the target is too short
+	assert.EqualValues(t, -1, String16Test(reader(be), 2, "héllo!", true))
+	// an empty pattern doesn't match anything
+	assert.EqualValues(t, -1, String16Test(reader(le), 2, "", false))
+
+	s, n := ReadString16(reader(le), 2, MaxStringLen, false)
+	assert.EqualValues(t, "héllo", s)
+	assert.EqualValues(t, 10, n)
+
+	s, n = ReadString16(reader(be), 2, MaxStringLen, true)
+	assert.EqualValues(t, "héllo", s)
+	assert.EqualValues(t, 10, n)
+
+	// no terminator before maxLen
+	s, n = ReadString16(reader(le), 2, 4, false)
+	assert.EqualValues(t, "hé", s)
+	assert.EqualValues(t, 4, n)
+
+	s, n = ReadString16(reader(le), int64(len(le))-1, MaxStringLen, false)
+	assert.EqualValues(t, "", s)
+	assert.EqualValues(t, 0, n)
+}
@@ -0,0 +1,269 @@
+package wizardry
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/itchio/wizardry/wizardry/wizutil"
+)
+
+// TextMaxLen is how many bytes of a target are looked at to classify it
+const TextMaxLen = 256 * 1024
+
+// TextEncoding describes how the characters of a text file are encoded
+type TextEncoding int
+
+const (
+	// EncodingBinary is for targets that don't look like text at all
+	EncodingBinary TextEncoding = iota
+	// EncodingASCII is for 7-bit text
+	EncodingASCII
+	// EncodingUTF8 is for valid UTF-8 text with at least one multi-byte sequence
+	EncodingUTF8
+	// EncodingUTF16LE is for little-endian UTF-16 text with a byte order mark
+	EncodingUTF16LE
+	// EncodingUTF16BE is for big-endian UTF-16 text with a byte order mark
+	EncodingUTF16BE
+	// EncodingISO8859 is for 8-bit text that isn't valid UTF-8
+	EncodingISO8859
+)
+
+// LineTerminators is a set of line terminators found in a text file
+type LineTerminators int
+
+const (
+	// TerminatorLF is for unix-style line endings ("\n")
+	TerminatorLF LineTerminators = 1 << iota
+	// TerminatorCRLF is for windows-style line endings ("\r\n")
+	TerminatorCRLF
+	// TerminatorCR is for classic-mac-style line endings ("\r")
+	TerminatorCR
+)
+
+// TextInfo is the result of classifying a target as text or binary
+type TextInfo struct {
+	Encoding    TextEncoding
+	HasBOM      bool
+	Terminators LineTerminators
+	// Empty is true for targets with no bytes at all, which aren't text
+	Empty bool
+}
+
+// IsText returns true if the target was classified as any kind of text
+func (ti *TextInfo) IsText() bool {
+	return ti.Encoding != EncodingBinary
+}
+
+// String returns a description similar to what file(1) prints for text files,
+// for example "ASCII text, with CRLF line terminators"
+func (ti *TextInfo) String() string {
+	if ti.Empty {
+		return "empty"
+	}
+
+	s := ""
+	switch ti.Encoding {
+	case EncodingBinary:
+		return "data"
+	case EncodingASCII:
+		s = "ASCII text"
+	case EncodingUTF8:
+		if ti.HasBOM {
+			s = "UTF-8 Unicode (with BOM) text"
+		} else {
+			s = "UTF-8 Unicode text"
+		}
+	case EncodingUTF16LE:
+		s = "Little-endian UTF-16 Unicode text"
+	case EncodingUTF16BE:
+		s = "Big-endian UTF-16 Unicode text"
+	case EncodingISO8859:
+		s = "ISO-8859 text"
+	}
+
+	var terminators []string
+	if ti.Terminators&TerminatorCRLF > 0 {
+		terminators = append(terminators, "CRLF")
+	}
+	if ti.Terminators&TerminatorCR > 0 {
+		terminators = append(terminators, "CR")
+	}
+	if ti.Terminators&TerminatorLF > 0 && len(terminators) > 0 {
+		// LF-only is the default, and isn't worth mentioning
+		terminators = append(terminators, "LF")
+	}
+
+	if ti.Terminators == 0 {
+		s += ", with no line terminators"
+	} else if len(terminators) > 0 {
+		s += ", with " + strings.Join(terminators, ", ") + " line terminators"
+	}
+
+	return s
+}
+
+// ClassifyText looks at the start of a target to determine whether it's
+// text, which encoding it uses, and which line terminators it has.
+func ClassifyText(sr *wizutil.SliceReader) *TextInfo {
+	ti := &TextInfo{}
+
+	size := sr.Size()
+	truncated := false
+	if size > TextMaxLen {
+		size = TextMaxLen
+		truncated = true
+	}
+	if size <= 0 {
+		ti.Empty = true
+		return ti
+	}
+
+	buf := make([]byte, size)
+	n, _ := sr.ReadAt(buf, 0)
+	buf = buf[:n]
+
+	if len(buf) >= 2 && buf[0] == 0xff && buf[1] == 0xfe {
+		if classifyUTF16(ti, buf[2:], littleEndianUnits) {
+			ti.Encoding = EncodingUTF16LE
+			ti.HasBOM = true
+		}
+		return ti
+	}
+
+	if len(buf) >= 2 && buf[0] == 0xfe && buf[1] == 0xff {
+		if classifyUTF16(ti, buf[2:], bigEndianUnits) {
+			ti.Encoding = EncodingUTF16BE
+			ti.HasBOM = true
+		}
+		return ti
+	}
+
+	if len(buf) >= 3 && buf[0] == 0xef && buf[1] == 0xbb && buf[2] == 0xbf {
+		ti.HasBOM = true
+		buf = buf[3:]
+	}
+
+	ascii := true
+	latin1 := true
+	for _, c := range buf {
+		if !isTextChar(c) {
+			ascii = false
+			if c < 0xa0 {
+				latin1 = false
+			}
+		}
+	}
+
+	switch {
+	case ascii && !ti.HasBOM:
+		ti.Encoding = EncodingASCII
+	case looksUTF8(buf, truncated, ti.HasBOM):
+		ti.Encoding = EncodingUTF8
+	case latin1 && !ti.HasBOM:
+		ti.Encoding = EncodingISO8859
+	default:
+		ti.HasBOM = false
+		return ti
+	}
+
+	ti.Terminators = scanTerminators(buf)
+	return ti
+}
+
+// utf16Units describes in which order UTF-16 code units are stored
+type utf16Units int
+
+const (
+	littleEndianUnits utf16Units = iota
+	bigEndianUnits
+)
+
+// unit decodes the code unit starting at buf[i]
+func (u utf16Units) unit(buf []byte, i int) uint16 {
+	if u == bigEndianUnits {
+		return uint16(buf[i])<<8 | uint16(buf[i+1])
+	}
+	return uint16(buf[i+1])<<8 | uint16(buf[i])
+}
+
+func classifyUTF16(ti *TextInfo, buf []byte, units utf16Units) bool {
+	// keep ASCII code units so we can look for line terminators
+	ascii := make([]byte, 0, len(buf)/2)
+
+	for i := 0; i+1 < len(buf); i += 2 {
+		u := units.unit(buf, i)
+		if u == 0xfffe || u == 0xffff {
+			return false
+		}
+		if u < 0x80 {
+			if !isTextChar(byte(u)) {
+				return false
+			}
+			ascii = append(ascii, byte(u))
+		}
+	}
+
+	ti.Terminators = scanTerminators(ascii)
+	return true
+}
+
+// looksUTF8 returns true if buf is valid UTF-8 made of text characters,
+// with at least one multi-byte sequence (unless it had a byte order mark).
+// If the buffer was truncated, an incomplete sequence at the very end
+// is tolerated.
+func looksUTF8(buf []byte, truncated bool, hasBOM bool) bool {
+	multiByte := false
+	for i := 0; i < len(buf); {
+		c := buf[i]
+		if c < utf8.RuneSelf {
+			if !isTextChar(c) {
+				return false
+			}
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRune(buf[i:])
+		if r == utf8.RuneError && size <= 1 {
+			if truncated && !utf8.FullRune(buf[i:]) {
+				break
+			}
+			return false
+		}
+		multiByte = true
+		i += size
+	}
+	return multiByte || hasBOM
+}
+
+func scanTerminators(buf []byte) LineTerminators {
+	var lt LineTerminators
+	for i := 0; i < len(buf); i++ {
+		switch buf[i] {
+		case '\n':
+			lt |= TerminatorLF
+		case '\r':
+			if i+1 < len(buf) && buf[i+1] == '\n' {
+				lt |= TerminatorCRLF
+				i++
+			} else {
+				lt |= TerminatorCR
+			}
+		}
+	}
+	return lt
+}
+
+// isTextChar returns true for printable ASCII and the control characters
+// commonly found in text files (bell, backspace, tab, newline, vertical tab,
+// form feed, carriage return, escape)
+func isTextChar(c byte) bool {
+	if c >= 0x20 && c < 0x7f {
+		return true
+	}
+	switch c {
+	case '\a', '\b', '\t', '\n', '\v', '\f', '\r', 0x1b:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package wizardry
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/itchio/wizardry/wizardry/wizutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ClassifyText(t *testing.T) {
+	tests := []struct {
+		target      string
+		description string
+		isText      bool
+	}{
+		{"", "empty", false},
+		{"hello\n", "ASCII text", true},
+		{"hello", "ASCII text, with no line terminators", true},
+		{"hello\r\nworld\r\n", "ASCII text, with CRLF line terminators", true},
+		{"hello\rworld\n", "ASCII text, with CR, LF line terminators", true},
+		{"h\xc3\xa9llo\n", "UTF-8 Unicode text", true},
+		{"\xef\xbb\xbfhello\n", "UTF-8 Unicode (with BOM) text", true},
+		{"h\xe9llo\n", "ISO-8859 text", true},
+		{"\xff\xfeh\x00i\x00\n\x00", "Little-endian UTF-16 Unicode text", true},
+		{"\xfe\xff\x00h\x00i\x00\r\x00\n", "Big-endian UTF-16 Unicode text, with CRLF line terminators", true},
+		{"\xff\xfeh\x00\x01\x00", "data", false},
+		{"\x7fELF\x02\x01\x01\x00", "data", false},
+		{"h\x80llo\n", "data", false},
+	}
+
+	for _, test := range tests {
+		target := []byte(test.target)
+		sr := wizutil.NewSliceReader(bytes.NewReader(target), 0, int64(len(target)))
+		ti := ClassifyText(sr)
+		assert.EqualValues(t, test.description, ti.String(), "for %q", test.target)
+		assert.EqualValues(t, test.isText, ti.IsText(), "for %q", test.target)
+	}
+}
+
+func Test_ClassifyTextTruncated(t *testing.T) {
+	// a multi-byte sequence cut short by the end of the sample is fine
+	target := append([]byte("\xc3\xa9"), bytes.Repeat([]byte("a"), TextMaxLen-3)...)
+	target = append(target, "\xc3\xa9"...)
+	sr := wizutil.NewSliceReader(bytes.NewReader(target), 0, int64(len(target)))
+	assert.EqualValues(t, "UTF-8 Unicode text, with no line terminators", ClassifyText(sr).String())
+}
@@ -0,0 +1,176 @@
+// Package wizcache remembers what targets were identified as, so that
+// identifying the same targets again, across builds for example, doesn't
+// evaluate any rule.
+package wizcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/itchio/wizardry/wizardry"
+	"github.com/itchio/wizardry/wizardry/wizinterpreter"
+	"github.com/itchio/wizardry/wizardry/wizutil"
+)
+
+// DefaultPrefixSize is how many bytes at the start of targets are hashed,
+// enough for text classification to never read past them
+const DefaultPrefixSize = wizardry.TextMaxLen
+
+// version changes whenever the way results are computed or encoded does,
+// so that stores never return results computed by older versions
+const version = 1
+
+// Cache sits in front of an interpreter. Results are keyed by the size of
+// the target, a hash of its first PrefixSize bytes, and the fingerprint of
+// the interpreter's spellbook - so they're invalidated as soon as the rules
+// change, or the Version of a custom kind they use does. Results that depend
+// on bytes past that prefix (from rules that look at the end of large
+// targets, for example) are never cached.
+//
+// Results are computed without wizardry.Limits, which only make
+// identifications fail, never change what they find.
+//
+// The spellbook must not be modified once the cache has been used.
+type Cache struct {
+	Context *wizinterpreter.InterpretContext
+	Store   Store
+	// PrefixSize defaults to DefaultPrefixSize if zero
+	PrefixSize int64
+
+	fingerprintOnce sync.Once
+	fingerprint     string
+
+	hits        int64
+	misses      int64
+	uncacheable int64
+}
+
+// Stats tells how useful a cache has been
+type Stats struct {
+	// Hits is how many targets were found in the cache
+	Hits int64
+	// Misses is how many targets had to be identified
+	Misses int64
+	// Uncacheable is how many of the misses couldn't be stored, because
+	// rules read past the hashed prefix
+	Uncacheable int64
+}
+
+// New returns a cache of the results of ictx, kept in store
+func New(ictx *wizinterpreter.InterpretContext, store Store) *Cache {
+	return &Cache{
+		Context: ictx,
+		Store:   store,
+	}
+}
+
+// Identify is like InterpretContext.Identify, using the cache when possible
+func (c *Cache) Identify(sr *wizutil.SliceReader) ([]string, error) {
+	result, err := c.IdentifyResult(sr)
+	if err != nil {
+		return nil, err
+	}
+	return result.Strings, nil
+}
+
+// IdentifyResult is like InterpretContext.IdentifyResult, using the cache
+// when possible. Errors from the store are logged, and otherwise ignored.
+func (c *Cache) IdentifyResult(sr *wizutil.SliceReader) (wizardry.Result, error) {
+	key, err := c.key(sr)
+	if err != nil {
+		return wizardry.Result{}, err
+	}
+
+	value, ok, err := c.Store.Get(key)
+	if err != nil {
+		c.Context.Logf("wizcache: while looking up %s: %s", key, err.Error())
+	} else if ok {
+		var result wizardry.Result
+		err = json.Unmarshal(value, &result)
+		if err == nil {
+			atomic.AddInt64(&c.hits, 1)
+			return result, nil
+		}
+		c.Context.Logf("wizcache: while decoding %s: %s", key, err.Error())
+	}
+	atomic.AddInt64(&c.misses, 1)
+
+	tracker := &readTracker{reader: sr}
+	result, err := c.Context.IdentifyResult(wizutil.NewSliceReader(tracker, 0, sr.Size()))
+	if err != nil {
+		return wizardry.Result{}, err
+	}
+
+	if tracker.end > c.prefixSize() {
+		atomic.AddInt64(&c.uncacheable, 1)
+		return result, nil
+	}
+
+	value, err = json.Marshal(result)
+	if err == nil {
+		err = c.Store.Put(key, value)
+	}
+	if err != nil {
+		c.Context.Logf("wizcache: while storing %s: %s", key, err.Error())
+	}
+
+	return result, nil
+}
+
+// Stats returns how many hits and misses the cache has had so far
+func (c *Cache) Stats() Stats {
+	return Stats{
+		Hits:        atomic.LoadInt64(&c.hits),
+		Misses:      atomic.LoadInt64(&c.misses),
+		Uncacheable: atomic.LoadInt64(&c.uncacheable),
+	}
+}
+
+func (c *Cache) prefixSize() int64 {
+	if c.PrefixSize > 0 {
+		return c.PrefixSize
+	}
+	return DefaultPrefixSize
+}
+
+// key hashes everything a result depends on, as long as rules don't read
+// past the prefix
+func (c *Cache) key(sr *wizutil.SliceReader) (string, error) {
+	c.fingerprintOnce.Do(func() {
+		c.fingerprint = c.Context.Book.Fingerprint()
+	})
+
+	prefixSize := c.prefixSize()
+	if prefixSize > sr.Size() {
+		prefixSize = sr.Size()
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "wizcache %d\n%s\n", version, c.fingerprint)
+	fmt.Fprintf(h, "rank %t\nsize %d\n", c.Context.RankCandidates, sr.Size())
+
+	_, err := io.Copy(h, io.NewSectionReader(sr, 0, prefixSize))
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// readTracker remembers how far into the target rules have read
+type readTracker struct {
+	reader io.ReaderAt
+	end    int64
+}
+
+func (rt *readTracker) ReadAt(p []byte, off int64) (int, error) {
+	n, err := rt.reader.ReadAt(p, off)
+	if end := off + int64(n); end > rt.end {
+		rt.end = end
+	}
+	return n, err
+}
@@ -0,0 +1,126 @@
+package wizcache
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/itchio/wizardry/wizardry"
+	"github.com/itchio/wizardry/wizardry/wizparser"
+	"github.com/itchio/wizardry/wizardry/wizutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Cache(t *testing.T) {
+	store := NewMemoryStore(0)
+	magic := "0	string	ABC	abc\n>3	byte	x	\\b, version %d"
+
+	c := cacheWith(t, magic, store)
+	assert.EqualValues(t, "abc, version 1", identify(t, c, []byte("ABC\x01")))
+	assert.EqualValues(t, "abc, version 1", identify(t, c, []byte("ABC\x01")))
+	assert.EqualValues(t, "abc, version 2", identify(t, c, []byte("ABC\x02")))
+	assert.EqualValues(t, Stats{Hits: 1, Misses: 2}, c.Stats())
+
+	// changing the rules invalidates the cache
+	c = cacheWith(t, strings.Replace(magic, "version", "v", 1), store)
+	assert.EqualValues(t, "abc, v 1", identify(t, c, []byte("ABC\x01")))
+	assert.EqualValues(t, Stats{Misses: 1}, c.Stats())
+	assert.EqualValues(t, 3, store.Len())
+}
+
+// cachedByteKind matches the byte at its offset, and prints it
+var cachedByteKind = &wizparser.CustomKindDefinition{
+	Name:    "cachedbyte",
+	Version: "1",
+	Evaluate: func(sr *wizutil.SliceReader, offset int64, data interface{}) wizardry.CustomResult {
+		buf := make([]byte, 1)
+		n, _ := sr.ReadAt(buf, offset)
+		return wizardry.CustomResult{Matched: n == 1, Value: int64(buf[0]), Length: 1}
+	},
+}
+
+func init() {
+	err := wizparser.RegisterCustomKind(cachedByteKind)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func Test_CacheCustomKind(t *testing.T) {
+	store := NewMemoryStore(0)
+	magic := "0	string	ABC	abc\n>3	cachedbyte	x	\\b, byte %d"
+
+	c := cacheWith(t, magic, store)
+	assert.EqualValues(t, "abc, byte 1", identify(t, c, []byte("ABC\x01")))
+	assert.EqualValues(t, "abc, byte 1", identify(t, c, []byte("ABC\x01")))
+	assert.EqualValues(t, Stats{Hits: 1, Misses: 1}, c.Stats())
+
+	// a new version of the kind invalidates the cache
+	cachedByteKind.Version = "2"
+	defer func() { cachedByteKind.Version = "1" }()
+	c = cacheWith(t, magic, store)
+	assert.EqualValues(t, "abc, byte 1", identify(t, c, []byte("ABC\x01")))
+	assert.EqualValues(t, Stats{Misses: 1}, c.Stats())
+}
+
+func Test_CachePrefix(t *testing.T) {
+	store := NewMemoryStore(0)
+	c := cacheWith(t, "0	string	ABC	abc\n>-1	byte	x	\\b, ends with %d", store)
+	c.PrefixSize = 8
+
+	small := []byte("ABC\x01")
+	large := []byte("ABC\x00\x00\x00\x00\x00\x00\x00\x02")
+
+	assert.EqualValues(t, "abc, ends with 1", identify(t, c, small))
+	assert.EqualValues(t, "abc, ends with 2", identify(t, c, large))
+	assert.EqualValues(t, Stats{Misses: 2, Uncacheable: 1}, c.Stats())
+
+	// same prefix, different end
+	large[len(large)-1] = 3
+	assert.EqualValues(t, "abc, ends with 3", identify(t, c, large))
+	assert.EqualValues(t, "abc, ends with 1", identify(t, c, small))
+	assert.EqualValues(t, Stats{Hits: 1, Misses: 3, Uncacheable: 2}, c.Stats())
+}
+
+func Test_MemoryStoreEviction(t *testing.T) {
+	ms := NewMemoryStore(2)
+	assert.NoError(t, ms.Put("a", []byte("1")))
+	assert.NoError(t, ms.Put("b", []byte("2")))
+
+	_, ok, _ := ms.Get("a")
+	assert.True(t, ok)
+
+	// "b" is the least recently used
+	assert.NoError(t, ms.Put("c", []byte("3")))
+	_, ok, _ = ms.Get("b")
+	assert.False(t, ok)
+	value, ok, _ := ms.Get("a")
+	assert.True(t, ok)
+	assert.EqualValues(t, "1", string(value))
+	assert.EqualValues(t, 2, ms.Len())
+}
+
+func Test_DirStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wizcache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	magic := "0	string	ABC	abc"
+
+	ds, err := NewDirStore(dir)
+	assert.NoError(t, err)
+	c := cacheWith(t, magic, ds)
+	assert.EqualValues(t, "abc", identify(t, c, []byte("ABC")))
+
+	// a new run, with the same rules
+	ds, err = NewDirStore(dir)
+	assert.NoError(t, err)
+	c = cacheWith(t, magic, ds)
+	assert.EqualValues(t, "abc", identify(t, c, []byte("ABC")))
+	assert.EqualValues(t, Stats{Hits: 1}, c.Stats())
+
+	_, ok, err := ds.Get("0000")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,30 @@
+package wizcache
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/itchio/wizardry/wizardry/wizinterpreter"
+	"github.com/itchio/wizardry/wizardry/wizparser"
+	"github.com/itchio/wizardry/wizardry/wizutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// cacheWith returns a cache in front of an interpreter for the rules of a magic file
+func cacheWith(t *testing.T, magic string, store Store) *Cache {
+	NoLogf := func(format string, args ...interface{}) {}
+
+	book := make(wizparser.Spellbook)
+	pctx := &wizparser.ParseContext{Logf: NoLogf}
+	assert.NoError(t, pctx.Parse(strings.NewReader(magic), book))
+
+	return New(&wizinterpreter.InterpretContext{Logf: NoLogf, Book: book}, store)
+}
+
+func identify(t *testing.T, c *Cache, target []byte) string {
+	sr := wizutil.NewSliceReader(bytes.NewReader(target), 0, int64(len(target)))
+	result, err := c.Identify(sr)
+	assert.NoError(t, err)
+	return wizutil.MergeStrings(result)
+}
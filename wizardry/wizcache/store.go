@@ -0,0 +1,154 @@
+package wizcache
+
+import (
+	"container/list"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store keeps encoded results by key. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// Get returns the value stored for key, if any
+	Get(key string) ([]byte, bool, error)
+	// Put stores a value for key, replacing any previous one
+	Put(key string, value []byte) error
+}
+
+// MemoryStore keeps results in memory, evicting the least recently used
+// ones when it's full
+type MemoryStore struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+type memoryEntry struct {
+	key   string
+	value []byte
+}
+
+// NewMemoryStore returns a store that holds at most maxEntries results,
+// or any number of them if maxEntries is 0
+func NewMemoryStore(maxEntries int) *MemoryStore {
+	return &MemoryStore{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the value stored for key, and marks it as recently used
+func (ms *MemoryStore) Get(key string) ([]byte, bool, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	el, ok := ms.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	ms.order.MoveToFront(el)
+	return el.Value.(*memoryEntry).value, true, nil
+}
+
+// Put stores a value for key, evicting the least recently used value if
+// the store is full
+func (ms *MemoryStore) Put(key string, value []byte) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if el, ok := ms.entries[key]; ok {
+		el.Value.(*memoryEntry).value = value
+		ms.order.MoveToFront(el)
+		return nil
+	}
+
+	ms.entries[key] = ms.order.PushFront(&memoryEntry{key: key, value: value})
+	if ms.maxEntries > 0 && ms.order.Len() > ms.maxEntries {
+		oldest := ms.order.Back()
+		ms.order.Remove(oldest)
+		delete(ms.entries, oldest.Value.(*memoryEntry).key)
+	}
+	return nil
+}
+
+// Len returns how many results are stored
+func (ms *MemoryStore) Len() int {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	return ms.order.Len()
+}
+
+// DirStore keeps results in a directory, one file per key, so they survive
+// across runs. Results computed with previous rules are never read again:
+// deleting the directory is the only way to reclaim their space.
+type DirStore struct {
+	dir string
+}
+
+var _ Store = (*DirStore)(nil)
+
+// NewDirStore returns a store that keeps results in dir, creating it if needed
+func NewDirStore(dir string) (*DirStore, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	return &DirStore{dir: dir}, nil
+}
+
+// Get reads the value stored for key, if any
+func (ds *DirStore) Get(key string) ([]byte, bool, error) {
+	value, err := ioutil.ReadFile(ds.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Put writes the value for key. Values are written to a temporary file
+// first, so concurrent readers never see partial values.
+func (ds *DirStore) Put(key string, value []byte) error {
+	path := ds.path(key)
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), key+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(value)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// keys are hex-encoded hashes, spread over subdirectories by their first byte
+func (ds *DirStore) path(key string) string {
+	if len(key) < 2 {
+		return filepath.Join(ds.dir, key)
+	}
+	return filepath.Join(ds.dir, key[:2], key)
+}
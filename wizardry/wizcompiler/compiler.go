@@ -1,13 +1,18 @@
 package wizcompiler
 
 import (
+	"bytes"
 	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/itchio/wizardry/wizardry"
 	"github.com/itchio/wizardry/wizardry/wizparser"
 	"github.com/pkg/errors"
 )
@@ -18,6 +23,11 @@ type ruleNode struct {
 	id       int64
 	rule     wizparser.Rule
 	children []*ruleNode
+	// cases of string switches, see stringSwitchify
+	cases []*ruleNode
+	// which bit of its level's string switch mask lets this node through,
+	// if it's a string switch case
+	switchBit uint64
 }
 
 type nodeEmitter func(node *ruleNode, defaultMarker string, prevSibling *ruleNode)
@@ -27,18 +37,136 @@ type PageUsage struct {
 	EmitSwapped bool
 }
 
-// Compile generates go code from a spellbook
+// CompileOptions tweaks the generated code
+type CompileOptions struct {
+	// Package is the go package to generate, "main" if empty
+	Package string
+	// Chatty makes the generated code print every rule it evaluates
+	Chatty bool
+	// EmitComments precedes the code of every rule with its source
+	EmitComments bool
+	// MIME makes the generated code report the MIME types and extensions
+	// of "!:mime" and "!:ext" annotations, like the interpreter does, and
+	// emit a MIMETypes table
+	MIME bool
+	// Linear evaluates top-level rules one after the other, instead of
+	// only those that can match the first byte of the target
+	Linear bool
+}
+
+// CompileStats describes what was generated
+type CompileStats struct {
+	// Duration is how long generating and formatting the code took
+	Duration time.Duration
+	// Size is the size of the generated code, in bytes
+	Size int64
+	// Pages is how many page functions were generated, counting pages
+	// used with swapped endianness twice
+	Pages int
+	// Rules is how many rules were compiled, in all page functions
+	Rules int
+	// Dispatched is how many top-level rules only run when the byte at the
+	// page offset is the one they test first
+	Dispatched int
+	// UnreachablePages lists the pages that weren't compiled, because no
+	// rule that may match uses them, directly or not, from the "" page
+	UnreachablePages []string
+	// DeadRules lists the rules that weren't compiled, because they can
+	// never match
+	DeadRules []DeadRule
+}
+
+// WriteReport prints what was left out of the generated code, if anything
+func (cs *CompileStats) WriteReport(w io.Writer) {
+	if len(cs.UnreachablePages) > 0 {
+		fmt.Fprintf(w, "Removed %d unreachable pages: %s\n", len(cs.UnreachablePages), strings.Join(cs.UnreachablePages, ", "))
+	}
+	if len(cs.DeadRules) > 0 {
+		removed := 0
+		for _, dead := range cs.DeadRules {
+			removed += dead.Rules
+		}
+		fmt.Fprintf(w, "Removed %d rules that can never match:\n", removed)
+		for _, dead := range cs.DeadRules {
+			page := dead.Page
+			if page == "" {
+				page = "(root)"
+			}
+			children := ""
+			if dead.Rules > 1 {
+				children = fmt.Sprintf(", with %d children", dead.Rules-1)
+			}
+			fmt.Fprintf(w, "  %s: %s (%s%s)\n", page, strings.TrimSpace(dead.Line), dead.Reason, children)
+		}
+	}
+}
+
+// Compile generates go code from a spellbook into the file at output,
+// printing progress to stdout. See CompileTo.
 func Compile(book wizparser.Spellbook, output string, chatty bool, emitComments bool, pkg string) error {
-	startTime := time.Now()
+	fmt.Println("Generating into:", output)
+
+	var buf bytes.Buffer
+	stats, err := CompileTo(&buf, book, CompileOptions{
+		Package:      pkg,
+		Chatty:       chatty,
+		EmitComments: emitComments,
+	})
+	if err != nil {
+		return err
+	}
 
-	f, err := os.Create(output)
+	err = ioutil.WriteFile(output, buf.Bytes(), 0644)
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	fmt.Println("Generating into:", output)
+	fmt.Printf("Compiled in %s\n", stats.Duration)
+	fmt.Printf("Generated code is %.2f KiB\n", float64(stats.Size)/1024.0)
+	stats.WriteReport(os.Stdout)
+
+	return nil
+}
+
+// CompileTo generates go code from a spellbook, formatted like gofmt
+// would, and writes it to w
+func CompileTo(w io.Writer, book wizparser.Spellbook, opts CompileOptions) (*CompileStats, error) {
+	startTime := time.Now()
+	stats := &CompileStats{}
+
+	pkg := opts.Package
+	if pkg == "" {
+		pkg = "main"
+	}
+	chatty := opts.Chatty
+	emitComments := opts.EmitComments
+	emitMIME := opts.MIME
+	linear := opts.Linear
+
+	err := book.Validate()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// the fingerprint is that of the rules as they were given
+	fingerprint := book.Fingerprint()
+	book, stats.UnreachablePages, stats.DeadRules = prune(book)
 
-	defer f.Close()
+	customImports, err := customKindImports(book)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// sort pages, prune already dropped the ones nothing uses
+	var pages []string
+	for page := range book {
+		pages = append(pages, page)
+	}
+	sort.Strings(pages)
+
+	usages := computePagesUsage(book)
+
+	f := new(bytes.Buffer)
 
 	lf := []byte("\n")
 	oneIndent := []byte("  ")
@@ -86,10 +214,15 @@ func Compile(book wizparser.Spellbook, output string, chatty bool, emitComments
 	emit("")
 	emit("import (")
 	withIndent(func() {
+		emit(strconv.Quote("context"))
 		emit(strconv.Quote("fmt"))
+		emit(strconv.Quote("io"))
 		emit(strconv.Quote("encoding/binary"))
 		emit(strconv.Quote("github.com/itchio/wizardry/wizardry"))
 		emit(strconv.Quote("github.com/itchio/wizardry/wizardry/wizutil"))
+		for _, imp := range customImports {
+			emit(strconv.Quote(imp))
+		}
 	})
 	emit(")")
 	emit("")
@@ -100,11 +233,124 @@ func Compile(book wizparser.Spellbook, output string, chatty bool, emitComments
 
 	emit("var l binary.ByteOrder=binary.LittleEndian")
 	emit("var b binary.ByteOrder=binary.BigEndian")
-	emit("var gt=wizardry.StringTest")
-	emit("var ht=wizardry.SearchTest")
+	emit("var gt=wizardry.StringTestWith")
+	emit("var ht=wizardry.SearchTestWith")
+	emit("var gu=wizardry.String16Test")
+	emit("var gv=wizardry.ReadString16")
+	emit("var gs=wizardry.ReadString")
+	emit("var gp=wizardry.ReadBytes")
+	emit("var gg=wizardry.ReadGUID")
+	emit("var gd=wizardry.DERTest")
 	emit("var t=true")
 	emit("var f=false")
-	emit("var tb=make([]byte, 8)")
+	emit("")
+
+	emit("// state is what a single identification keeps track of, so that")
+	emit("// identifications may run concurrently")
+	emit("type state struct {")
+	withIndent(func() {
+		emit("gw *wizardry.Guard // enforces the limits of the identification")
+		emit("tb [8]byte // integers are read into it")
+		emit("ic int // indirection depth")
+	})
+	emit("}")
+	emit("")
+
+	emit("const (")
+	withIndent(func() {
+		emit("// Fingerprint identifies the rules this package was generated from,")
+		emit("// see wizparser.Spellbook.Fingerprint")
+		emit("Fingerprint = %s", strconv.Quote(fingerprint))
+		emit("// Chatty, EmitComments, MIME and Linear are the options this package")
+		emit("// was generated with, see wizcompiler.CompileOptions")
+		emit("Chatty = %t", chatty)
+		emit("EmitComments = %t", emitComments)
+		emit("MIME = %t", emitMIME)
+		emit("Linear = %t", linear)
+	})
+	emit(")")
+	emit("")
+
+	emit("// Identify follows the rules to find out the type of a target of the")
+	emit("// given size, falling back to text classification if nothing matched.")
+	emit("// The result is empty if \"use\" rules nest too deeply, see IdentifyContext.")
+	emit("// It may be called from several goroutines at once.")
+	emit("func Identify(r io.ReaderAt, size int64) wizardry.Result {")
+	withIndent(func() {
+		emit("res,_:=IdentifyContext(context.Background(),r,size,wizardry.Limits{})")
+		emit("return res")
+	})
+	emit("}")
+	emit("")
+
+	emit("// IdentifyContext is like Identify, but gives up when ctx is done, or")
+	emit("// when identification exceeds the given limits. The error is then")
+	emit("// either ctx's error, or a *wizardry.LimitError.")
+	emit("func IdentifyContext(ctx context.Context, r io.ReaderAt, size int64, lm wizardry.Limits) (wizardry.Result, error) {")
+	withIndent(func() {
+		emit("st:=&state{gw: wizardry.NewGuard(ctx,r,lm)}")
+		emit("sr:=wizutil.NewSliceReader(st.gw,0,size)")
+		emit("fd:=make(wizardry.Fields)")
+		emit("out:=identify%s(st,sr,0,fd)", pageSymbol("", false))
+		emit("if len(out)==0 {")
+		withIndent(func() {
+			emit("out=append(out, st.gw.ClassifyText(sr).String())")
+		})
+		emit("}")
+		emit("// reads fail silently once the guard has failed")
+		emit("if err:=st.gw.Err(); err!=nil {return wizardry.Result{}, err}")
+		emit("return wizardry.Result{Strings: out, Fields: fd}, nil")
+	})
+	emit("}")
+	emit("")
+
+	emit("// Pages lists the named pages of rules compiled in this package")
+	emit("func Pages() []string {")
+	withIndent(func() {
+		emit("return []string{")
+		withIndent(func() {
+			for _, page := range pages {
+				if page != "" {
+					emit("%s,", strconv.Quote(page))
+				}
+			}
+		})
+		emit("}")
+	})
+	emit("}")
+	emit("")
+
+	if emitMIME {
+		mimeTypes := mimeTable(book)
+		var mimeNames []string
+		for mime := range mimeTypes {
+			mimeNames = append(mimeNames, mime)
+		}
+		sort.Strings(mimeNames)
+
+		emit("// MIMETypes maps the MIME types the rules can report to the extensions")
+		emit("// files of that type usually have")
+		emit("var MIMETypes = map[string][]string{")
+		withIndent(func() {
+			for _, mime := range mimeNames {
+				var quoted []string
+				for _, ext := range mimeTypes[mime] {
+					quoted = append(quoted, strconv.Quote(ext))
+				}
+				emit("%s: {%s},", strconv.Quote(mime), strings.Join(quoted, ", "))
+			}
+		})
+		emit("}")
+		emit("")
+	}
+
+	emit("// classifies the target as text or binary, at most once per page")
+	emit("func ix(st *state, r *wizutil.SliceReader, tx **wizardry.TextInfo) bool {")
+	withIndent(func() {
+		emit("if *tx==nil {*tx=st.gw.ClassifyText(r)}")
+		emit("return (*tx).IsText()")
+	})
+	emit("}")
 	emit("")
 
 	for _, byteWidth := range []byte{1, 2, 4, 8} {
@@ -112,14 +358,14 @@ func Compile(book wizparser.Spellbook, output string, chatty bool, emitComments
 			retType := "uint64"
 
 			emit("// reads an unsigned %d-bit %s integer", byteWidth*8, endianness)
-			emit("func f%d%s(r *wizutil.SliceReader, off int64) (%s, bool) {", byteWidth, endiannessString(endianness, false), retType)
+			emit("func f%d%s(st *state, r *wizutil.SliceReader, off int64) (%s, bool) {", byteWidth, endiannessString(endianness, false), retType)
 			withIndent(func() {
-				emit("n,err:=r.ReadAt(tb,int64(off))")
+				emit("n,err:=r.ReadAt(st.tb[:%d],int64(off))", byteWidth)
 				emit("if n<%d||err!=nil {return 0,f}", byteWidth)
 				if byteWidth == 1 {
-					emit("return %s(tb[0]),t", retType)
+					emit("return %s(st.tb[0]),t", retType)
 				} else {
-					emit("return %s(%s.Uint%d(tb)),t", retType, endiannessString(endianness, false), byteWidth*8)
+					emit("return %s(%s.Uint%d(st.tb[:])),t", retType, endiannessString(endianness, false), byteWidth*8)
 				}
 			})
 			emit("}")
@@ -127,18 +373,51 @@ func Compile(book wizparser.Spellbook, output string, chatty bool, emitComments
 		}
 	}
 
-	// sort pages
-	var pages []string
-	for page := range book {
-		pages = append(pages, page)
+	for _, byteWidth := range []byte{1, 2, 4, 8} {
+		for _, endianness := range []wizparser.Endianness{wizparser.LittleEndian, wizparser.BigEndian} {
+			reader := fmt.Sprintf("%d%s", byteWidth, endiannessString(endianness, false))
+
+			emit("// reads an unsigned %d-bit %s integer, from the header window if it holds it", byteWidth*8, endianness)
+			emit("func w%s(st *state, r *wizutil.SliceReader, hw []byte, po int64, off int64) (uint64, bool) {", reader)
+			withIndent(func() {
+				emit("i:=off-po")
+				// near the end of the target, the window is short
+				emit("if i<0||i+%d>int64(len(hw)) {return f%s(st,r,off)}", byteWidth, reader)
+				if byteWidth == 1 {
+					emit("return uint64(hw[i]),t")
+				} else {
+					emit("return uint64(%s.Uint%d(hw[i:])),t", endiannessString(endianness, false), byteWidth*8)
+				}
+			})
+			emit("}")
+			emit("")
+		}
 	}
-	sort.Strings(pages)
 
-	usages := computePagesUsage(book)
+	for _, endianness := range []wizparser.Endianness{wizparser.LittleEndian, wizparser.BigEndian} {
+		emit("// reads a 32-bit %s ID3 synchsafe integer", endianness)
+		emit("func f4i%s(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {", endiannessString(endianness, false))
+		withIndent(func() {
+			emit("v,k:=f4%s(st,r,off)", endiannessString(endianness, false))
+			emit("return wizardry.DecodeID3(v),k")
+		})
+		emit("}")
+		emit("")
+	}
+
+	emit("// reads a 32-bit middle-endian integer")
+	emit("func f4m(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {")
+	withIndent(func() {
+		emit("n,err:=r.ReadAt(st.tb[:4],int64(off))")
+		emit("if n<4||err!=nil {return 0,f}")
+		emit("return uint64(wizardry.MiddleEndianUint32(st.tb[:])),t")
+	})
+	emit("}")
+	emit("")
 
 	for _, page := range pages {
-		nodes := treeify(book[page])
 		usage := usages[page]
+		nodes := treeify(book[page])
 
 		for _, swapEndian := range []bool{false, true} {
 			defaultSeed := 0
@@ -153,19 +432,65 @@ func Compile(book wizparser.Spellbook, output string, chatty bool, emitComments
 				}
 			}
 
-			emit("func Identify%s(r *wizutil.SliceReader, po int64) []string {", pageSymbol(page, swapEndian))
+			for _, node := range nodes {
+				switchify(node)
+			}
+
+			window := headerWindow(nodes)
+
+			// integerReader returns an expression that reads an integer
+			// at off, which ends at end relative to the page offset if
+			// that's known statically
+			integerReader := func(byteWidth int, endianness wizparser.Endianness, off string, end int64) string {
+				reader := fmt.Sprintf("%d%s", byteWidth, endiannessString(endianness, swapEndian))
+				if end > 0 && end <= window {
+					return fmt.Sprintf("w%s(st,r,hw,po,%s)", reader, off)
+				}
+				return fmt.Sprintf("f%s(st,r,%s)", reader, off)
+			}
+
+			var groups []*dispatchGroup
+			if linear {
+				groups = []*dispatchGroup{{nodes: nodes}}
+			} else {
+				groups = dispatchify(nodes, swapEndian)
+			}
+
+			stats.Pages++
+			emit("func identify%s(st *state, r *wizutil.SliceReader, po int64, fd wizardry.Fields) []string {", pageSymbol(page, swapEndian))
 			withIndent(func() {
 				emit("var out []string")
 				emit("var ss []string; ss=ss[0:]")
-				emit("var gf int64; gf&=gf") // globalOffset
+				emit("var gf [32]int64; gf[0]=po") // where the last match ended, per level
 				emit("var ra uint64; ra&=ra")
 				emit("var rb uint64; rb&=rb")
 				emit("var rc uint64; rc&=rc")
 				emit("var rA int64; rA&=rA")
+				emit("var rB int64; rB&=rB")
+				emit("var sv string; sv+=\"\"")
+				emit("var sb []byte; sb=sb[0:]")
+				emit("var sm [32]uint64; sm[0]&=sm[0]") // string switch cases that may match, per level
 				emit("var k bool; k=!!k")
 				emit("var l bool; l=!!l")
 				emit("var m bool; m=!!m")
 				emit("var d=make([]bool, 32); d[0]=!!d[0]")
+				if usesTextFlags(book[page]) {
+					emit("var tx *wizardry.TextInfo")
+				}
+				if usesCustomKinds(book[page]) {
+					emit("var cr wizardry.CustomResult")
+				}
+				if window > 0 {
+					// the header window, where most integers are read from
+					emit("var hw=gp(r,po,%d)", window)
+				}
+				for _, group := range groups {
+					if group.cases != nil {
+						// the byte keyed top-level rules are dispatched on
+						emit("var hb uint64; hb,_=%s", integerReader(1, wizparser.LittleEndian, "po", 1))
+						break
+					}
+				}
 				emit("")
 
 				emit("a:=func (args... string) {")
@@ -178,6 +503,7 @@ func Compile(book wizparser.Spellbook, output string, chatty bool, emitComments
 
 				emitNode = func(node *ruleNode, defaultMarker string, prevSiblingNode *ruleNode) {
 					rule := node.rule
+					stats.Rules++
 
 					canFail := false
 
@@ -208,20 +534,27 @@ func Compile(book wizparser.Spellbook, output string, chatty bool, emitComments
 						reuseOffset = pr.Offset.Equals(rule.Offset)
 					}
 
+					// relative offsets are counted from the end of the
+					// parent's match, or from the page offset at the top
+					parentOffset := &VariableAccess{"po"}
+					if rule.Level > 0 {
+						parentOffset = &VariableAccess{fmt.Sprintf("gf[%d]", rule.Level-1)}
+					}
+
 					switch rule.Offset.OffsetType {
 					case wizparser.OffsetTypeDirect:
+						base := "po"
+						if rule.Offset.IsRelative {
+							base = parentOffset.Name
+						} else if rule.Offset.Direct < 0 {
+							// relative to the end of the target
+							base = "r.Size()"
+						}
 						off = &BinaryOp{
-							LHS:      &VariableAccess{"po"},
+							LHS:      &VariableAccess{base},
 							Operator: OperatorAdd,
 							RHS:      &NumberLiteral{rule.Offset.Direct},
 						}
-						if rule.Offset.IsRelative {
-							off = &BinaryOp{
-								LHS:      off,
-								Operator: OperatorAdd,
-								RHS:      &VariableAccess{"gf"},
-							}
-						}
 					case wizparser.OffsetTypeIndirect:
 						indirect := rule.Offset.Indirect
 
@@ -230,15 +563,24 @@ func Compile(book wizparser.Spellbook, output string, chatty bool, emitComments
 							offsetAddress = &BinaryOp{
 								LHS:      offsetAddress,
 								Operator: OperatorAdd,
-								RHS:      &VariableAccess{"gf"},
+								RHS:      parentOffset,
+							}
+						} else if indirect.OffsetAddress < 0 {
+							// relative to the end of the target
+							offsetAddress = &BinaryOp{
+								LHS:      &VariableAccess{"r.Size()"},
+								Operator: OperatorAdd,
+								RHS:      offsetAddress,
 							}
 						}
 
 						if !reuseOffset {
-							emit("ra,k=f%d%s(r,%s)",
-								indirect.ByteWidth,
-								endiannessString(indirect.Endianness, swapEndian),
-								offsetAddress)
+							if windowedIndirect(indirect) {
+								end := indirect.OffsetAddress + int64(indirect.ByteWidth)
+								emit("ra,k=%s", integerReader(indirect.ByteWidth, indirect.Endianness, offsetAddress.String(), end))
+							} else {
+								emit("ra,k=%s(st,r,%s)", indirectReader(indirect, swapEndian), offsetAddress)
+							}
 						}
 						canFail = true
 						emit("if !k {goto %s}", failLabel(node))
@@ -246,39 +588,20 @@ func Compile(book wizparser.Spellbook, output string, chatty bool, emitComments
 
 						if indirect.OffsetAdjustmentIsRelative {
 							offsetAdjustAddress := fmt.Sprintf("%s + %s", offsetAddress, quoteNumber(indirect.OffsetAdjustmentValue))
-							emit("rb,l=f%d%s(r,%s)",
-								indirect.ByteWidth,
-								endiannessString(indirect.Endianness, swapEndian),
-								offsetAdjustAddress)
+							emit("rb,l=%s(st,r,%s)", indirectReader(indirect, swapEndian), offsetAdjustAddress)
 							emit("if !l {goto %s}", failLabel(node))
+							if !indirect.OffsetAdjustmentType.CanApply(0) {
+								emit("if rb==0 {goto %s}", failLabel(node))
+							}
 							offsetAdjustValue = &VariableAccess{"int64(rb)"}
 						}
 
 						off = &VariableAccess{"int64(ra)"}
 
-						switch indirect.OffsetAdjustmentType {
-						case wizparser.AdjustmentAdd:
-							off = &BinaryOp{
-								LHS:      off,
-								Operator: OperatorAdd,
-								RHS:      offsetAdjustValue,
-							}
-						case wizparser.AdjustmentSub:
-							off = &BinaryOp{
-								LHS:      off,
-								Operator: OperatorSub,
-								RHS:      offsetAdjustValue,
-							}
-						case wizparser.AdjustmentMul:
+						if indirect.OffsetAdjustmentType != wizparser.AdjustmentNone {
 							off = &BinaryOp{
 								LHS:      off,
-								Operator: OperatorMul,
-								RHS:      offsetAdjustValue,
-							}
-						case wizparser.AdjustmentDiv:
-							off = &BinaryOp{
-								LHS:      off,
-								Operator: OperatorDiv,
+								Operator: adjustmentOperator(indirect.OffsetAdjustmentType),
 								RHS:      offsetAdjustValue,
 							}
 						}
@@ -287,28 +610,39 @@ func Compile(book wizparser.Spellbook, output string, chatty bool, emitComments
 							off = &BinaryOp{
 								LHS:      off,
 								Operator: OperatorAdd,
-								RHS:      &VariableAccess{"gf"},
+								RHS:      parentOffset,
 							}
 						}
 					}
 
 					off = off.Fold()
 
+					// children with relative offsets need to know where this rule's match ends
+					globalOffsetSet := false
+					setGlobalOffset := func(value Expression) {
+						emit("gf[%d]=%s", rule.Level, value.Fold())
+						globalOffsetSet = true
+					}
+
+					// expression holding the value the rule read, if its
+					// description needs to print it
+					descValue := ""
+					// expression holding strings to print after the description
+					trailing := ""
+
 					switch rule.Kind.Family {
 					case wizparser.KindFamilySwitch:
 						sk, _ := rule.Kind.Data.(*wizparser.SwitchKind)
 
-						emit("rc,m=f%d%s(r,%s)",
-							sk.ByteWidth,
-							endiannessString(sk.Endianness, swapEndian),
-							off,
-						)
+						emit("rc,m=%s", integerReader(sk.ByteWidth, sk.Endianness, off.String(), staticEnd(rule, sk.ByteWidth)))
 
 						canFail = true
 						emit("switch rc {")
 						withIndent(func() {
 							for _, c := range sk.Cases {
-								emit("case %d: a(%s)", c.Value, strconv.Quote(string(c.Description)))
+								// the value read is the case value, so we can format now
+								description := wizardry.FormatDescription(string(c.Description), c.Value)
+								emit("case %d: a(%s)", c.Value, strconv.Quote(description))
 							}
 							emit("default: {goto %s}", failLabel(node))
 						})
@@ -317,112 +651,297 @@ func Compile(book wizparser.Spellbook, output string, chatty bool, emitComments
 					case wizparser.KindFamilyInteger:
 						ik, _ := rule.Kind.Data.(*wizparser.IntegerKind)
 
-						if !ik.MatchAny {
+						if !ik.MatchAny || rule.NeedsValue() {
 							reuseSibling := false
 							if prevSiblingNode != nil {
 								pr := prevSiblingNode.rule
 								if pr.Offset.Equals(rule.Offset) && pr.Kind.Family == wizparser.KindFamilyInteger {
 									pik, _ := pr.Kind.Data.(*wizparser.IntegerKind)
-									if pik.ByteWidth == ik.ByteWidth {
+									if pik.ByteWidth == ik.ByteWidth && pik.Endianness == ik.Endianness && (!pik.MatchAny || pr.NeedsValue()) {
 										reuseSibling = true
 									}
 								}
 							}
 
 							if !reuseSibling {
-								emit("rc,m=f%d%s(r,%s)",
-									ik.ByteWidth,
-									endiannessString(ik.Endianness, swapEndian),
-									off,
-								)
+								emit("rc,m=%s", integerReader(ik.ByteWidth, ik.Endianness, off.String(), staticEnd(rule, ik.ByteWidth)))
 							}
 
-							lhs := "rc"
-
-							operator := "=="
-							switch ik.IntegerTest {
-							case wizparser.IntegerTestEqual:
-								operator = "=="
-							case wizparser.IntegerTestNotEqual:
-								operator = "!="
-							case wizparser.IntegerTestLessThan:
-								operator = "< "
-							case wizparser.IntegerTestGreaterThan:
-								operator = ">"
+							canFail = true
+							if ik.MatchAny {
+								emit("if !m {goto %s}", failLabel(node))
+							} else {
+								lhs := adjustedExpr("rc", ik)
+
+								if ik.Signed && (ik.IntegerTest == wizparser.IntegerTestGreaterThan || ik.IntegerTest == wizparser.IntegerTestLessThan) {
+									lhs = fmt.Sprintf("int64(int%d(%s))", ik.ByteWidth*8, lhs)
+								}
+
+								ruleTest := fmt.Sprintf("m&&%s", integerTestExpr(lhs, ik))
+								emit("if !(%s) {goto %s}", ruleTest, failLabel(node))
 							}
 
-							if ik.Signed && (ik.IntegerTest == wizparser.IntegerTestGreaterThan || ik.IntegerTest == wizparser.IntegerTestLessThan) {
-								lhs = fmt.Sprintf("int64(int%d(%s))", ik.ByteWidth*8, lhs)
+							descValue = adjustedExpr("rc", ik)
+							if ik.Signed {
+								descValue = fmt.Sprintf("int64(int%d(%s))", ik.ByteWidth*8, descValue)
 							}
+						}
+						if emitGlobalOffset {
+							gfValue := &BinaryOp{
+								LHS:      off,
+								Operator: OperatorAdd,
+								RHS:      &NumberLiteral{int64(ik.ByteWidth)},
+							}
+							setGlobalOffset(gfValue)
+						}
+
+					case wizparser.KindFamilyOffset:
+						ik, _ := rule.Kind.Data.(*wizparser.IntegerKind)
 
-							if ik.DoAnd {
-								lhs = fmt.Sprintf("%s&%s", lhs, quoteNumber(int64(ik.AndValue)))
+						lhs := adjustedExpr(fmt.Sprintf("uint64(%s)", off), ik)
+						if !ik.MatchAny {
+							canFail = true
+							emit("if !(%s) {goto %s}", integerTestExpr(lhs, ik), failLabel(node))
+						}
+						descValue = lhs
+						if emitGlobalOffset {
+							setGlobalOffset(off)
+						}
+
+					case wizparser.KindFamilyStringSwitch:
+						sk, _ := rule.Kind.Data.(*wizparser.StringSwitchKind)
+						trie := newPrefixTrie(sk)
+
+						emit("sb=gp(r,%s,%d)", off, trie.maxLen())
+						emit("sm[%d]=0", rule.Level)
+
+						var emitTrie func(pt *prefixTrie, depth int)
+						emitTrie = func(pt *prefixTrie, depth int) {
+							if pt.mask != 0 {
+								emit("sm[%d]|=%#x", rule.Level, pt.mask)
 							}
 
-							switch ik.AdjustmentType {
-							case wizparser.AdjustmentAdd:
-								lhs = fmt.Sprintf("(%s+%s)", lhs, quoteNumber(ik.AdjustmentValue))
-							case wizparser.AdjustmentSub:
-								lhs = fmt.Sprintf("(%s-%s)", lhs, quoteNumber(ik.AdjustmentValue))
-							case wizparser.AdjustmentMul:
-								lhs = fmt.Sprintf("(%s*%s)", lhs, quoteNumber(ik.AdjustmentValue))
-							case wizparser.AdjustmentDiv:
-								lhs = fmt.Sprintf("(%s/%s)", lhs, quoteNumber(ik.AdjustmentValue))
+							switch len(pt.children) {
+							case 0:
+								return
+							case 1:
+								chain, next := pt.chain()
+								end := depth + len(chain)
+								if len(chain) == 1 {
+									emit("if len(sb)>%d&&sb[%d]==%d {", depth, depth, chain[0])
+								} else {
+									emit("if len(sb)>=%d&&string(sb[%d:%d])==%s {", end, depth, end, strconv.Quote(string(chain)))
+								}
+								withIndent(func() {
+									emitTrie(next, end)
+								})
+								emit("}")
+							default:
+								emit("if len(sb)>%d {", depth)
+								withIndent(func() {
+									emit("switch sb[%d] {", depth)
+									for _, key := range pt.keys() {
+										emit("case %d:", key)
+										child := pt.children[key]
+										withIndent(func() {
+											emitTrie(child, depth+1)
+										})
+									}
+									emit("}")
+								})
+								emit("}")
 							}
+						}
+						emitTrie(trie, 0)
+
+						// cases are tested in order, like the rules they were
+						// generated from
+						var prevCase = node
+						for _, c := range node.cases {
+							emitNode(c, defaultMarker, prevCase)
+							if page == "" && rule.Level == 0 {
+								emit("if len(out)>0 {return out}")
+							}
+							prevCase = c
+						}
 
-							rhs := quoteNumber(ik.Value)
+					case wizparser.KindFamilyString:
+						sk, _ := rule.Kind.Data.(*wizparser.StringKind)
+						if node.switchBit != 0 {
+							canFail = true
+							emit("if sm[%d]&%#x==0 {goto %s}", rule.Level, node.switchBit, failLabel(node))
+						}
+						if check := textCheck(sk.Flags); check != "" {
+							canFail = true
+							emit("if %s {goto %s}", check, failLabel(node))
+						}
+						updatesOffset := emitGlobalOffset && sk.Flags&wizardry.NoOffsetUpdate == 0
+						// the string read from the target is needed to print it,
+						// and to know where lexical comparisons end
+						readsValue := sk.MatchAny || rule.NeedsValue() ||
+							(updatesOffset && sk.Operator != wizardry.StringEqual)
+						if readsValue {
+							emit("sv=gs(r,%s,%d)", off, wizardry.MaxStringLen)
+							descValue = fmt.Sprintf("wizardry.StringTestFlags(%d).Printable(sv)", sk.Flags)
+						}
 
-							ruleTest := fmt.Sprintf("m&&%s%s%s", lhs, operator, rhs)
+						var matchLen Expression = &VariableAccess{"rA"}
+						if sk.MatchAny {
+							matchLen = &VariableAccess{"int64(len(sv))"}
+						} else {
 							canFail = true
-							emit("if !(%s) {goto %s}", ruleTest, failLabel(node))
+							emit("rA = gt(r,%s,%s,%d,%d)", off, strconv.Quote(string(sk.Value)), sk.Flags, sk.Operator)
+							if sk.Negate {
+								emit("if rA>=0 {goto %s}", failLabel(node))
+							} else {
+								emit("if rA<0 {goto %s}", failLabel(node))
+							}
+							if sk.Operator != wizardry.StringEqual {
+								matchLen = &VariableAccess{"int64(len(sv))"}
+							}
 						}
-						if emitGlobalOffset {
+						if updatesOffset && !sk.Negate {
 							gfValue := &BinaryOp{
 								LHS:      off,
 								Operator: OperatorAdd,
-								RHS:      &NumberLiteral{int64(ik.ByteWidth)},
+								RHS:      matchLen,
 							}
-							emit("gf=%s", gfValue.Fold())
+							setGlobalOffset(gfValue)
+						} else if emitGlobalOffset && !sk.Negate {
+							setGlobalOffset(off)
 						}
-					case wizparser.KindFamilyString:
-						sk, _ := rule.Kind.Data.(*wizparser.StringKind)
-						emit("rA = gt(r,%s,%s,%d)", off, strconv.Quote(string(sk.Value)), sk.Flags)
-						canFail = true
-						if sk.Negate {
-							emit("if rA>=0 {goto %s}", failLabel(node))
+
+					case wizparser.KindFamilyString16:
+						sk, _ := rule.Kind.Data.(*wizparser.String16Kind)
+						bigEndian := sk.Endianness.MaybeSwapped(swapEndian) == wizparser.BigEndian
+
+						if sk.MatchAny {
+							emit("sv,rA=gv(r,%s,%d,%t)", off, wizardry.MaxStringLen, bigEndian)
 						} else {
-							emit("if rA<0 {goto %s}", failLabel(node))
+							if rule.NeedsValue() {
+								emit("sv,_=gv(r,%s,%d,%t)", off, wizardry.MaxStringLen, bigEndian)
+							}
+							emit("rA=gu(r,%s,%s,%t)", off, strconv.Quote(string(sk.Value)), bigEndian)
+							canFail = true
+							if sk.Negate {
+								emit("if rA>=0 {goto %s}", failLabel(node))
+							} else {
+								emit("if rA<0 {goto %s}", failLabel(node))
+							}
 						}
-						if emitGlobalOffset {
+						descValue = "sv"
+						if emitGlobalOffset && !sk.Negate {
 							gfValue := &BinaryOp{
 								LHS:      off,
 								Operator: OperatorAdd,
 								RHS:      &VariableAccess{"rA"},
 							}
-							emit("gf=%s", gfValue.Fold())
+							setGlobalOffset(gfValue)
 						}
 
 					case wizparser.KindFamilySearch:
 						sk, _ := rule.Kind.Data.(*wizparser.SearchKind)
-						emit("rA=ht(r,%s,%s,%s)", off, quoteNumber(int64(sk.MaxLen)), strconv.Quote(string(sk.Value)))
+						if check := textCheck(sk.Flags); check != "" {
+							emit("if %s {goto %s}", check, failLabel(node))
+						}
+						emit("rA,rB=ht(r,%s,%s,%s,%d)", off, quoteNumber(int64(sk.MaxLen)), strconv.Quote(string(sk.Value)), sk.Flags)
 						canFail = true
 						emit("if rA<0 {goto %s}", failLabel(node))
 						if emitGlobalOffset {
-							gfValue := &BinaryOp{
+							var gfValue Expression = &BinaryOp{
 								LHS:      off,
 								Operator: OperatorAdd,
-								RHS: &BinaryOp{
-									LHS:      &VariableAccess{"rA"},
+								RHS:      &VariableAccess{"rA"},
+							}
+							if sk.Flags&wizardry.NoOffsetUpdate == 0 {
+								gfValue = &BinaryOp{
+									LHS:      gfValue,
 									Operator: OperatorAdd,
-									RHS:      &NumberLiteral{int64(len(sk.Value))},
-								},
+									RHS:      &VariableAccess{"rB"},
+								}
 							}
-							emit("gf=%s", gfValue.Fold())
+							setGlobalOffset(gfValue)
+						}
+
+					case wizparser.KindFamilyGUID:
+						gk, _ := rule.Kind.Data.(*wizparser.GUIDKind)
+						canFail = true
+						emit("sv,k=gg(r,%s)", off)
+						if gk.MatchAny {
+							emit("if !k {goto %s}", failLabel(node))
+						} else {
+							emit("if !k||sv!=%s {goto %s}", strconv.Quote(wizardry.FormatGUID(gk.Value)), failLabel(node))
+						}
+						descValue = "sv"
+						if emitGlobalOffset {
+							gfValue := &BinaryOp{
+								LHS:      off,
+								Operator: OperatorAdd,
+								RHS:      &NumberLiteral{wizardry.GUIDSize},
+							}
+							setGlobalOffset(gfValue)
+						}
+
+					case wizparser.KindFamilyDER:
+						dk, _ := rule.Kind.Data.(*wizparser.DERKind)
+						canFail = true
+						emit("rA,sv=gd(r,%s,%s)", off, strconv.Quote(dk.Test))
+						emit("if rA<0 {goto %s}", failLabel(node))
+						descValue = "sv"
+						if emitGlobalOffset {
+							// relative rules that follow look inside the element
+							gfValue := &BinaryOp{
+								LHS:      off,
+								Operator: OperatorAdd,
+								RHS:      &VariableAccess{"rA"},
+							}
+							setGlobalOffset(gfValue)
+						}
+
+					case wizparser.KindFamilyCustom:
+						ck, _ := rule.Kind.Data.(*wizparser.CustomKind)
+						canFail = true
+						emit("cr=%s", ck.Definition.Compile(ck.Data, "r", off.String()))
+						emit("if !cr.Matched {goto %s}", failLabel(node))
+						descValue = "cr.Value"
+						if emitGlobalOffset {
+							gfValue := &BinaryOp{
+								LHS:      off,
+								Operator: OperatorAdd,
+								RHS:      &VariableAccess{"cr.Length"},
+							}
+							setGlobalOffset(gfValue)
 						}
 
 					case wizparser.KindFamilyUse:
 						uk, _ := rule.Kind.Data.(*wizparser.UseKind)
-						emit("a(Identify%s(r,%s)...)", pageSymbol(uk.Page, uk.SwapEndian), off)
+						canFail = true
+						// swapping twice gets us back to the original endianness
+						emit("if !st.gw.EnterUse() {goto %s}", failLabel(node))
+						emit("ss=identify%s(st,r,%s,fd); st.gw.ExitUse()", pageSymbol(uk.Page, uk.SwapEndian != swapEndian), off)
+						// a use only matches if its page did, see the interpreter
+						emit("if len(ss)==0 {goto %s}", failLabel(node))
+						// the page's output comes before the description
+						emit("a(ss...)")
+
+					case wizparser.KindFamilyIndirect:
+						ik, _ := rule.Kind.Data.(*wizparser.IndirectKind)
+						if ik.Relative && rule.Offset.OffsetType == wizparser.OffsetTypeIndirect {
+							// direct offsets already include the page offset
+							off = (&BinaryOp{
+								LHS:      off,
+								Operator: OperatorAdd,
+								RHS:      &VariableAccess{"po"},
+							}).Fold()
+						}
+
+						canFail = true
+						// identifying from the very same offset would never end
+						emit("if st.ic>=%d||%s<=0||%s>=r.Size() {goto %s}", wizardry.MaxIndirections, off, off, failLabel(node))
+						emit("st.ic++; ss=identify%s(st,r.Slice(%s),0,fd); st.ic--", pageSymbol("", false), off)
+						emit("if len(ss)==0 {goto %s}", failLabel(node))
+						descValue = off.String()
+						trailing = "ss"
 
 					case wizparser.KindFamilyName:
 						// do nothing, pretty much
@@ -443,7 +962,7 @@ func Compile(book wizparser.Spellbook, output string, chatty bool, emitComments
 						canFail = true
 						emit("if %s {goto %s}", defaultMarker, failLabel(node))
 						if emitGlobalOffset {
-							emit("gf=%s", off)
+							setGlobalOffset(off)
 						}
 
 					default:
@@ -452,11 +971,39 @@ func Compile(book wizparser.Spellbook, output string, chatty bool, emitComments
 						emit("goto %s", failLabel(node))
 					}
 
-					if chatty {
+					if emitGlobalOffset && !globalOffsetSet {
+						// the match ends where it starts
+						setGlobalOffset(off)
+					}
+
+					// string switch cases take care of the rest themselves
+					switchesStrings := rule.Kind.Family == wizparser.KindFamilyStringSwitch
+
+					if chatty && !switchesStrings {
 						emit("fmt.Printf(\"%%s\\n\", %s)", strconv.Quote(rule.Line))
 					}
 					if len(rule.Description) > 0 {
-						emit("a(%s)", strconv.Quote(string(rule.Description)))
+						if descValue != "" && hasFormat(rule.Description) {
+							emit("a(wizardry.FormatDescription(%s,%s))", strconv.Quote(string(rule.Description)), descValue)
+						} else {
+							emit("a(%s)", strconv.Quote(string(rule.Description)))
+						}
+					}
+					if trailing != "" {
+						emit("a(%s...)", trailing)
+					}
+					for _, capture := range rule.Fields {
+						if value := fieldExpr(capture, rule.Description, descValue); value != "" {
+							emit("fd.Add(%s,%s)", strconv.Quote(capture.Name), value)
+						}
+					}
+					if emitMIME {
+						if rule.MIME != "" {
+							emit("fd.Add(%s,%s)", strconv.Quote(wizardry.MIMEField), strconv.Quote(rule.MIME))
+						}
+						for _, ext := range rule.Extensions {
+							emit("fd.Add(%s,%s)", strconv.Quote(wizardry.ExtensionField), strconv.Quote(ext))
+						}
 					}
 
 					numChildren := len(node.children)
@@ -479,7 +1026,7 @@ func Compile(book wizparser.Spellbook, output string, chatty bool, emitComments
 						}
 					}
 
-					if defaultMarker != "" {
+					if defaultMarker != "" && !switchesStrings {
 						emit("%s=t", defaultMarker)
 					}
 
@@ -488,10 +1035,31 @@ func Compile(book wizparser.Spellbook, output string, chatty bool, emitComments
 					}
 				}
 
-				for _, node := range nodes {
-					switchify(node)
+				emitTopNodes := func(nodes []*ruleNode) {
+					for _, node := range stringSwitchify(nodes) {
+						emitNode(node, "", nil)
+						if page == "" && node.rule.Kind.Family != wizparser.KindFamilyStringSwitch {
+							// the first top-level rule that prints something wins
+							emit("if len(out)>0 {return out}")
+						}
+					}
+				}
+
+				for _, group := range groups {
+					if group.cases == nil {
+						emitTopNodes(group.nodes)
+						continue
+					}
 
-					emitNode(node, "", nil)
+					emit("switch hb {")
+					for _, key := range group.keys {
+						emit("case 0x%02x:", key)
+						withIndent(func() {
+							stats.Dispatched += len(group.cases[key])
+							emitTopNodes(group.cases[key])
+						})
+					}
+					emit("}")
 				}
 
 				emit("return out")
@@ -502,12 +1070,212 @@ func Compile(book wizparser.Spellbook, output string, chatty bool, emitComments
 
 	}
 
-	fmt.Printf("Compiled in %s\n", time.Since(startTime))
+	formatted, err := format.Source(f.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "formatting generated code")
+	}
+
+	n, err := w.Write(formatted)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 
-	fSize, _ := f.Seek(0, os.SEEK_CUR)
-	fmt.Printf("Generated code is %.2f KiB\n", float64(fSize)/1024.0)
+	stats.Size = int64(n)
+	stats.Duration = time.Since(startTime)
+	return stats, nil
+}
 
-	return nil
+// mimeTable maps the MIME types of the rules of the compiled pages to
+// the extensions given along with them, sorted
+func mimeTable(book wizparser.Spellbook) map[string][]string {
+	table := make(map[string][]string)
+	for _, rules := range book {
+		for _, rule := range rules {
+			if rule.MIME == "" {
+				continue
+			}
+			exts := table[rule.MIME]
+			for _, ext := range rule.Extensions {
+				found := false
+				for _, e := range exts {
+					if e == ext {
+						found = true
+						break
+					}
+				}
+				if !found {
+					exts = append(exts, ext)
+				}
+			}
+			sort.Strings(exts)
+			table[rule.MIME] = exts
+		}
+	}
+	return table
+}
+
+// textCheck returns a go expression that is true if the "t" or "b" flags
+// rule out the target, or an empty string if there are no such flags
+func textCheck(flags wizardry.StringTestFlags) string {
+	if flags&wizardry.ForceText > 0 {
+		return "!ix(st,r,&tx)"
+	}
+	if flags&wizardry.ForceBinary > 0 {
+		return "ix(st,r,&tx)"
+	}
+	return ""
+}
+
+// integerTestExpr returns a go expression comparing lhs to an integer kind's magic value
+func integerTestExpr(lhs string, ik *wizparser.IntegerKind) string {
+	rhs := quoteNumber(ik.Value)
+
+	switch ik.IntegerTest {
+	case wizparser.IntegerTestNotEqual:
+		return fmt.Sprintf("%s!=%s", lhs, rhs)
+	case wizparser.IntegerTestLessThan:
+		return fmt.Sprintf("%s< %s", lhs, rhs)
+	case wizparser.IntegerTestGreaterThan:
+		return fmt.Sprintf("%s>%s", lhs, rhs)
+	case wizparser.IntegerTestAnd:
+		return fmt.Sprintf("%s&%s==%s", lhs, rhs, rhs)
+	case wizparser.IntegerTestBitsClear:
+		return fmt.Sprintf("%s&%s!=%s", lhs, rhs, rhs)
+	default:
+		return fmt.Sprintf("%s==%s", lhs, rhs)
+	}
+}
+
+// adjustedExpr applies an integer kind's mask and arithmetic to an expression
+func adjustedExpr(lhs string, ik *wizparser.IntegerKind) string {
+	if ik.DoAnd {
+		lhs = fmt.Sprintf("%s&%s", lhs, quoteNumber(int64(ik.AndValue)))
+	}
+
+	if ik.AdjustmentType != wizparser.AdjustmentNone {
+		// go has the same operators as magic files
+		lhs = fmt.Sprintf("(%s%s%s)", lhs, ik.AdjustmentType, quoteNumber(ik.AdjustmentValue))
+	}
+
+	if ik.Invert {
+		lhs = fmt.Sprintf("(^(%s))", lhs)
+		if ik.ByteWidth < 8 {
+			lhs = fmt.Sprintf("(%s&%s)", lhs, quoteNumber((1<<uint(ik.ByteWidth*8))-1))
+		}
+	}
+
+	return lhs
+}
+
+// adjustmentOperator returns the folder operator for an offset adjustment
+func adjustmentOperator(adj wizparser.Adjustment) Operator {
+	switch adj {
+	case wizparser.AdjustmentSub:
+		return OperatorSub
+	case wizparser.AdjustmentMul:
+		return OperatorMul
+	case wizparser.AdjustmentDiv:
+		return OperatorDiv
+	case wizparser.AdjustmentMod:
+		return OperatorMod
+	case wizparser.AdjustmentAnd:
+		return OperatorBinaryAnd
+	case wizparser.AdjustmentOr:
+		return OperatorBinaryOr
+	case wizparser.AdjustmentXor:
+		return OperatorBinaryXor
+	default:
+		return OperatorAdd
+	}
+}
+
+// hasFormat returns true if a description has printf-style verbs
+func hasFormat(description []byte) bool {
+	return bytes.IndexByte(description, '%') >= 0
+}
+
+// usesTextFlags returns true if any rule in a page is restricted to
+// text or binary targets
+func usesTextFlags(rules []wizparser.Rule) bool {
+	for _, rule := range rules {
+		var flags wizardry.StringTestFlags
+		switch rule.Kind.Family {
+		case wizparser.KindFamilyString:
+			sk, _ := rule.Kind.Data.(*wizparser.StringKind)
+			flags = sk.Flags
+		case wizparser.KindFamilySearch:
+			sk, _ := rule.Kind.Data.(*wizparser.SearchKind)
+			flags = sk.Flags
+		}
+		if flags&(wizardry.ForceText|wizardry.ForceBinary) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldExpr returns an expression for the value a field capture records,
+// see wizparser.FieldCapture, or "" if there's nothing to record
+func fieldExpr(capture wizparser.FieldCapture, description []byte, descValue string) string {
+	switch {
+	case len(capture.Format) > 0:
+		if descValue != "" && hasFormat(capture.Format) {
+			return fmt.Sprintf("wizardry.FormatDescription(%s,%s)", strconv.Quote(string(capture.Format)), descValue)
+		}
+		return strconv.Quote(string(capture.Format))
+	case len(description) > 0:
+		if descValue != "" && hasFormat(description) {
+			return fmt.Sprintf("wizardry.FieldValue(wizardry.FormatDescription(%s,%s))", strconv.Quote(string(description)), descValue)
+		}
+		return strconv.Quote(wizardry.FieldValue(string(description)))
+	case descValue != "":
+		return fmt.Sprintf("fmt.Sprint(%s)", descValue)
+	}
+	return ""
+}
+
+func usesCustomKinds(rules []wizparser.Rule) bool {
+	for _, rule := range rules {
+		if rule.Kind.Family == wizparser.KindFamilyCustom {
+			return true
+		}
+	}
+	return false
+}
+
+// customKindImports lists the packages needed by the custom kinds used in
+// the book, and makes sure they can all be compiled
+func customKindImports(book wizparser.Spellbook) ([]string, error) {
+	seen := make(map[string]bool)
+	// already imported by every generated file
+	seen["fmt"] = true
+	seen["encoding/binary"] = true
+	seen["github.com/itchio/wizardry/wizardry"] = true
+	seen["github.com/itchio/wizardry/wizardry/wizutil"] = true
+
+	var imports []string
+	for _, rules := range book {
+		for _, rule := range rules {
+			if rule.Kind.Family != wizparser.KindFamilyCustom {
+				continue
+			}
+
+			ck, _ := rule.Kind.Data.(*wizparser.CustomKind)
+			if ck.Definition.Compile == nil {
+				return nil, fmt.Errorf("custom kind %s can't be compiled, used in rule %s", ck.Definition.Name, rule.Line)
+			}
+
+			for _, imp := range ck.Definition.Imports {
+				if !seen[imp] {
+					seen[imp] = true
+					imports = append(imports, imp)
+				}
+			}
+		}
+	}
+
+	sort.Strings(imports)
+	return imports, nil
 }
 
 func pageSymbol(page string, swapEndian bool) string {
@@ -516,6 +1284,11 @@ func pageSymbol(page string, swapEndian bool) string {
 		result += strings.Title(token)
 	}
 
+	if page == "" {
+		// the "" page is wrapped by Identify
+		result = "__Root"
+	}
+
 	if swapEndian {
 		result += "__Swapped"
 	}
@@ -530,6 +1303,18 @@ func endiannessString(en wizparser.Endianness, swapEndian bool) string {
 	return "l"
 }
 
+// indirectReader returns the name of the generated function that reads an indirect offset
+func indirectReader(indirect *wizparser.IndirectOffset, swapEndian bool) string {
+	switch indirect.Format {
+	case wizparser.IndirectFormatID3:
+		return fmt.Sprintf("f4i%s", endiannessString(indirect.Endianness, swapEndian))
+	case wizparser.IndirectFormatMiddleEndian:
+		return "f4m"
+	default:
+		return fmt.Sprintf("f%d%s", indirect.ByteWidth, endiannessString(indirect.Endianness, swapEndian))
+	}
+}
+
 func quoteNumber(number int64) string {
 	return fmt.Sprintf("%d", number)
 }
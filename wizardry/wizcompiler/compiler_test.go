@@ -0,0 +1,94 @@
+package wizcompiler
+
+import (
+	"bytes"
+	"go/format"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/itchio/wizardry/wizardry/wizparser"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CompileTo(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	name	inner",
+		">0	byte	1	inner",
+		"!:mime	application/x-inner",
+		"!:ext	inr/inn",
+		"",
+		"0	string	ABC	abc",
+		">3	use	inner",
+		">3	use	\\^inner",
+	}, "\n")
+
+	book := make(wizparser.Spellbook)
+	pctx := &wizparser.ParseContext{
+		Logf: func(format string, args ...interface{}) {},
+	}
+	assert.NoError(t, pctx.Parse(strings.NewReader(magic), book))
+
+	var buf bytes.Buffer
+	stats, err := CompileTo(&buf, book, CompileOptions{Package: "magic", EmitComments: true, MIME: true})
+	assert.NoError(t, err)
+
+	code := buf.Bytes()
+	assert.True(t, bytes.HasPrefix(code, []byte("// this file has been generated")))
+	assert.Contains(t, string(code), "package magic\n")
+	assert.Contains(t, string(code), "// >3\tuse\tinner\n")
+
+	// the public API
+	assert.Contains(t, string(code), "func Identify(r io.ReaderAt, size int64) wizardry.Result {")
+	assert.Contains(t, string(code), "\tFingerprint = "+strconv.Quote(book.Fingerprint())+"\n")
+	assert.Contains(t, string(code), "\tEmitComments = true\n")
+	assert.Contains(t, string(code), "\t\t\"inner\",\n")
+	assert.Contains(t, string(code), "\t\"application/x-inner\": {\"inn\", \"inr\"},\n")
+	assert.Contains(t, string(code), "func identifyInner__Swapped(")
+
+	formatted, err := format.Source(code)
+	assert.NoError(t, err)
+	assert.EqualValues(t, string(formatted), string(code), "generated code is gofmt-clean")
+
+	assert.EqualValues(t, len(code), stats.Size)
+	// the root page, and inner both as-is and swapped
+	assert.EqualValues(t, 3, stats.Pages)
+	assert.EqualValues(t, 3+2*2, stats.Rules)
+
+	// invalid books aren't compiled
+	book.AddRule("", wizparser.Rule{Level: 3})
+	buf.Reset()
+	_, err = CompileTo(&buf, book, CompileOptions{})
+	assert.Error(t, err)
+	assert.EqualValues(t, 0, buf.Len())
+}
+
+func Test_HeaderWindow(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	lelong	0x12345678	abc",
+		">8	byte	1	\\b, one",
+		">2000	byte	2	\\b, far",
+		">(4.s)	byte	3	\\b, there",
+		"",
+		"0	belong	0xcafebabe	def",
+	}, "\n")
+
+	book := make(wizparser.Spellbook)
+	pctx := &wizparser.ParseContext{
+		Logf: func(format string, args ...interface{}) {},
+	}
+	assert.NoError(t, pctx.Parse(strings.NewReader(magic), book))
+
+	var buf bytes.Buffer
+	_, err := CompileTo(&buf, book, CompileOptions{Package: "magic"})
+	assert.NoError(t, err)
+
+	code := buf.String()
+	assert.Contains(t, code, "\tvar hw = gp(r, po, 9)\n")
+	assert.Contains(t, code, "rc, m = w4l(st, r, hw, po, po)\n")
+	assert.Contains(t, code, "rc, m = w1l(st, r, hw, po, po+8)\n")
+	assert.Contains(t, code, "ra, k = w2l(st, r, hw, po, 4)\n")
+	assert.Contains(t, code, "rc, m = w4b(st, r, hw, po, po)\n")
+	// too far to be worth reading along with the rest
+	assert.Contains(t, code, "rc, m = f1l(st, r, po+2000)\n")
+}
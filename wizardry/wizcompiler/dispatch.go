@@ -0,0 +1,127 @@
+package wizcompiler
+
+import (
+	"sort"
+
+	"github.com/itchio/wizardry/wizardry"
+	"github.com/itchio/wizardry/wizardry/wizparser"
+	"github.com/itchio/wizardry/wizardry/wizutil"
+)
+
+// minDispatchRun is how many consecutive top-level rules must be keyed by
+// their leading byte before they're worth a switch
+const minDispatchRun = 4
+
+// dispatchGroup is a run of consecutive top-level nodes. Nodes of keyed
+// groups only run if the byte at the page offset is their key: since nodes
+// with different keys can't both match, only the order of nodes that share
+// a key needs to be kept. A target whose first byte isn't a key of any
+// group only pays for reading that byte from the header window and for the
+// switches, then runs the same unkeyed nodes linear code would.
+type dispatchGroup struct {
+	// nodes of unkeyed groups, in order
+	nodes []*ruleNode
+	// keys of keyed groups, sorted
+	keys  []byte
+	cases map[byte][]*ruleNode
+}
+
+// dispatchify splits top-level nodes into groups, see dispatchGroup
+func dispatchify(nodes []*ruleNode, swapEndian bool) []*dispatchGroup {
+	var groups []*dispatchGroup
+	var run []*ruleNode
+
+	unkeyed := func(nodes ...*ruleNode) {
+		if len(nodes) == 0 {
+			return
+		}
+		if len(groups) > 0 && groups[len(groups)-1].cases == nil {
+			last := groups[len(groups)-1]
+			last.nodes = append(last.nodes, nodes...)
+			return
+		}
+		groups = append(groups, &dispatchGroup{nodes: nodes})
+	}
+
+	endRun := func() {
+		if len(run) < minDispatchRun {
+			unkeyed(run...)
+			run = nil
+			return
+		}
+
+		group := &dispatchGroup{cases: make(map[byte][]*ruleNode)}
+		for _, node := range run {
+			key, _ := dispatchByte(node.rule, swapEndian)
+			if _, ok := group.cases[key]; !ok {
+				group.keys = append(group.keys, key)
+			}
+			group.cases[key] = append(group.cases[key], node)
+		}
+		sort.Slice(group.keys, func(i, j int) bool {
+			return group.keys[i] < group.keys[j]
+		})
+		groups = append(groups, group)
+		run = nil
+	}
+
+	for _, node := range nodes {
+		if _, ok := dispatchByte(node.rule, swapEndian); ok {
+			run = append(run, node)
+		} else {
+			endRun()
+			unkeyed(node)
+		}
+	}
+	endRun()
+
+	return groups
+}
+
+// dispatchByte returns the byte a rule requires at the page offset,
+// if it can only match when a given byte is there
+func dispatchByte(rule wizparser.Rule, swapEndian bool) (byte, bool) {
+	if rule.Offset.OffsetType != wizparser.OffsetTypeDirect || rule.Offset.IsRelative || rule.Offset.Direct != 0 {
+		return 0, false
+	}
+
+	switch rule.Kind.Family {
+	case wizparser.KindFamilyString:
+		sk, _ := rule.Kind.Data.(*wizparser.StringKind)
+		if sk.MatchAny || sk.Negate || sk.Operator != wizardry.StringEqual || len(sk.Value) == 0 {
+			return 0, false
+		}
+
+		// flags may let other bytes match the first byte of the pattern
+		first := sk.Value[0]
+		if sk.Flags&wizardry.OptionalBlanks > 0 && wizutil.IsWhitespace(first) {
+			return 0, false
+		}
+		if sk.Flags&wizardry.LowerMatchesBoth > 0 && wizutil.IsLowerLetter(first) {
+			return 0, false
+		}
+		if sk.Flags&wizardry.UpperMatchesBoth > 0 && wizutil.IsUpperLetter(first) {
+			return 0, false
+		}
+		return first, true
+
+	case wizparser.KindFamilyInteger:
+		ik, _ := rule.Kind.Data.(*wizparser.IntegerKind)
+		if ik.MatchAny || ik.IntegerTest != wizparser.IntegerTestEqual || ik.DoAnd || ik.Invert || ik.AdjustmentType != wizparser.AdjustmentNone {
+			return 0, false
+		}
+
+		// values that don't fit never match, leave them be
+		value := uint64(ik.Value)
+		if ik.Value < 0 || (ik.ByteWidth < 8 && value>>uint(ik.ByteWidth*8) != 0) {
+			return 0, false
+		}
+
+		if ik.Endianness.MaybeSwapped(swapEndian) == wizparser.BigEndian {
+			return byte(value >> uint((ik.ByteWidth-1)*8)), true
+		}
+		return byte(value), true
+	}
+
+	return 0, false
+}
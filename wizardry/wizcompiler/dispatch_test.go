@@ -0,0 +1,60 @@
+package wizcompiler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/itchio/wizardry/wizardry/wizparser"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Dispatchify(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	ABC	abc",
+		"0	belong	0x7f454c46	elf, big-endian",
+		"0	lelong	0x44434241	abcd, little-endian",
+		"0	string	\\x7f	delete",
+		"0	string/c	abc	abc, any case",
+		"0	string/c	#!	script",
+		"0	string	AB	ab",
+		"4	string	ABC	abc, later",
+		"0	byte	>1	more than one",
+		"0	short	1	one",
+		"0	string	Z	z",
+		"0	string	A	a",
+		"0	byte	0x7f	delete again",
+	}, "\n")
+
+	book := make(wizparser.Spellbook)
+	pctx := &wizparser.ParseContext{
+		Logf: func(format string, args ...interface{}) {},
+	}
+	assert.NoError(t, pctx.Parse(strings.NewReader(magic), book))
+
+	describe := func(nodes []*ruleNode) []string {
+		var descriptions []string
+		for _, node := range nodes {
+			descriptions = append(descriptions, string(node.rule.Description))
+		}
+		return descriptions
+	}
+
+	groups := dispatchify(treeify(book[""]), false)
+	if assert.Len(t, groups, 3) {
+		assert.EqualValues(t, []byte{'A', 0x7f}, groups[0].keys)
+		assert.EqualValues(t, []string{"abc", "abcd, little-endian"}, describe(groups[0].cases['A']))
+		assert.EqualValues(t, []string{"elf, big-endian", "delete"}, describe(groups[0].cases[0x7f]))
+
+		// case-insensitive letters can't be dispatched on, and the keyed
+		// rules that follow are too few to bother
+		assert.EqualValues(t, []string{"abc, any case", "script", "ab", "abc, later", "more than one"}, describe(groups[1].nodes))
+
+		assert.EqualValues(t, []byte{0x01, 'A', 'Z', 0x7f}, groups[2].keys)
+	}
+
+	// swapped pages read integers the other way around
+	groups = dispatchify(treeify(book[""]), true)
+	assert.EqualValues(t, []byte{'A', 'D', 'F', 0x7f}, groups[0].keys)
+	assert.EqualValues(t, []string{"abcd, little-endian"}, describe(groups[0].cases['D']))
+	assert.EqualValues(t, []string{"elf, big-endian"}, describe(groups[0].cases['F']))
+}
@@ -14,13 +14,16 @@ const (
 	OperatorBinaryAnd
 	OperatorAdd
 	OperatorSub
+	OperatorMod
+	OperatorBinaryOr
+	OperatorBinaryXor
 )
 
 func (op Operator) Precedence() int {
 	switch op {
-	case OperatorMul, OperatorDiv, OperatorBinaryAnd:
+	case OperatorMul, OperatorDiv, OperatorBinaryAnd, OperatorMod:
 		return 5
-	case OperatorAdd, OperatorSub:
+	case OperatorAdd, OperatorSub, OperatorBinaryOr, OperatorBinaryXor:
 		return 4
 	default:
 		return 0
@@ -29,7 +32,7 @@ func (op Operator) Precedence() int {
 
 func (op Operator) IsAssociative() bool {
 	switch op {
-	case OperatorMul, OperatorAdd:
+	case OperatorMul, OperatorAdd, OperatorBinaryAnd, OperatorBinaryOr, OperatorBinaryXor:
 		return true
 	default:
 		return false
@@ -48,6 +51,12 @@ func (op Operator) Evaluate(lhs int64, rhs int64) int64 {
 		return lhs + rhs
 	case OperatorSub:
 		return lhs - rhs
+	case OperatorMod:
+		return lhs % rhs
+	case OperatorBinaryOr:
+		return lhs | rhs
+	case OperatorBinaryXor:
+		return lhs ^ rhs
 	default:
 		return -1
 	}
@@ -65,6 +74,12 @@ func (op Operator) String() string {
 		return "+"
 	case OperatorSub:
 		return "-"
+	case OperatorMod:
+		return "%"
+	case OperatorBinaryOr:
+		return "|"
+	case OperatorBinaryXor:
+		return "^"
 	default:
 		return "?"
 	}
@@ -167,4 +167,28 @@ func Test_Fold(t *testing.T) {
 		assert.EqualValues(t, "x*0", node.String())
 		assert.EqualValues(t, "0", node.Fold().String())
 	}
+	{
+		node := &BinaryOp{
+			LHS: &BinaryOp{
+				LHS:      &VariableAccess{"x"},
+				Operator: OperatorAdd,
+				RHS:      &NumberLiteral{1},
+			},
+			Operator: OperatorMod,
+			RHS:      &NumberLiteral{4},
+		}
+		assert.EqualValues(t, "(x+1)%4", node.String())
+	}
+	{
+		node := &BinaryOp{
+			LHS:      &NumberLiteral{0x0f},
+			Operator: OperatorBinaryOr,
+			RHS: &BinaryOp{
+				LHS:      &NumberLiteral{0xf0},
+				Operator: OperatorBinaryXor,
+				RHS:      &NumberLiteral{0xff},
+			},
+		}
+		assert.EqualValues(t, "15", node.Fold().String())
+	}
 }
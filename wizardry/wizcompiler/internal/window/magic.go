@@ -0,0 +1,397 @@
+// this file has been generated by github.com/itchio/wizardry
+// from a set of magic rules. you probably don't want to edit it by hand
+
+package window
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"github.com/itchio/wizardry/wizardry"
+	"github.com/itchio/wizardry/wizardry/wizutil"
+	"io"
+)
+
+// silence import errors, if we don't use string/search etc.
+var _ wizardry.StringTestFlags
+var _ fmt.State
+var l binary.ByteOrder = binary.LittleEndian
+var b binary.ByteOrder = binary.BigEndian
+var gt = wizardry.StringTestWith
+var ht = wizardry.SearchTestWith
+var gu = wizardry.String16Test
+var gv = wizardry.ReadString16
+var gs = wizardry.ReadString
+var gp = wizardry.ReadBytes
+var gg = wizardry.ReadGUID
+var gd = wizardry.DERTest
+var t = true
+var f = false
+
+// state is what a single identification keeps track of, so that
+// identifications may run concurrently
+type state struct {
+	gw *wizardry.Guard // enforces the limits of the identification
+	tb [8]byte         // integers are read into it
+	ic int             // indirection depth
+}
+
+const (
+	// Fingerprint identifies the rules this package was generated from,
+	// see wizparser.Spellbook.Fingerprint
+	Fingerprint = "6f356b03810e899238bf9a2e5339f220621e8c77bd339474df26b0be904dc241"
+	// Chatty, EmitComments, MIME and Linear are the options this package
+	// was generated with, see wizcompiler.CompileOptions
+	Chatty       = false
+	EmitComments = false
+	MIME         = false
+	Linear       = false
+)
+
+// Identify follows the rules to find out the type of a target of the
+// given size, falling back to text classification if nothing matched.
+// The result is empty if "use" rules nest too deeply, see IdentifyContext.
+// It may be called from several goroutines at once.
+func Identify(r io.ReaderAt, size int64) wizardry.Result {
+	res, _ := IdentifyContext(context.Background(), r, size, wizardry.Limits{})
+	return res
+}
+
+// IdentifyContext is like Identify, but gives up when ctx is done, or
+// when identification exceeds the given limits. The error is then
+// either ctx's error, or a *wizardry.LimitError.
+func IdentifyContext(ctx context.Context, r io.ReaderAt, size int64, lm wizardry.Limits) (wizardry.Result, error) {
+	st := &state{gw: wizardry.NewGuard(ctx, r, lm)}
+	sr := wizutil.NewSliceReader(st.gw, 0, size)
+	fd := make(wizardry.Fields)
+	out := identify__Root(st, sr, 0, fd)
+	if len(out) == 0 {
+		out = append(out, st.gw.ClassifyText(sr).String())
+	}
+	// reads fail silently once the guard has failed
+	if err := st.gw.Err(); err != nil {
+		return wizardry.Result{}, err
+	}
+	return wizardry.Result{Strings: out, Fields: fd}, nil
+}
+
+// Pages lists the named pages of rules compiled in this package
+func Pages() []string {
+	return []string{
+		"sub",
+	}
+}
+
+// classifies the target as text or binary, at most once per page
+func ix(st *state, r *wizutil.SliceReader, tx **wizardry.TextInfo) bool {
+	if *tx == nil {
+		*tx = st.gw.ClassifyText(r)
+	}
+	return (*tx).IsText()
+}
+
+// reads an unsigned 8-bit little-endian integer
+func f1l(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	n, err := r.ReadAt(st.tb[:1], int64(off))
+	if n < 1 || err != nil {
+		return 0, f
+	}
+	return uint64(st.tb[0]), t
+}
+
+// reads an unsigned 8-bit big-endian integer
+func f1b(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	n, err := r.ReadAt(st.tb[:1], int64(off))
+	if n < 1 || err != nil {
+		return 0, f
+	}
+	return uint64(st.tb[0]), t
+}
+
+// reads an unsigned 16-bit little-endian integer
+func f2l(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	n, err := r.ReadAt(st.tb[:2], int64(off))
+	if n < 2 || err != nil {
+		return 0, f
+	}
+	return uint64(l.Uint16(st.tb[:])), t
+}
+
+// reads an unsigned 16-bit big-endian integer
+func f2b(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	n, err := r.ReadAt(st.tb[:2], int64(off))
+	if n < 2 || err != nil {
+		return 0, f
+	}
+	return uint64(b.Uint16(st.tb[:])), t
+}
+
+// reads an unsigned 32-bit little-endian integer
+func f4l(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	n, err := r.ReadAt(st.tb[:4], int64(off))
+	if n < 4 || err != nil {
+		return 0, f
+	}
+	return uint64(l.Uint32(st.tb[:])), t
+}
+
+// reads an unsigned 32-bit big-endian integer
+func f4b(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	n, err := r.ReadAt(st.tb[:4], int64(off))
+	if n < 4 || err != nil {
+		return 0, f
+	}
+	return uint64(b.Uint32(st.tb[:])), t
+}
+
+// reads an unsigned 64-bit little-endian integer
+func f8l(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	n, err := r.ReadAt(st.tb[:8], int64(off))
+	if n < 8 || err != nil {
+		return 0, f
+	}
+	return uint64(l.Uint64(st.tb[:])), t
+}
+
+// reads an unsigned 64-bit big-endian integer
+func f8b(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	n, err := r.ReadAt(st.tb[:8], int64(off))
+	if n < 8 || err != nil {
+		return 0, f
+	}
+	return uint64(b.Uint64(st.tb[:])), t
+}
+
+// reads an unsigned 8-bit little-endian integer, from the header window if it holds it
+func w1l(st *state, r *wizutil.SliceReader, hw []byte, po int64, off int64) (uint64, bool) {
+	i := off - po
+	if i < 0 || i+1 > int64(len(hw)) {
+		return f1l(st, r, off)
+	}
+	return uint64(hw[i]), t
+}
+
+// reads an unsigned 8-bit big-endian integer, from the header window if it holds it
+func w1b(st *state, r *wizutil.SliceReader, hw []byte, po int64, off int64) (uint64, bool) {
+	i := off - po
+	if i < 0 || i+1 > int64(len(hw)) {
+		return f1b(st, r, off)
+	}
+	return uint64(hw[i]), t
+}
+
+// reads an unsigned 16-bit little-endian integer, from the header window if it holds it
+func w2l(st *state, r *wizutil.SliceReader, hw []byte, po int64, off int64) (uint64, bool) {
+	i := off - po
+	if i < 0 || i+2 > int64(len(hw)) {
+		return f2l(st, r, off)
+	}
+	return uint64(l.Uint16(hw[i:])), t
+}
+
+// reads an unsigned 16-bit big-endian integer, from the header window if it holds it
+func w2b(st *state, r *wizutil.SliceReader, hw []byte, po int64, off int64) (uint64, bool) {
+	i := off - po
+	if i < 0 || i+2 > int64(len(hw)) {
+		return f2b(st, r, off)
+	}
+	return uint64(b.Uint16(hw[i:])), t
+}
+
+// reads an unsigned 32-bit little-endian integer, from the header window if it holds it
+func w4l(st *state, r *wizutil.SliceReader, hw []byte, po int64, off int64) (uint64, bool) {
+	i := off - po
+	if i < 0 || i+4 > int64(len(hw)) {
+		return f4l(st, r, off)
+	}
+	return uint64(l.Uint32(hw[i:])), t
+}
+
+// reads an unsigned 32-bit big-endian integer, from the header window if it holds it
+func w4b(st *state, r *wizutil.SliceReader, hw []byte, po int64, off int64) (uint64, bool) {
+	i := off - po
+	if i < 0 || i+4 > int64(len(hw)) {
+		return f4b(st, r, off)
+	}
+	return uint64(b.Uint32(hw[i:])), t
+}
+
+// reads an unsigned 64-bit little-endian integer, from the header window if it holds it
+func w8l(st *state, r *wizutil.SliceReader, hw []byte, po int64, off int64) (uint64, bool) {
+	i := off - po
+	if i < 0 || i+8 > int64(len(hw)) {
+		return f8l(st, r, off)
+	}
+	return uint64(l.Uint64(hw[i:])), t
+}
+
+// reads an unsigned 64-bit big-endian integer, from the header window if it holds it
+func w8b(st *state, r *wizutil.SliceReader, hw []byte, po int64, off int64) (uint64, bool) {
+	i := off - po
+	if i < 0 || i+8 > int64(len(hw)) {
+		return f8b(st, r, off)
+	}
+	return uint64(b.Uint64(hw[i:])), t
+}
+
+// reads a 32-bit little-endian ID3 synchsafe integer
+func f4il(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	v, k := f4l(st, r, off)
+	return wizardry.DecodeID3(v), k
+}
+
+// reads a 32-bit big-endian ID3 synchsafe integer
+func f4ib(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	v, k := f4b(st, r, off)
+	return wizardry.DecodeID3(v), k
+}
+
+// reads a 32-bit middle-endian integer
+func f4m(st *state, r *wizutil.SliceReader, off int64) (uint64, bool) {
+	n, err := r.ReadAt(st.tb[:4], int64(off))
+	if n < 4 || err != nil {
+		return 0, f
+	}
+	return uint64(wizardry.MiddleEndianUint32(st.tb[:])), t
+}
+
+func identify__Root(st *state, r *wizutil.SliceReader, po int64, fd wizardry.Fields) []string {
+	var out []string
+	var ss []string
+	ss = ss[0:]
+	var gf [32]int64
+	gf[0] = po
+	var ra uint64
+	ra &= ra
+	var rb uint64
+	rb &= rb
+	var rc uint64
+	rc &= rc
+	var rA int64
+	rA &= rA
+	var rB int64
+	rB &= rB
+	var sv string
+	sv += ""
+	var sb []byte
+	sb = sb[0:]
+	var sm [32]uint64
+	sm[0] &= sm[0]
+	var k bool
+	k = !!k
+	var l bool
+	l = !!l
+	var m bool
+	m = !!m
+	var d = make([]bool, 32)
+	d[0] = !!d[0]
+	var hw = gp(r, po, 61)
+
+	a := func(args ...string) {
+		out = append(out, args...)
+	}
+	rA = gt(r, po, "WIN", 0, 0)
+	if rA < 0 {
+		goto f0
+	}
+	a("windowed")
+	rc, m = w4l(st, r, hw, po, po+4)
+	if !m {
+		goto f1
+	}
+	a(wizardry.FormatDescription("\\b, a=%d", int64(int32(rc))))
+f1:
+	rc, m = w2l(st, r, hw, po, po+8)
+	if !m {
+		goto f2
+	}
+	a(wizardry.FormatDescription("\\b, b=%d", int64(int16(rc))))
+f2:
+	rc, m = w1l(st, r, hw, po, po+60)
+	if !m {
+		goto f3
+	}
+	a(wizardry.FormatDescription("\\b, c=%d", int64(int8(rc))))
+f3:
+	ra, k = w4l(st, r, hw, po, 12)
+	if !k {
+		goto f4
+	}
+	rc, m = f1l(st, r, int64(ra))
+	if !m {
+		goto f4
+	}
+	a(wizardry.FormatDescription("\\b, indirect=%d", int64(int8(rc))))
+f4:
+	if !st.gw.EnterUse() {
+		goto f5
+	}
+	ss = identifySub(st, r, po+16, fd)
+	st.gw.ExitUse()
+	if len(ss) == 0 {
+		goto f5
+	}
+	a(ss...)
+f5:
+	rc, m = f1l(st, r, po+2000)
+	if !m {
+		goto f6
+	}
+	a(wizardry.FormatDescription("\\b, far=%d", int64(int8(rc))))
+f6:
+f0:
+	if len(out) > 0 {
+		return out
+	}
+	return out
+}
+
+func identifySub(st *state, r *wizutil.SliceReader, po int64, fd wizardry.Fields) []string {
+	var out []string
+	var ss []string
+	ss = ss[0:]
+	var gf [32]int64
+	gf[0] = po
+	var ra uint64
+	ra &= ra
+	var rb uint64
+	rb &= rb
+	var rc uint64
+	rc &= rc
+	var rA int64
+	rA &= rA
+	var rB int64
+	rB &= rB
+	var sv string
+	sv += ""
+	var sb []byte
+	sb = sb[0:]
+	var sm [32]uint64
+	sm[0] &= sm[0]
+	var k bool
+	k = !!k
+	var l bool
+	l = !!l
+	var m bool
+	m = !!m
+	var d = make([]bool, 32)
+	d[0] = !!d[0]
+	var hw = gp(r, po, 8)
+
+	a := func(args ...string) {
+		out = append(out, args...)
+	}
+	rc, m = w4l(st, r, hw, po, po)
+	if !m {
+		goto f1
+	}
+	a(wizardry.FormatDescription("\\b, sub a=%d", int64(int32(rc))))
+f1:
+	rc, m = w4l(st, r, hw, po, po+4)
+	if !m {
+		goto f2
+	}
+	a(wizardry.FormatDescription("\\b, sub b=%d", int64(int32(rc))))
+f2:
+	return out
+}
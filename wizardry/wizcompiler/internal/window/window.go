@@ -0,0 +1,6 @@
+// Package window is generated from the rules in magdir, to check that
+// compiled rules read integers the same way as the interpreter, whether
+// they're in the header window or not.
+package window
+
+//go:generate go run github.com/itchio/wizardry compile magdir --output magic.go --package window
@@ -0,0 +1,133 @@
+package wizcompiler
+
+import (
+	"sort"
+
+	"github.com/itchio/wizardry/wizardry/wizparser"
+)
+
+// DeadRule is a rule that can never match, left out of the generated code
+// along with its children
+type DeadRule struct {
+	Page string
+	Line string
+	// Reason tells why the rule can never match
+	Reason string
+	// Rules is how many rules were left out: this one, and its children
+	Rules int
+}
+
+// prune returns a copy of book without the rules that can never match,
+// their children, and the pages the "" page can't reach once they're gone.
+// It also returns the names of those pages, sorted.
+func prune(book wizparser.Spellbook) (wizparser.Spellbook, []string, []DeadRule) {
+	var deadRules []DeadRule
+
+	var pages []string
+	for page := range book {
+		pages = append(pages, page)
+	}
+	sort.Strings(pages)
+
+	pruned := make(wizparser.Spellbook)
+	for _, page := range pages {
+		rules := book[page]
+		var kept []wizparser.Rule
+
+		for i := 0; i < len(rules); i++ {
+			rule := rules[i]
+			reason := neverMatches(book, rule)
+			if reason == "" {
+				kept = append(kept, rule)
+				continue
+			}
+
+			dead := DeadRule{
+				Page:   page,
+				Line:   rule.Line,
+				Reason: reason,
+				Rules:  1,
+			}
+			for i+1 < len(rules) && rules[i+1].Level > rule.Level {
+				dead.Rules++
+				i++
+			}
+			deadRules = append(deadRules, dead)
+		}
+
+		pruned[page] = kept
+	}
+
+	// pages are only reachable through "use" rules that were kept
+	reachable := map[string]bool{"": true}
+	queue := []string{""}
+	for len(queue) > 0 {
+		page := queue[0]
+		queue = queue[1:]
+
+		for _, rule := range pruned[page] {
+			if rule.Kind.Family != wizparser.KindFamilyUse {
+				continue
+			}
+			uk, _ := rule.Kind.Data.(*wizparser.UseKind)
+			if !reachable[uk.Page] {
+				reachable[uk.Page] = true
+				queue = append(queue, uk.Page)
+			}
+		}
+	}
+
+	var unreachablePages []string
+	for _, page := range pages {
+		if !reachable[page] {
+			delete(pruned, page)
+			unreachablePages = append(unreachablePages, page)
+		}
+	}
+
+	return pruned, unreachablePages, deadRules
+}
+
+// neverMatches returns why a rule can never match, whatever the target,
+// or an empty string if it may
+func neverMatches(book wizparser.Spellbook, rule wizparser.Rule) string {
+	switch rule.Kind.Family {
+	case wizparser.KindFamilyInteger:
+		ik, _ := rule.Kind.Data.(*wizparser.IntegerKind)
+		if ik.MatchAny || ik.Invert || ik.AdjustmentType != wizparser.AdjustmentNone {
+			return ""
+		}
+
+		// values read from the target only have that many bits
+		var bits uint64 = 1<<64 - 1
+		if ik.ByteWidth < 8 {
+			bits = 1<<uint(ik.ByteWidth*8) - 1
+		}
+		if ik.DoAnd {
+			bits &= ik.AndValue
+		}
+
+		switch ik.IntegerTest {
+		case wizparser.IntegerTestEqual:
+			if uint64(ik.Value)&^bits != 0 {
+				return "the value read can never be equal to the magic value"
+			}
+		case wizparser.IntegerTestAnd:
+			if uint64(ik.Value)&^bits != 0 {
+				return "the value read can never have all the bits of the magic value set"
+			}
+		case wizparser.IntegerTestLessThan:
+			if !ik.Signed && ik.Value == 0 {
+				return "unsigned values can never be less than zero"
+			}
+		}
+
+	case wizparser.KindFamilyUse:
+		uk, _ := rule.Kind.Data.(*wizparser.UseKind)
+		if _, ok := book[uk.Page]; !ok {
+			return "page " + uk.Page + " doesn't exist"
+		}
+	}
+
+	return ""
+}
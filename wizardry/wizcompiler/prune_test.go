@@ -0,0 +1,73 @@
+package wizcompiler
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/itchio/wizardry/wizardry/wizparser"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Prune(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	name	used",
+		">0	byte	1	used",
+		"",
+		"0	name	orphan",
+		">0	use	only-from-orphan",
+		"",
+		"0	name	only-from-orphan",
+		">0	byte	1	orphaned",
+		"",
+		"0	name	only-from-dead",
+		">0	byte	1	dead",
+		"",
+		"0	byte	1	root",
+		">0	use	used",
+		">1	byte	0x100	never",
+		">>0	use	only-from-dead",
+		">>0	byte	1	dead child",
+		">1	ubyte&0x0f	0x10	never, masked",
+		">1	ubyte	<0	never, negative",
+		">1	byte	<0	maybe, negative",
+		">1	use	nowhere",
+	}, "\n")
+
+	book := make(wizparser.Spellbook)
+	pctx := &wizparser.ParseContext{
+		Logf: func(format string, args ...interface{}) {},
+	}
+	assert.NoError(t, pctx.Parse(strings.NewReader(magic), book))
+
+	pruned, unreachablePages, deadRules := prune(book)
+	assert.EqualValues(t, []string{"only-from-dead", "only-from-orphan", "orphan"}, unreachablePages)
+	assert.Len(t, pruned, 2)
+	assert.Len(t, pruned["used"], 2)
+	assert.Len(t, pruned[""], 3)
+
+	if assert.Len(t, deadRules, 4) {
+		assert.EqualValues(t, ">1	byte	0x100	never", deadRules[0].Line)
+		assert.EqualValues(t, 3, deadRules[0].Rules)
+		assert.EqualValues(t, 1, deadRules[1].Rules)
+		assert.EqualValues(t, "page nowhere doesn't exist", deadRules[3].Reason)
+	}
+
+	// the book itself is left alone
+	assert.Len(t, book[""], 9)
+
+	var buf bytes.Buffer
+	stats, err := CompileTo(&buf, book, CompileOptions{})
+	assert.NoError(t, err)
+	assert.EqualValues(t, unreachablePages, stats.UnreachablePages)
+	assert.NotContains(t, buf.String(), "func identifyOrphan(")
+	assert.NotContains(t, buf.String(), "\t\t\"orphan\",\n")
+	assert.Contains(t, buf.String(), "\t\t\"used\",\n")
+	assert.NotContains(t, buf.String(), "never")
+
+	var report bytes.Buffer
+	stats.WriteReport(&report)
+	assert.Contains(t, report.String(), "Removed 3 unreachable pages: only-from-dead, only-from-orphan, orphan\n")
+	assert.Contains(t, report.String(), "Removed 6 rules that can never match:\n")
+	assert.Contains(t, report.String(), "  (root): >1	byte	0x100	never (the value read can never be equal to the magic value, with 2 children)\n")
+}
@@ -2,8 +2,11 @@ package wizcompiler
 
 import (
 	"fmt"
+	"sort"
 
+	"github.com/itchio/wizardry/wizardry"
 	"github.com/itchio/wizardry/wizardry/wizparser"
+	"github.com/itchio/wizardry/wizardry/wizutil"
 )
 
 func switchify(node *ruleNode) *ruleNode {
@@ -53,9 +56,9 @@ func switchify(node *ruleNode) *ruleNode {
 
 		candidate := false
 
-		if child.rule.Kind.Family == wizparser.KindFamilyInteger && len(child.children) == 0 {
+		if child.rule.Kind.Family == wizparser.KindFamilyInteger && len(child.children) == 0 && len(child.rule.Fields) == 0 && child.rule.MIME == "" && len(child.rule.Extensions) == 0 {
 			ik, _ := child.rule.Kind.Data.(*wizparser.IntegerKind)
-			if ik.IntegerTest == wizparser.IntegerTestEqual && !ik.DoAnd && ik.AdjustmentType == wizparser.AdjustmentNone {
+			if ik.IntegerTest == wizparser.IntegerTestEqual && !ik.DoAnd && !ik.Invert && ik.AdjustmentType == wizparser.AdjustmentNone {
 				candidate = true
 			}
 		}
@@ -85,7 +88,207 @@ func switchify(node *ruleNode) *ruleNode {
 
 	endStreak()
 
-	node.children = newChildren
+	node.children = stringSwitchify(newChildren)
 
 	return node
 }
+
+const (
+	// a string switch's cases are kept track of with one bit each
+	maxStringSwitchCases = 64
+	// prefixes longer than that aren't much more selective
+	maxStringSwitchPrefixLen = 32
+	// flags that let several bytes match a pattern byte multiply prefixes
+	maxStringSwitchPrefixes = 8
+	// string switch nodes need their own fail labels
+	stringSwitchIDBase = 1 << 32
+)
+
+// stringSwitchify merges runs of sibling string tests at the same offset
+// into string switches. Unlike integer switches, cases keep their rule,
+// children and all, and are emitted as usual: the switch reads the target
+// once, and lets through only the cases whose prefix matches.
+func stringSwitchify(nodes []*ruleNode) []*ruleNode {
+	var streak []*ruleNode
+	var streakPrefixes [][]string
+
+	var newNodes []*ruleNode
+
+	endStreak := func() {
+		if len(streak) < 2 {
+			newNodes = append(newNodes, streak...)
+		} else {
+			sk := &wizparser.StringSwitchKind{}
+			for i, node := range streak {
+				sk.Cases = append(sk.Cases, &wizparser.StringSwitchCase{
+					Value:    node.rule.Kind.Data.(*wizparser.StringKind).Value,
+					Flags:    node.rule.Kind.Data.(*wizparser.StringKind).Flags,
+					Prefixes: streakPrefixes[i],
+				})
+				node.switchBit = 1 << uint(i)
+			}
+			newNodes = append(newNodes, &ruleNode{
+				id: streak[0].id + stringSwitchIDBase,
+				rule: wizparser.Rule{
+					Kind: wizparser.Kind{
+						Family: wizparser.KindFamilyStringSwitch,
+						Data:   sk,
+					},
+					Level:  streak[0].rule.Level,
+					Offset: streak[0].rule.Offset,
+					Line:   fmt.Sprintf("(switch generated from %d string tests)", len(streak)),
+				},
+				cases: streak,
+			})
+		}
+		streak = nil
+		streakPrefixes = nil
+	}
+
+	for _, node := range nodes {
+		var prefixes []string
+
+		if node.rule.Kind.Family == wizparser.KindFamilyString {
+			sk, _ := node.rule.Kind.Data.(*wizparser.StringKind)
+			if !sk.MatchAny && !sk.Negate && sk.Operator == wizardry.StringEqual {
+				prefixes = stringPrefixes(sk.Value, sk.Flags)
+			}
+		}
+
+		if prefixes == nil {
+			endStreak()
+			newNodes = append(newNodes, node)
+			continue
+		}
+
+		if len(streak) > 0 && (len(streak) == maxStringSwitchCases || !streak[0].rule.Offset.Equals(node.rule.Offset)) {
+			endStreak()
+		}
+		streak = append(streak, node)
+		streakPrefixes = append(streakPrefixes, prefixes)
+	}
+
+	endStreak()
+
+	return newNodes
+}
+
+// stringPrefixes returns what the target must start with for a string
+// test to match, or nil if it could start with anything
+func stringPrefixes(value []byte, flags wizardry.StringTestFlags) []string {
+	prefixes := []string{""}
+
+	for _, p := range value {
+		var next []string
+		last := false
+
+		switch {
+		case flags&wizardry.OptionalBlanks > 0 && wizutil.IsWhitespace(p):
+			if flags&wizardry.CompactWhitespace > 0 {
+				// whether the target has a blank there or not, we
+				// can't tell how many it has
+				last = true
+				break
+			}
+			// the blank is there, or it isn't
+			for _, prefix := range prefixes {
+				next = append(next, prefix+string([]byte{p}), prefix)
+			}
+		case flags&wizardry.LowerMatchesBoth > 0 && wizutil.IsLowerLetter(p):
+			for _, prefix := range prefixes {
+				next = append(next, prefix+string([]byte{p}), prefix+string([]byte{wizutil.ToUpper(p)}))
+			}
+		case flags&wizardry.UpperMatchesBoth > 0 && wizutil.IsUpperLetter(p):
+			for _, prefix := range prefixes {
+				next = append(next, prefix+string([]byte{p}), prefix+string([]byte{wizutil.ToLower(p)}))
+			}
+		default:
+			for _, prefix := range prefixes {
+				next = append(next, prefix+string([]byte{p}))
+			}
+			// whitespace that follows in the target is skipped
+			last = flags&wizardry.CompactWhitespace > 0 && wizutil.IsWhitespace(p)
+		}
+
+		if next == nil || len(next) > maxStringSwitchPrefixes {
+			break
+		}
+		prefixes = next
+		if last || len(prefixes[0]) >= maxStringSwitchPrefixLen {
+			break
+		}
+	}
+
+	for _, prefix := range prefixes {
+		if prefix == "" {
+			return nil
+		}
+	}
+	return prefixes
+}
+
+// prefixTrie is what the target bytes of a string switch are matched
+// against, byte by byte
+type prefixTrie struct {
+	// cases with a prefix that ends here
+	mask     uint64
+	children map[byte]*prefixTrie
+}
+
+func newPrefixTrie(sk *wizparser.StringSwitchKind) *prefixTrie {
+	root := &prefixTrie{}
+	for i, c := range sk.Cases {
+		for _, prefix := range c.Prefixes {
+			node := root
+			for j := 0; j < len(prefix); j++ {
+				if node.children == nil {
+					node.children = make(map[byte]*prefixTrie)
+				}
+				child, ok := node.children[prefix[j]]
+				if !ok {
+					child = &prefixTrie{}
+					node.children[prefix[j]] = child
+				}
+				node = child
+			}
+			node.mask |= 1 << uint(i)
+		}
+	}
+	return root
+}
+
+// keys returns the bytes that lead to children, sorted
+func (pt *prefixTrie) keys() []byte {
+	var keys []byte
+	for key := range pt.children {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// chain follows children for as long as there's only one of them, and no
+// case ends, and returns the bytes it took along with where they lead
+func (pt *prefixTrie) chain() ([]byte, *prefixTrie) {
+	var chain []byte
+	node := pt
+	for len(node.children) == 1 && (node == pt || node.mask == 0) {
+		key := node.keys()[0]
+		chain = append(chain, key)
+		node = node.children[key]
+	}
+	return chain, node
+}
+
+// maxLen returns the length of the longest prefix
+func (pt *prefixTrie) maxLen() int {
+	max := 0
+	for _, child := range pt.children {
+		if l := child.maxLen() + 1; l > max {
+			max = l
+		}
+	}
+	return max
+}
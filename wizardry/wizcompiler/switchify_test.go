@@ -0,0 +1,71 @@
+package wizcompiler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/itchio/wizardry/wizardry"
+	"github.com/itchio/wizardry/wizardry/wizparser"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StringPrefixes(t *testing.T) {
+	assert.EqualValues(t, []string{"#!/bin"}, stringPrefixes([]byte("#!/bin"), 0))
+	assert.EqualValues(t, []string{"#! /", "#!/"}, stringPrefixes([]byte("#! /"), wizardry.OptionalBlanks))
+	assert.EqualValues(t, []string{"a "}, stringPrefixes([]byte("a b"), wizardry.CompactWhitespace))
+	assert.EqualValues(t, []string{"a"}, stringPrefixes([]byte("a b"), wizardry.CompactWhitespace|wizardry.OptionalBlanks))
+	assert.EqualValues(t, []string{"Ab", "AB"}, stringPrefixes([]byte("Ab"), wizardry.LowerMatchesBoth))
+	assert.EqualValues(t, []string{"\xff\xfe"}, stringPrefixes([]byte("\xff\xfe"), wizardry.ForceBinary))
+
+	// the target could start with anything
+	assert.Nil(t, stringPrefixes([]byte(" "), wizardry.OptionalBlanks))
+	assert.EqualValues(t, []string{" a", "a"}, stringPrefixes([]byte(" a"), wizardry.OptionalBlanks))
+	// prefixes stop before there are too many variants
+	prefixes := stringPrefixes([]byte("abcdef"), wizardry.LowerMatchesBoth)
+	assert.Len(t, prefixes, 8)
+	assert.EqualValues(t, "abc", prefixes[0])
+}
+
+func Test_StringSwitchify(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	HDR	header",
+		">3	string	AB	\\b, ab",
+		">>&0	byte	x	\\b, then %d",
+		">3	string/w	C\\ D	\\b, cd",
+		">3	byte	1	\\b, one",
+		">3	string	E	\\b, e",
+		">4	string	F	\\b, f",
+		">4	string	G	\\b, g",
+		">4	string	!H	\\b, not h",
+	}, "\n")
+
+	book := make(wizparser.Spellbook)
+	pctx := &wizparser.ParseContext{
+		Logf: func(format string, args ...interface{}) {},
+	}
+	assert.NoError(t, pctx.Parse(strings.NewReader(magic), book))
+
+	root := switchify(treeify(book[""])[0])
+	if assert.Len(t, root.children, 5) {
+		first := root.children[0]
+		assert.EqualValues(t, wizparser.KindFamilyStringSwitch, first.rule.Kind.Family)
+		assert.Len(t, first.cases, 2)
+		assert.Len(t, first.cases[0].children, 1)
+		assert.EqualValues(t, 0x2, first.cases[1].switchBit)
+
+		sk := first.rule.Kind.Data.(*wizparser.StringSwitchKind)
+		assert.EqualValues(t, []string{"C D", "CD"}, sk.Cases[1].Prefixes)
+
+		trie := newPrefixTrie(sk)
+		assert.EqualValues(t, []byte{'A', 'C'}, trie.keys())
+		assert.EqualValues(t, 3, trie.maxLen())
+
+		// an integer test ends the streak, and a single test isn't worth a switch
+		assert.EqualValues(t, wizparser.KindFamilyInteger, root.children[1].rule.Kind.Family)
+		assert.EqualValues(t, wizparser.KindFamilyString, root.children[2].rule.Kind.Family)
+
+		// tests at another offset get a switch of their own, but negated tests don't
+		assert.EqualValues(t, wizparser.KindFamilyStringSwitch, root.children[3].rule.Kind.Family)
+		assert.EqualValues(t, wizparser.KindFamilyString, root.children[4].rule.Kind.Family)
+	}
+}
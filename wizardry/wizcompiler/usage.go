@@ -30,5 +30,33 @@ func computePagesUsage(book wizparser.Spellbook) map[string]*PageUsage {
 		}
 	}
 
+	// pages used from a swapped page are used with the opposite endianness
+	// too, and that can cascade through nested uses
+	for changed := true; changed; {
+		changed = false
+		for page, rules := range book {
+			usage, ok := usages[page]
+			if !ok || !usage.EmitSwapped {
+				continue
+			}
+
+			for _, rule := range rules {
+				if rule.Kind.Family != wizparser.KindFamilyUse {
+					continue
+				}
+
+				uk, _ := rule.Kind.Data.(*wizparser.UseKind)
+				subUsage := usages[uk.Page]
+				if uk.SwapEndian && !subUsage.EmitNormal {
+					subUsage.EmitNormal = true
+					changed = true
+				} else if !uk.SwapEndian && !subUsage.EmitSwapped {
+					subUsage.EmitSwapped = true
+					changed = true
+				}
+			}
+		}
+	}
+
 	return usages
 }
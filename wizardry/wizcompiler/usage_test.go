@@ -0,0 +1,40 @@
+package wizcompiler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/itchio/wizardry/wizardry/wizparser"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PagesUsage(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	name	inner",
+		">0	byte	1	inner",
+		"",
+		"0	name	outer",
+		">0	use	inner",
+		">1	use	\\^inner",
+		"",
+		"0	name	unused",
+		">0	byte	1	unused",
+		"",
+		"0	byte	1	root",
+		">0	use	\\^outer",
+	}, "\n")
+
+	book := make(wizparser.Spellbook)
+	pctx := &wizparser.ParseContext{
+		Logf: func(format string, args ...interface{}) {},
+	}
+	assert.NoError(t, pctx.Parse(strings.NewReader(magic), book))
+
+	usages := computePagesUsage(book)
+
+	assert.EqualValues(t, &PageUsage{EmitNormal: true}, usages[""])
+	assert.EqualValues(t, &PageUsage{EmitSwapped: true}, usages["outer"])
+	// used as-is from a swapped page, and swapped back from it
+	assert.EqualValues(t, &PageUsage{EmitNormal: true, EmitSwapped: true}, usages["inner"])
+	assert.Nil(t, usages["unused"])
+}
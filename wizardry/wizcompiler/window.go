@@ -0,0 +1,87 @@
+package wizcompiler
+
+import "github.com/itchio/wizardry/wizardry/wizparser"
+
+const (
+	// maxHeaderWindow is how many bytes at most page functions read at
+	// once, at the page offset
+	maxHeaderWindow = 1024
+	// minWindowedReads is how many integer reads a page must have in its
+	// header window before it's worth reading it
+	minWindowedReads = 2
+)
+
+// headerWindow returns how many bytes at the page offset hold all the
+// integers that a page reads at static offsets, leaving out those past
+// maxHeaderWindow. It returns 0 if a window isn't worth reading.
+func headerWindow(nodes []*ruleNode) int64 {
+	var window int64
+	reads := 0
+
+	var walk func(nodes []*ruleNode)
+	walk = func(nodes []*ruleNode) {
+		for _, node := range nodes {
+			for _, end := range staticReads(node.rule) {
+				if end <= maxHeaderWindow {
+					reads++
+					if end > window {
+						window = end
+					}
+				}
+			}
+			walk(node.children)
+			walk(node.cases)
+		}
+	}
+	walk(nodes)
+
+	if reads < minWindowedReads {
+		return 0
+	}
+	return window
+}
+
+// staticReads returns where the integers a rule reads at static offsets
+// end, relative to the page offset
+func staticReads(rule wizparser.Rule) []int64 {
+	var ends []int64
+
+	switch rule.Kind.Family {
+	case wizparser.KindFamilyInteger:
+		ik, _ := rule.Kind.Data.(*wizparser.IntegerKind)
+		if end := staticEnd(rule, ik.ByteWidth); end > 0 && (!ik.MatchAny || rule.NeedsValue()) {
+			ends = append(ends, end)
+		}
+	case wizparser.KindFamilySwitch:
+		sk, _ := rule.Kind.Data.(*wizparser.SwitchKind)
+		if end := staticEnd(rule, sk.ByteWidth); end > 0 {
+			ends = append(ends, end)
+		}
+	}
+
+	if rule.Offset.OffsetType == wizparser.OffsetTypeIndirect {
+		// indirect addresses aren't relative to the page offset, but the
+		// page offset of the "" page is 0
+		indirect := rule.Offset.Indirect
+		if windowedIndirect(indirect) {
+			ends = append(ends, indirect.OffsetAddress+int64(indirect.ByteWidth))
+		}
+	}
+
+	return ends
+}
+
+// staticEnd returns where an integer a rule reads ends, relative to the
+// page offset, or 0 if it isn't known statically
+func staticEnd(rule wizparser.Rule, byteWidth int) int64 {
+	if rule.Offset.OffsetType != wizparser.OffsetTypeDirect || rule.Offset.IsRelative || rule.Offset.Direct < 0 {
+		return 0
+	}
+	return rule.Offset.Direct + int64(byteWidth)
+}
+
+// windowedIndirect returns true if the address of an indirect offset may
+// be read from the header window
+func windowedIndirect(indirect *wizparser.IndirectOffset) bool {
+	return !indirect.IsRelative && indirect.OffsetAddress >= 0 && indirect.Format == wizparser.IndirectFormatPlain
+}
@@ -0,0 +1,65 @@
+package wizcompiler
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"testing"
+
+	"github.com/itchio/wizardry/wizardry/wizcompiler/internal/window"
+	"github.com/itchio/wizardry/wizardry/wizinterpreter"
+	"github.com/itchio/wizardry/wizardry/wizparser"
+	"github.com/itchio/wizardry/wizardry/wizutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HeaderWindowFallback(t *testing.T) {
+	NoLogf := func(format string, args ...interface{}) {}
+
+	book := make(wizparser.Spellbook)
+	pctx := &wizparser.ParseContext{Logf: NoLogf}
+	assert.NoError(t, pctx.ParseAll("internal/window/magdir", book))
+
+	// the generated package must be up to date, see go:generate in window.go
+	var buf bytes.Buffer
+	_, err := CompileTo(&buf, book, CompileOptions{Package: "window"})
+	assert.NoError(t, err)
+	generated, err := ioutil.ReadFile("internal/window/magic.go")
+	assert.NoError(t, err)
+	assert.True(t, bytes.Equal(buf.Bytes(), generated), "internal/window/magic.go is stale, run go generate")
+	assert.Contains(t, buf.String(), "\tvar hw = gp(r, po, 61)\n")
+
+	ictx := &wizinterpreter.InterpretContext{Logf: NoLogf, Book: book}
+	identify := func(target []byte) string {
+		sr := wizutil.NewSliceReader(bytes.NewReader(target), 0, int64(len(target)))
+		expected, err := ictx.Identify(sr)
+		assert.NoError(t, err)
+
+		actual := window.Identify(bytes.NewReader(target), int64(len(target)))
+		assert.EqualValues(t, wizutil.MergeStrings(expected), wizutil.MergeStrings(actual.Strings))
+		return wizutil.MergeStrings(actual.Strings)
+	}
+
+	target := make([]byte, 128)
+	copy(target, "WIN")
+	binary.LittleEndian.PutUint32(target[4:], 1)
+	binary.LittleEndian.PutUint16(target[8:], 2)
+	binary.LittleEndian.PutUint32(target[12:], 100)
+	binary.LittleEndian.PutUint32(target[16:], 5)
+	binary.LittleEndian.PutUint32(target[20:], 6)
+	target[60] = 3
+	target[100] = 4
+
+	// everything is read from the header window, except for the indirect
+	// offset, which points past it
+	assert.EqualValues(t, "windowed, a=1, b=2, c=3, indirect=4, sub a=5, sub b=6", identify(target))
+
+	// the window is cut short by the end of the target, the page's too
+	assert.EqualValues(t, "windowed, a=1, b=2, sub a=5", identify(target[:23]))
+	assert.EqualValues(t, "windowed, a=1", identify(target[:9]))
+	assert.EqualValues(t, "windowed", identify(target[:7]))
+
+	// an indirect offset past the end of the target
+	binary.LittleEndian.PutUint32(target[12:], 200)
+	assert.EqualValues(t, "windowed, a=1, b=2, c=3, sub a=5, sub b=6", identify(target))
+}
@@ -0,0 +1,67 @@
+package wizinterpreter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/itchio/wizardry/wizardry"
+	"github.com/stretchr/testify/assert"
+)
+
+func rankWith(t *testing.T, magic string, target []byte) wizardry.Result {
+	ictx := contextWith(t, magic)
+	ictx.RankCandidates = true
+
+	result, err := ictx.IdentifyResult(targetReader(target))
+	assert.NoError(t, err)
+	return result
+}
+
+func Test_RankCandidates(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	byte	0x7f	weak byte match",
+		"0	belong	0x12345678	never matches",
+		"0	string	\\177E	short prefix",
+		"0	string	\\177ELF	ELF",
+		"!:strength *2",
+		">4	byte	2	64-bit",
+		"!:field bits 64",
+	}, "\n")
+
+	result := rankWith(t, magic, []byte("\x7fELF\x02"))
+
+	// the output is the same as without ranking
+	assert.EqualValues(t, []string{"weak byte match"}, result.Strings)
+	assert.Empty(t, result.Fields)
+
+	candidates := result.Candidates
+	assert.Len(t, candidates, 3)
+	assert.EqualValues(t, []string{"ELF", "64-bit"}, candidates[0].Strings)
+	assert.EqualValues(t, "64", candidates[0].Fields.Get("bits"))
+	assert.EqualValues(t, 140, candidates[0].Strength)
+	assert.EqualValues(t, 2, candidates[0].Levels)
+	assert.EqualValues(t, 5, candidates[0].Bytes)
+
+	assert.EqualValues(t, []string{"short prefix"}, candidates[1].Strings)
+	assert.EqualValues(t, []string{"weak byte match"}, candidates[2].Strings)
+	assert.True(t, candidates[1].Score > candidates[2].Score)
+}
+
+func Test_StrengthAnnotation(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	AB	plus",
+		"!:strength + 10",
+		"0	string	AB	divided",
+		"!:strength /0",
+		"0	string	AB	multiplied",
+		"!:strength *3",
+		"0	string	AB	not a strength",
+		"!:strengthy *3",
+	}, "\n")
+
+	rules := contextWith(t, magic).Book[""]
+	assert.EqualValues(t, 60, rules[0].Strength())
+	assert.EqualValues(t, 50, rules[1].Strength())
+	assert.EqualValues(t, 150, rules[2].Strength())
+	assert.EqualValues(t, 50, rules[3].Strength())
+}
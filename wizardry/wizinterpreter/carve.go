@@ -0,0 +1,245 @@
+package wizinterpreter
+
+import (
+	"context"
+	"sort"
+
+	"github.com/itchio/wizardry/wizardry"
+	"github.com/itchio/wizardry/wizardry/wizparser"
+	"github.com/itchio/wizardry/wizardry/wizutil"
+)
+
+// CarveResult is a format found at some offset of a target
+type CarveResult struct {
+	Offset  int64
+	Strings []string
+}
+
+// carveGroup is a top-level rule of the "" page, along with all its children
+type carveGroup struct {
+	rules []wizparser.Rule
+	// prefix is what the top-level rule requires at offset at
+	prefix []byte
+	at     int64
+	// order is the position of the group in the "" page
+	order int
+}
+
+// carveIndex maps the first byte of a top-level rule's literal prefix
+// to the groups that start with it, for each offset prefixes are at
+type carveIndex struct {
+	groups  []*carveGroup
+	offsets []*carveBuckets
+}
+
+// carveBuckets are the groups whose prefix is at a given offset
+type carveBuckets struct {
+	at      int64
+	buckets [256][]*carveGroup
+}
+
+// Carve looks for embedded formats in a target, by running the top-level
+// rules at every stride-th offset. Offset 0 is identified as usual (without
+// falling back to text classification), other offsets only consider rules
+// that test for a literal string or integer at a fixed offset, like
+// "257 string ustar\0", looked up by its first byte.
+func (ctx *InterpretContext) Carve(sr *wizutil.SliceReader, stride int64) ([]CarveResult, error) {
+	return ctx.CarveContext(context.Background(), sr, stride, wizardry.Limits{})
+}
+
+// CarveContext is like Carve, but gives up when cctx is done, or when
+// carving the whole target exceeds the given limits. The error is then
+// either cctx's error, or a *wizardry.LimitError.
+func (ctx *InterpretContext) CarveContext(cctx context.Context, sr *wizutil.SliceReader, stride int64, limits wizardry.Limits) ([]CarveResult, error) {
+	if stride < 1 {
+		stride = 1
+	}
+
+	err := ctx.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	guard := wizardry.NewGuard(cctx, sr, limits)
+	sr = wizutil.NewSliceReader(guard, 0, sr.Size())
+
+	var results []CarveResult
+
+	outStrings, err := ctx.identifyInternal(newCarveState(guard), sr, 0, "", false)
+	if err != nil {
+		return nil, err
+	}
+	if len(outStrings) > 0 {
+		results = append(results, CarveResult{
+			Offset:  0,
+			Strings: outStrings,
+		})
+	}
+
+	index := ctx.buildCarveIndex()
+	ctx.Logf("|====> carving with %d indexed top-level rules", len(index.groups))
+
+	// each offset gets its own view, so that they all move forward
+	bvs := make([]*wizutil.ByteView, len(index.offsets))
+	for i := range bvs {
+		bvs[i] = &wizutil.ByteView{
+			Input:    sr,
+			LookBack: 0,
+		}
+	}
+
+	for offset := stride; offset < sr.Size(); offset += stride {
+		if guard.Err() != nil {
+			break
+		}
+
+		var groups []*carveGroup
+		for i, cb := range index.offsets {
+			c := bvs[i].Get(offset + cb.at)
+			if c == -1 {
+				continue
+			}
+
+			for _, group := range cb.buckets[byte(c)] {
+				if carvePrefixMatches(bvs[i], offset+cb.at, group.prefix) {
+					groups = append(groups, group)
+				}
+			}
+		}
+
+		if len(groups) == 0 {
+			continue
+		}
+
+		// rules are evaluated in the order of the page, the first match wins
+		sort.Slice(groups, func(i, j int) bool {
+			return groups[i].order < groups[j].order
+		})
+		var rules []wizparser.Rule
+		for _, group := range groups {
+			rules = append(rules, group.rules...)
+		}
+
+		ctx.Logf("|====> carving at %d (%d candidate rules)", offset, len(rules))
+
+		outStrings, err := ctx.identifyRules(newCarveState(guard), sr.Slice(offset), 0, rules, false, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(outStrings) > 0 {
+			results = append(results, CarveResult{
+				Offset:  offset,
+				Strings: outStrings,
+			})
+		}
+	}
+
+	// reads fail silently once the guard has failed
+	if err := guard.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func carvePrefixMatches(bv *wizutil.ByteView, offset int64, prefix []byte) bool {
+	for i, b := range prefix {
+		c := bv.Get(offset + int64(i))
+		if c == -1 || byte(c) != b {
+			return false
+		}
+	}
+	return true
+}
+
+func (ctx *InterpretContext) buildCarveIndex() *carveIndex {
+	index := &carveIndex{}
+
+	byOffset := make(map[int64]*carveBuckets)
+
+	var group *carveGroup
+	for _, rule := range ctx.Book[""] {
+		if rule.Level == 0 {
+			group = nil
+
+			at, prefix := carvePrefix(rule)
+			if len(prefix) > 0 {
+				group = &carveGroup{
+					prefix: prefix,
+					at:     at,
+					order:  len(index.groups),
+				}
+				index.groups = append(index.groups, group)
+
+				cb, ok := byOffset[at]
+				if !ok {
+					cb = &carveBuckets{at: at}
+					byOffset[at] = cb
+					index.offsets = append(index.offsets, cb)
+				}
+				cb.buckets[prefix[0]] = append(cb.buckets[prefix[0]], group)
+			}
+		}
+
+		if group != nil {
+			group.rules = append(group.rules, rule)
+		}
+	}
+
+	return index
+}
+
+// carvePrefix returns the bytes a rule requires, and the offset it requires
+// them at, or nil if they cannot be determined statically
+func carvePrefix(rule wizparser.Rule) (int64, []byte) {
+	if rule.Offset.OffsetType != wizparser.OffsetTypeDirect || rule.Offset.IsRelative || rule.Offset.Direct < 0 {
+		return 0, nil
+	}
+	at := rule.Offset.Direct
+
+	switch rule.Kind.Family {
+	case wizparser.KindFamilyString:
+		sk, _ := rule.Kind.Data.(*wizparser.StringKind)
+		if sk.Negate || sk.MatchAny || sk.Operator != wizardry.StringEqual {
+			return 0, nil
+		}
+		if sk.Flags&^(wizardry.ForceText|wizardry.ForceBinary) != 0 {
+			return 0, nil
+		}
+		return at, sk.Value
+
+	case wizparser.KindFamilyInteger:
+		ik, _ := rule.Kind.Data.(*wizparser.IntegerKind)
+		if ik.MatchAny || ik.IntegerTest != wizparser.IntegerTestEqual || ik.DoAnd || ik.Invert || ik.AdjustmentType != wizparser.AdjustmentNone {
+			return 0, nil
+		}
+
+		buf := make([]byte, 8)
+		bo := ik.Endianness.ByteOrder()
+		switch ik.ByteWidth {
+		case 1:
+			buf[0] = byte(ik.Value)
+		case 2:
+			bo.PutUint16(buf, uint16(ik.Value))
+		case 4:
+			bo.PutUint32(buf, uint32(ik.Value))
+		case 8:
+			bo.PutUint64(buf, uint64(ik.Value))
+		default:
+			return 0, nil
+		}
+		return at, buf[:ik.ByteWidth]
+	}
+
+	return 0, nil
+}
+
+// newCarveState returns the state for identifying at one offset of a
+// carved target. The guard is shared by all offsets, so that limits apply
+// to carving the whole target.
+func newCarveState(guard *wizardry.Guard) *identifyState {
+	return &identifyState{
+		guard: guard,
+	}
+}
@@ -0,0 +1,110 @@
+package wizinterpreter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/itchio/wizardry/wizardry"
+	"github.com/itchio/wizardry/wizardry/wizutil"
+	"github.com/stretchr/testify/assert"
+)
+
+var carveMagic = strings.Join([]string{
+	"0	string	ABA	aba",
+	">3	byte	x	\\b, then %d",
+	"0	lelong	0x44434241	abcd",
+	"0	string	ZZ	zz",
+	"4	string/c	late	late",
+}, "\n")
+
+func carveWith(t *testing.T, magic string, target []byte, stride int64, limits wizardry.Limits) ([]CarveResult, error) {
+	return contextWith(t, magic).CarveContext(context.Background(), targetReader(target), stride, limits)
+}
+
+func carveOffsets(t *testing.T, target string, stride int64) map[int64]string {
+	results, err := carveWith(t, carveMagic, []byte(target), stride, wizardry.Limits{})
+	assert.NoError(t, err)
+
+	offsets := make(map[int64]string)
+	for _, result := range results {
+		offsets[result.Offset] = wizutil.MergeStrings(result.Strings)
+	}
+	return offsets
+}
+
+func Test_Carve(t *testing.T) {
+	// offset 0 is identified as usual, even by rules that aren't indexed
+	assert.EqualValues(t, map[int64]string{0: "late"}, carveOffsets(t, "....LATE", 1))
+
+	// embedded matches, looked up by their first byte, integers too
+	assert.EqualValues(t, map[int64]string{
+		3:  "aba, then 46",
+		11: "abcd",
+	}, carveOffsets(t, "...ABA.LATEABCD", 1))
+
+	// overlapping matches
+	assert.EqualValues(t, map[int64]string{
+		0: "aba, then 66",
+		2: "aba, then 66",
+		4: "aba",
+	}, carveOffsets(t, "ABABABA", 1))
+
+	// a match at the very end, and one cut short by it
+	assert.EqualValues(t, map[int64]string{5: "zz"}, carveOffsets(t, ".....ZZ", 1))
+	assert.EqualValues(t, map[int64]string{}, carveOffsets(t, "......Z", 1))
+}
+
+func Test_CarveStride(t *testing.T) {
+	target := "....ABA...ABA.ZZ"
+
+	assert.EqualValues(t, map[int64]string{
+		4:  "aba, then 46",
+		10: "aba, then 46",
+		14: "zz",
+	}, carveOffsets(t, target, 2))
+
+	// matches between strides are skipped
+	assert.EqualValues(t, map[int64]string{
+		4: "aba, then 46",
+	}, carveOffsets(t, target, 4))
+	// the last stride only has room for one Z
+	assert.EqualValues(t, map[int64]string{
+		10: "aba, then 46",
+	}, carveOffsets(t, target, 5))
+}
+
+func Test_CarveLimits(t *testing.T) {
+	target := bytes.Repeat([]byte("ABA."), 64)
+
+	results, err := carveWith(t, carveMagic, target, 1, wizardry.Limits{})
+	assert.NoError(t, err)
+	assert.Len(t, results, 64)
+
+	_, err = carveWith(t, carveMagic, target, 1, wizardry.Limits{MaxBytesRead: 64})
+	if assert.IsType(t, &wizardry.LimitError{}, err) {
+		assert.EqualValues(t, "MaxBytesRead", err.(*wizardry.LimitError).Limit)
+	}
+}
+
+func Test_CarveTar(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	ZZ	zz",
+		"257	string	ustar\\0	POSIX tar archive",
+		">0	string	x	\\b, first file %s",
+	}, "\n")
+
+	header := make([]byte, 512)
+	copy(header, "hello.txt")
+	copy(header[257:], "ustar\x0000")
+	target := append([]byte("ZZ some installer stub "), header...)
+
+	results, err := carveWith(t, magic, target, 1, wizardry.Limits{})
+	assert.NoError(t, err)
+	if assert.Len(t, results, 2) {
+		assert.EqualValues(t, 0, results[0].Offset)
+		assert.EqualValues(t, 23, results[1].Offset)
+		assert.EqualValues(t, "POSIX tar archive, first file hello.txt", wizutil.MergeStrings(results[1].Strings))
+	}
+}
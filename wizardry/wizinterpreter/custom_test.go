@@ -0,0 +1,91 @@
+package wizinterpreter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/itchio/wizardry/wizardry"
+	"github.com/itchio/wizardry/wizardry/wizparser"
+	"github.com/itchio/wizardry/wizardry/wizutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// xorsum/N tests the xor of N bytes, "x" matches any value
+type xorsumTest struct {
+	size     int64
+	value    int64
+	matchAny bool
+}
+
+var xorsumKind = &wizparser.CustomKindDefinition{
+	Name: "xorsum",
+	Parse: func(suffix string, test []byte) (interface{}, error) {
+		if !strings.HasPrefix(suffix, "/") {
+			return nil, fmt.Errorf("expected /size, got %q", suffix)
+		}
+		size, err := strconv.ParseInt(suffix[1:], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		xt := &xorsumTest{size: size}
+		if string(test) == "x" {
+			xt.matchAny = true
+			return xt, nil
+		}
+		xt.value, err = strconv.ParseInt(strings.TrimPrefix(string(test), "="), 0, 64)
+		return xt, err
+	},
+	Evaluate: func(sr *wizutil.SliceReader, offset int64, data interface{}) wizardry.CustomResult {
+		xt := data.(*xorsumTest)
+		buf := make([]byte, xt.size)
+		n, _ := sr.ReadAt(buf, offset)
+		if int64(n) < xt.size {
+			return wizardry.CustomResult{}
+		}
+
+		var sum int64
+		for _, b := range buf {
+			sum ^= int64(b)
+		}
+		return wizardry.CustomResult{
+			Matched: xt.matchAny || sum == xt.value,
+			Value:   sum,
+			Length:  xt.size,
+		}
+	},
+}
+
+func init() {
+	err := wizparser.RegisterCustomKind(xorsumKind)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func Test_CustomKind(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	XS	xorsummed",
+		">2	xorsum/3	=0x07	\\b, valid",
+		">>&0	string	END	\\b, end",
+		">2	xorsum/3	x	\\b, sum %d",
+	}, "\n")
+
+	assert.EqualValues(t, "xorsummed, valid, end, sum 7", identifyWith(t, magic, []byte("XS\x01\x02\x04END")))
+	assert.EqualValues(t, "xorsummed, sum 6", identifyWith(t, magic, []byte("XS\x01\x03\x04END")))
+}
+
+func Test_CustomKindRegistration(t *testing.T) {
+	assert.Error(t, wizparser.RegisterCustomKind(xorsumKind), "registered twice")
+
+	noEval := &wizparser.CustomKindDefinition{Name: "noeval"}
+	assert.Error(t, wizparser.RegisterCustomKind(noEval), "no Evaluate")
+
+	shadow := &wizparser.CustomKindDefinition{Name: "string", Evaluate: xorsumKind.Evaluate}
+	assert.Error(t, wizparser.RegisterCustomKind(shadow), "shadows a built-in")
+
+	badName := &wizparser.CustomKindDefinition{Name: "Crc-32", Evaluate: xorsumKind.Evaluate}
+	assert.Error(t, wizparser.RegisterCustomKind(badName), "invalid name")
+}
@@ -0,0 +1,39 @@
+package wizinterpreter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DER(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	der	seq	DER sequence",
+		">&0	der	obj_id9=2a864886f70d010701	\\b, PKCS#7 data",
+		">>&9	der	utf8_str=x	\\b, named %s",
+		">&0	der	obj_id=x	\\b, object %s",
+		"0	der	int2	two-byte integer",
+	}, "\n")
+
+	oid := "\x06\x09\x2a\x86\x48\x86\xf7\x0d\x01\x07\x01"
+	name := "\x0c\x02hi"
+	assert.EqualValues(t, "DER sequence, PKCS#7 data, named hi, object 2a864886f70d010701",
+		identifyWith(t, magic, []byte("\x30\x0f"+oid+name)))
+
+	// long form lengths
+	assert.EqualValues(t, "DER sequence, PKCS#7 data, named hi, object 2a864886f70d010701",
+		identifyWith(t, magic, []byte("\x30\x82\x00\x0f"+oid+name)))
+
+	// another object, and contents cut short by the end of the target
+	other := strings.Replace(oid, "\x07\x01", "\x07\x02", 1)
+	assert.EqualValues(t, "DER sequence, object 2a864886f70d010702", identifyWith(t, magic, []byte("\x30\x0f"+other+name)))
+	assert.EqualValues(t, "DER sequence, PKCS#7 data, object 2a864886f70d010701", identifyWith(t, magic, []byte("\x30\x0f"+oid+"\x0c\x05hi")))
+
+	// long form lengths past the end of the target don't match
+	assert.EqualValues(t, "DER sequence, PKCS#7 data, object 2a864886f70d010701", identifyWith(t, magic, []byte("\x30\x0f"+oid+"\x0c\x84\xff\xff\xff\xf0hi")))
+
+	// lengths must match
+	assert.EqualValues(t, "two-byte integer", identifyWith(t, magic, []byte("\x02\x02\x01\x00")))
+	assert.EqualValues(t, "data", identifyWith(t, magic, []byte("\x02\x01\x01\x00")))
+}
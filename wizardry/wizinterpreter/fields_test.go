@@ -0,0 +1,76 @@
+package wizinterpreter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/itchio/wizardry/wizardry"
+	"github.com/stretchr/testify/assert"
+)
+
+func fieldsWith(t *testing.T, magic string, target []byte) wizardry.Fields {
+	return resultWith(t, magic, target).Fields
+}
+
+func Test_FieldCapture(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	FMT	format",
+		"!:mime	application/x-test",
+		"!:field format fmt",
+		">3	byte	1	\\b, (version one),",
+		"!:field version",
+		">3	byte	x",
+		"!:field raw",
+		">4	leshort	x	\\b, build %d",
+		"!:field build 0x%x",
+		">6	bogus	x	never parsed",
+		"!:field bogus",
+		">6	byte	x",
+		"!:field",
+	}, "\n")
+
+	fields := fieldsWith(t, magic, []byte("FMT\x01\x34\x12\x00"))
+	assert.EqualValues(t, "fmt", fields.Get("format"))
+	assert.EqualValues(t, "version one", fields.Get("version"))
+	assert.EqualValues(t, "1", fields.Get("raw"))
+	assert.EqualValues(t, "0x1234", fields.Get("build"))
+	assert.EqualValues(t, "application/x-test", fields.Get(wizardry.MIMEField))
+	assert.Len(t, fields, 5)
+}
+
+func Test_FieldCaptureAcrossPages(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	name	slice",
+		">0	byte	1	one",
+		"!:field arch",
+		">0	byte	2	two",
+		"!:field arch",
+		"",
+		"0	string	FAT	fat",
+		">3	use	slice",
+		">4	use	slice",
+		">5	use	slice",
+	}, "\n")
+
+	fields := fieldsWith(t, magic, []byte("FAT\x01\x02\x01"))
+	assert.EqualValues(t, []string{"one", "two"}, fields["arch"])
+}
+
+func Test_MIME(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	IMG	image",
+		">3	byte	1	\\b, version one",
+		"!:mime	image/x-test",
+		"!:ext	img/tst",
+		">3	byte	2	\\b, version two",
+		"!:mime	image/x-test2",
+	}, "\n")
+
+	result := resultWith(t, magic, []byte("IMG\x01"))
+	assert.EqualValues(t, "image/x-test", result.MIME())
+	assert.EqualValues(t, []string{"img", "tst"}, result.Extensions())
+
+	result = resultWith(t, magic, []byte("IMG\x03"))
+	assert.EqualValues(t, "", result.MIME())
+	assert.Empty(t, result.Extensions())
+}
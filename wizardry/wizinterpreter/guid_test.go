@@ -0,0 +1,26 @@
+package wizinterpreter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GUID(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	OLE	ole",
+		">3	guid	00020906-0000-0000-C000-000000000046	\\b, Word document",
+		">3	guid	x	\\b, class %s",
+	}, "\n")
+
+	// the first three groups are stored little-endian
+	word := "OLE\x06\x09\x02\x00\x00\x00\x00\x00\xc0\x00\x00\x00\x00\x00\x00\x46"
+	assert.EqualValues(t, "ole, Word document, class 00020906-0000-0000-C000-000000000046", identifyWith(t, magic, []byte(word)))
+
+	other := "OLE\x00\x01\x02\x03\x04\x05\x06\x07\x08\x09\x0a\x0b\x0c\x0d\x0e\x0f"
+	assert.EqualValues(t, "ole, class 03020100-0504-0706-0809-0A0B0C0D0E0F", identifyWith(t, magic, []byte(other)))
+
+	// too short to hold a GUID
+	assert.EqualValues(t, "ole", identifyWith(t, magic, []byte(word[:18])))
+}
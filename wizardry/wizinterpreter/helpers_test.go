@@ -0,0 +1,37 @@
+package wizinterpreter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/itchio/wizardry/wizardry"
+	"github.com/itchio/wizardry/wizardry/wizparser"
+	"github.com/itchio/wizardry/wizardry/wizutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// contextWith returns an interpreter for the rules of a magic file
+func contextWith(t *testing.T, magic string) *InterpretContext {
+	NoLogf := func(format string, args ...interface{}) {}
+
+	book := make(wizparser.Spellbook)
+	pctx := &wizparser.ParseContext{Logf: NoLogf}
+	assert.NoError(t, pctx.Parse(strings.NewReader(magic), book))
+
+	return &InterpretContext{Logf: NoLogf, Book: book}
+}
+
+func targetReader(target []byte) *wizutil.SliceReader {
+	return wizutil.NewSliceReader(bytes.NewReader(target), 0, int64(len(target)))
+}
+
+func resultWith(t *testing.T, magic string, target []byte) wizardry.Result {
+	result, err := contextWith(t, magic).IdentifyResult(targetReader(target))
+	assert.NoError(t, err)
+	return result
+}
+
+func identifyWith(t *testing.T, magic string, target []byte) string {
+	return wizutil.MergeStrings(resultWith(t, magic, target).Strings)
+}
@@ -0,0 +1,118 @@
+package wizinterpreter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/itchio/wizardry/wizardry"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IndirectID3(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	ID3	Audio file with ID3 version 2",
+		">(6.I+10)	beshort&0xffe0	0xffe0	\\b, MPEG ADTS",
+	}, "\n")
+
+	// tag size is synchsafe: 0x02 0x01 means 2*128+1 = 257 bytes
+	target := make([]byte, 1024)
+	copy(target, []byte{'I', 'D', '3', 3, 0, 0, 0x00, 0x00, 0x02, 0x01})
+	target[267] = 0xff
+	target[268] = 0xfb
+
+	assert.EqualValues(t, "Audio file with ID3 version 2, MPEG ADTS", identifyWith(t, magic, target))
+
+	// a plain big-endian read would land at 0x201+10 instead
+	target[267] = 0
+	target[0x201+10] = 0xff
+	target[0x201+10+1] = 0xfb
+	assert.EqualValues(t, "Audio file with ID3 version 2", identifyWith(t, magic, target))
+}
+
+func Test_IndirectQuad(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	BIG64	64-bit container",
+		">(8.q)	string	DATA	\\b, little-endian index",
+		">(16.Q)	string	MORE	\\b, big-endian index",
+	}, "\n")
+
+	target := make([]byte, 64)
+	copy(target, "BIG64")
+	copy(target[8:], []byte{0x20, 0, 0, 0, 0, 0, 0, 0})
+	copy(target[16:], []byte{0, 0, 0, 0, 0, 0, 0, 0x30})
+	copy(target[0x20:], "DATA")
+	copy(target[0x30:], "MORE")
+
+	assert.EqualValues(t, "64-bit container, little-endian index, big-endian index", identifyWith(t, magic, target))
+
+	// the high half of the quad must be taken into account
+	target[12] = 1
+	assert.EqualValues(t, "64-bit container, big-endian index", identifyWith(t, magic, target))
+}
+
+func Test_IndirectMiddleEndian(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	PDP	PDP-11 image",
+		">(4.m)	string	SYM	\\b, with symbols",
+	}, "\n")
+
+	// 0x00000030 stored as two little-endian halves, high half first
+	target := make([]byte, 64)
+	copy(target, "PDP")
+	copy(target[4:], []byte{0x00, 0x00, 0x30, 0x00})
+	copy(target[0x30:], "SYM")
+
+	assert.EqualValues(t, "PDP-11 image, with symbols", identifyWith(t, magic, target))
+}
+
+func Test_IndirectKind(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	SFX	self-extracting archive",
+		">(4.b)	indirect	x	\\b, containing",
+		"0	string	PAY	payload",
+		">3	byte	x	%d",
+	}, "\n")
+
+	// the whole book is run again at the offset, as if it were a target
+	target := []byte("SFX.\x08...PAY\x07")
+	assert.EqualValues(t, "self-extracting archive, containing payload 7", identifyWith(t, magic, target))
+
+	// nothing found there
+	target[8] = 'X'
+	assert.EqualValues(t, "self-extracting archive", identifyWith(t, magic, target))
+
+	// identifying at offset 0 again would never end
+	target[4] = 0
+	assert.EqualValues(t, "self-extracting archive", identifyWith(t, magic, target))
+}
+
+func Test_IndirectKindRelative(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	name	wrapper",
+		">(&0.b)	indirect/r	x	\\b, wrapping",
+		"",
+		"0	string	WRAP	wrapper",
+		">4	use	wrapper",
+		"0	string	PAY	payload",
+	}, "\n")
+
+	// the offset that was read is relative to the page
+	assert.EqualValues(t, "wrapper, wrapping payload", identifyWith(t, magic, []byte("WRAP\x02.PAY")))
+
+	// without /r, it's relative to the start of the target
+	magic = strings.Replace(magic, "indirect/r", "indirect", 1)
+	assert.EqualValues(t, "wrapper", identifyWith(t, magic, []byte("WRAP\x02.PAY")))
+	assert.EqualValues(t, "wrapper, wrapping payload", identifyWith(t, magic, []byte("WRAP\x05PAY")))
+}
+
+func Test_IndirectKindDepth(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	R	again",
+		">1	indirect	x",
+	}, "\n")
+
+	// indirections nest until MaxIndirections
+	target := bytes.Repeat([]byte("R"), 64)
+	assert.EqualValues(t, wizardry.MaxIndirections+1, strings.Count(identifyWith(t, magic, target), "again"))
+}
@@ -1,8 +1,11 @@
 package wizinterpreter
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"sync"
+	"unicode/utf16"
 
 	"github.com/itchio/wizardry/wizardry"
 	"github.com/itchio/wizardry/wizardry/wizparser"
@@ -10,7 +13,7 @@ import (
 )
 
 // MaxLevels is the maximum level of magic rules that are interpreted
-const MaxLevels = 32
+const MaxLevels = wizardry.MaxLevels
 
 // LogFunc logs something somewhere
 type LogFunc func(format string, args ...interface{})
@@ -19,45 +22,262 @@ type LogFunc func(format string, args ...interface{})
 type InterpretContext struct {
 	Logf LogFunc
 	Book wizparser.Spellbook
+
+	// RankCandidates makes IdentifyResult evaluate every top-level rule,
+	// instead of stopping at the first one that prints something, and
+	// list them in Result.Candidates, best first.
+	RankCandidates bool
+
+	// the book is validated once, before the first identification
+	validateOnce sync.Once
+	validateErr  error
+}
+
+// identifyState holds what is computed lazily during a single identification
+type identifyState struct {
+	textInfo    *wizardry.TextInfo
+	indirection int
+	fields      wizardry.Fields
+
+	// candidates is nil unless candidates are ranked
+	candidates []wizardry.Candidate
+	// levelBase is the level of the rule that used the current page
+	levelBase int
+	// levels and bytes measure the current candidate, see wizardry.Candidate
+	levels int
+	bytes  int64
+
+	truncations []wizardry.Truncation
+
+	// guard enforces limits, and catches "use" rules nesting forever
+	guard *wizardry.Guard
+}
+
+// truncated records that a rule couldn't be evaluated because it needed to
+// read up to end, past the end of the target
+func (st *identifyState) truncated(rule wizparser.Rule, sr *wizutil.SliceReader, end int64) {
+	missing := end - sr.Size()
+	if missing <= 0 {
+		return
+	}
+	st.truncations = append(st.truncations, wizardry.Truncation{
+		Rule:    rule.Line,
+		Missing: missing,
+	})
+}
+
+// matched records that a rule matched, at a given depth, after verifying
+// a given number of bytes of the target
+func (st *identifyState) matched(level int, verifiedBytes int64) {
+	if depth := st.levelBase + level + 1; depth > st.levels {
+		st.levels = depth
+	}
+	st.bytes += verifiedBytes
+}
+
+// capture records a field for a rule that matched, see wizparser.FieldCapture
+func (st *identifyState) capture(capture wizparser.FieldCapture, descString string, value interface{}) {
+	if st.fields == nil {
+		return
+	}
+
+	var fieldValue string
+	switch {
+	case len(capture.Format) > 0:
+		fieldValue = string(capture.Format)
+		if value != nil {
+			fieldValue = wizardry.FormatDescription(fieldValue, value)
+		}
+	case descString != "":
+		fieldValue = wizardry.FieldValue(descString)
+	case value != nil:
+		fieldValue = fmt.Sprint(value)
+	}
+	st.fields.Add(capture.Name, fieldValue)
+}
+
+// captureMIME records the MIME type and extensions of a rule that matched
+func (st *identifyState) captureMIME(rule wizparser.Rule) {
+	if st.fields == nil {
+		return
+	}
+
+	st.fields.Add(wizardry.MIMEField, rule.MIME)
+	for _, ext := range rule.Extensions {
+		st.fields.Add(wizardry.ExtensionField, ext)
+	}
 }
 
-// Identify follows the rules in a spellbook to find out the type of a file
+func (st *identifyState) isText(sr *wizutil.SliceReader) bool {
+	if st.textInfo == nil {
+		st.textInfo = st.guard.ClassifyText(sr)
+	}
+	return st.textInfo.IsText()
+}
+
+// Identify follows the rules in a spellbook to find out the type of a target.
+// If no rule matches, the target is classified as text or binary data instead.
 func (ctx *InterpretContext) Identify(sr *wizutil.SliceReader) ([]string, error) {
-	outStrings, err := ctx.identifyInternal(sr, 0, "", false)
+	result, err := ctx.IdentifyResult(sr)
 	if err != nil {
 		return nil, err
 	}
+	return result.Strings, nil
+}
+
+// IdentifyResult is like Identify, but also returns the fields captured by
+// "!:field" annotations
+func (ctx *InterpretContext) IdentifyResult(sr *wizutil.SliceReader) (wizardry.Result, error) {
+	return ctx.IdentifyContext(context.Background(), sr, wizardry.Limits{})
+}
+
+// IdentifyContext is like IdentifyResult, but gives up when cctx is done,
+// or when identification exceeds the given limits. The error is then
+// either cctx's error, or a *wizardry.LimitError.
+func (ctx *InterpretContext) IdentifyContext(cctx context.Context, sr *wizutil.SliceReader, limits wizardry.Limits) (wizardry.Result, error) {
+	err := ctx.validate()
+	if err != nil {
+		return wizardry.Result{}, err
+	}
+
+	guard := wizardry.NewGuard(cctx, sr, limits)
+	sr = wizutil.NewSliceReader(guard, 0, sr.Size())
+
+	st := &identifyState{
+		fields: make(wizardry.Fields),
+		guard:  guard,
+	}
+	if ctx.RankCandidates {
+		st.candidates = []wizardry.Candidate{}
+	}
+
+	outStrings, err := ctx.identifyInternal(st, sr, 0, "", false)
+	if err != nil {
+		return wizardry.Result{}, err
+	}
+
+	if len(outStrings) == 0 {
+		if st.textInfo == nil {
+			st.textInfo = st.guard.ClassifyText(sr)
+		}
+		outStrings = append(outStrings, st.textInfo.String())
+	}
+
+	// reads fail silently once the guard has failed
+	if err := guard.Err(); err != nil {
+		return wizardry.Result{}, err
+	}
+
+	wizardry.SortCandidates(st.candidates)
+
+	var needMoreBytes int64
+	for _, truncation := range st.truncations {
+		if truncation.Missing > needMoreBytes {
+			needMoreBytes = truncation.Missing
+		}
+	}
+
+	return wizardry.Result{
+		Strings:       outStrings,
+		Fields:        st.fields,
+		Candidates:    st.candidates,
+		Truncations:   st.truncations,
+		NeedMoreBytes: needMoreBytes,
+	}, nil
+}
+
+// validate rejects malformed books before any rule is interpreted
+func (ctx *InterpretContext) validate() error {
+	ctx.validateOnce.Do(func() {
+		ctx.validateErr = ctx.Book.Validate()
+	})
+	return ctx.validateErr
+}
+
+func (ctx *InterpretContext) identifyInternal(st *identifyState, sr *wizutil.SliceReader, pageOffset int64, page string, swapEndian bool) ([]string, error) {
+	ctx.Logf("|====> identifying at %d using page %s (%d rules)", pageOffset, page, len(ctx.Book[page]))
+
+	outStrings, err := ctx.identifyRules(st, sr, pageOffset, ctx.Book[page], page != "", swapEndian)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.Logf("|====> done identifying at %d using page %s (%d rules)", pageOffset, page, len(ctx.Book[page]))
 
 	return outStrings, nil
 }
 
-func (ctx *InterpretContext) identifyInternal(sr *wizutil.SliceReader, pageOffset int64, page string, swapEndian bool) ([]string, error) {
+// identifyRules evaluates a list of rules in order. When inPage is true, the
+// rules are the body of a named page, and level 0 is considered matched.
+func (ctx *InterpretContext) identifyRules(st *identifyState, sr *wizutil.SliceReader, pageOffset int64, rules []wizparser.Rule, inPage bool, swapEndian bool) ([]string, error) {
 	var outStrings []string
 
 	matchedLevels := make([]bool, MaxLevels)
 	everMatchedLevels := make([]bool, MaxLevels)
-	globalOffset := int64(0)
-
-	ctx.Logf("|====> identifying at %d using page %s (%d rules)", pageOffset, page, len(ctx.Book[page]))
+	// where the last match ended, per level
+	levelOffsets := make([]int64, MaxLevels)
 
-	if page != "" {
+	if inPage {
 		matchedLevels[0] = true
 		everMatchedLevels[0] = true
 	}
 
-	for _, rule := range ctx.Book[page] {
-		stopProcessing := false
+	// when ranking, every top-level rule is a candidate with its own strings
+	// and fields. Only the first one that prints something makes it into the
+	// output, just like when we're not ranking.
+	ranking := !inPage && st.candidates != nil
+	var candidate *wizardry.Candidate
+	candidateStart := 0
+	fields := st.fields
+
+	endCandidate := func() {
+		if candidate == nil {
+			return
+		}
 
-		// if any of the deeper levels have ever matched, stop working
-		for l := rule.Level + 1; l < len(matchedLevels); l++ {
-			if everMatchedLevels[l] {
-				stopProcessing = true
-				break
+		st.fields = fields
+		candidateStrings := outStrings[candidateStart:]
+		if candidateStart == 0 {
+			for name, values := range candidate.Fields {
+				for _, value := range values {
+					fields.Add(name, value)
+				}
 			}
+		} else {
+			outStrings = outStrings[:candidateStart]
 		}
 
-		if stopProcessing {
-			break
+		if len(candidateStrings) > 0 {
+			candidate.Strings = append([]string(nil), candidateStrings...)
+			candidate.Levels = st.levels
+			candidate.Bytes = st.bytes
+			candidate.Score = wizardry.CandidateScore(candidate.Strength, candidate.Levels, candidate.Bytes)
+			st.candidates = append(st.candidates, *candidate)
+		}
+		candidate = nil
+	}
+
+	for _, rule := range rules {
+		if err := st.guard.Err(); err != nil {
+			return nil, err
+		}
+
+		if !inPage && rule.Level == 0 {
+			if ranking {
+				endCandidate()
+				candidate = &wizardry.Candidate{
+					Strength: rule.Strength(),
+					Fields:   make(wizardry.Fields),
+				}
+				candidateStart = len(outStrings)
+				st.fields = candidate.Fields
+				st.levels = 0
+				st.bytes = 0
+			} else if len(outStrings) > 0 {
+				// the first top-level rule that prints something wins, like
+				// in libmagic without --keep-going
+				break
+			}
 		}
 
 		skipRule := false
@@ -73,8 +293,19 @@ func (ctx *InterpretContext) identifyInternal(sr *wizutil.SliceReader, pageOffse
 			continue
 		}
 
+		// the rule isn't matched until it succeeds: skipping it for a bad
+		// offset or a read error must not let its children run
+		matchedLevels[rule.Level] = false
+
 		lookupOffset := int64(0)
 
+		// relative offsets are counted from the end of the parent's match,
+		// or from the page offset at the top
+		parentOffset := pageOffset
+		if rule.Level > 0 {
+			parentOffset = levelOffsets[rule.Level-1]
+		}
+
 		ctx.Logf("| %s", rule)
 
 		switch rule.Offset.OffsetType {
@@ -83,12 +314,18 @@ func (ctx *InterpretContext) identifyInternal(sr *wizutil.SliceReader, pageOffse
 			offsetAddress := indirect.OffsetAddress
 
 			if indirect.IsRelative {
-				offsetAddress += int64(globalOffset)
+				offsetAddress += parentOffset
+			} else if offsetAddress < 0 {
+				// relative to the end of the target
+				offsetAddress += sr.Size()
 			}
 
-			readAddress, err := readAnyUint(sr, int(offsetAddress), indirect.ByteWidth, indirect.Endianness.MaybeSwapped(swapEndian))
+			readAddress, err := readIndirect(sr, int(offsetAddress), indirect, indirect.Endianness.MaybeSwapped(swapEndian))
 			if err != nil {
 				ctx.Logf("Error while dereferencing: %s - skipping rule", err.Error())
+				if err == io.EOF && offsetAddress >= 0 {
+					st.truncated(rule, sr, offsetAddress+indirectWidth(indirect))
+				}
 				continue
 			}
 			lookupOffset = int64(readAddress)
@@ -96,132 +333,223 @@ func (ctx *InterpretContext) identifyInternal(sr *wizutil.SliceReader, pageOffse
 			offsetAdjustValue := indirect.OffsetAdjustmentValue
 			if indirect.OffsetAdjustmentIsRelative {
 				offsetAdjustAddress := int64(offsetAddress) + offsetAdjustValue
-				readAdjustAddress, err := readAnyUint(sr, int(offsetAdjustAddress), indirect.ByteWidth, indirect.Endianness)
+				readAdjustAddress, err := readIndirect(sr, int(offsetAdjustAddress), indirect, indirect.Endianness.MaybeSwapped(swapEndian))
 				if err != nil {
 					ctx.Logf("Error while dereferencing: %s - skipping rule", err.Error())
+					if err == io.EOF && offsetAdjustAddress >= 0 {
+						st.truncated(rule, sr, offsetAdjustAddress+indirectWidth(indirect))
+					}
 					continue
 				}
 				offsetAdjustValue = int64(readAdjustAddress)
 			}
 
-			switch indirect.OffsetAdjustmentType {
-			case wizparser.AdjustmentAdd:
-				lookupOffset = lookupOffset + offsetAdjustValue
-			case wizparser.AdjustmentSub:
-				lookupOffset = lookupOffset - offsetAdjustValue
-			case wizparser.AdjustmentMul:
-				lookupOffset = lookupOffset * offsetAdjustValue
-			case wizparser.AdjustmentDiv:
-				lookupOffset = lookupOffset / offsetAdjustValue
+			if !indirect.OffsetAdjustmentType.CanApply(offsetAdjustValue) {
+				ctx.Logf("indirect offset divides by zero - skipping rule")
+				continue
 			}
 
+			lookupOffset = indirect.OffsetAdjustmentType.Apply(lookupOffset, offsetAdjustValue)
+
 		case wizparser.OffsetTypeDirect:
-			lookupOffset = rule.Offset.Direct + pageOffset
+			if rule.Offset.IsRelative {
+				lookupOffset = parentOffset + rule.Offset.Direct
+			} else if rule.Offset.Direct < 0 {
+				// relative to the end of the target
+				lookupOffset = sr.Size() + rule.Offset.Direct
+			} else {
+				lookupOffset = rule.Offset.Direct + pageOffset
+			}
 		}
 
-		if rule.Offset.IsRelative {
-			lookupOffset += globalOffset
+		if rule.Offset.IsRelative && rule.Offset.OffsetType == wizparser.OffsetTypeIndirect {
+			lookupOffset += parentOffset
 		}
 
 		if lookupOffset < 0 || lookupOffset >= sr.Size() {
 			ctx.Logf("we done goofed, lookupOffset %d is out of bounds, skipping %#v", lookupOffset, rule)
+			if lookupOffset >= 0 {
+				st.truncated(rule, sr, lookupOffset+readSize(rule))
+			}
 			continue
 		}
 
 		success := false
+		// where the match ends, for children with relative offsets
+		globalOffset := lookupOffset
+
+		// value is what the rule read, to be printed in its description
+		var value interface{}
+		// trailingStrings are printed after the rule's description
+		var trailingStrings []string
+		// verified is how many bytes were compared against the rule's pattern
+		var verified int64
 
 		switch rule.Kind.Family {
 		case wizparser.KindFamilyInteger:
 			ik, _ := rule.Kind.Data.(*wizparser.IntegerKind)
 
-			if ik.MatchAny {
+			if ik.MatchAny && !rule.NeedsValue() {
 				success = true
+				globalOffset = lookupOffset + int64(ik.ByteWidth)
 			} else {
-				targetValue, err := readAnyUint(sr, int(lookupOffset), ik.ByteWidth, ik.Endianness)
+				targetValue, err := readAnyUint(sr, int(lookupOffset), ik.ByteWidth, ik.Endianness.MaybeSwapped(swapEndian))
 				if err != nil {
 					ctx.Logf("in integer test, while reading target value: %s", err.Error())
+					if err == io.EOF {
+						st.truncated(rule, sr, lookupOffset+int64(ik.ByteWidth))
+					}
 					continue
 				}
 
-				if ik.DoAnd {
-					targetValue &= ik.AndValue
-				}
+				targetValue = adjustInteger(ik, targetValue)
+				success = ik.MatchAny || integerTestMatches(ik, targetValue)
+				value = integerValue(ik, targetValue)
 
-				switch ik.AdjustmentType {
-				case wizparser.AdjustmentAdd:
-					targetValue = uint64(int64(targetValue) + ik.AdjustmentValue)
-				case wizparser.AdjustmentSub:
-					targetValue = uint64(int64(targetValue) - ik.AdjustmentValue)
-				case wizparser.AdjustmentMul:
-					targetValue = uint64(int64(targetValue) * ik.AdjustmentValue)
-				case wizparser.AdjustmentDiv:
-					targetValue = uint64(int64(targetValue) / ik.AdjustmentValue)
+				if success {
+					globalOffset = lookupOffset + int64(ik.ByteWidth)
+					if !ik.MatchAny {
+						verified = int64(ik.ByteWidth)
+					}
 				}
+			}
 
-				switch ik.IntegerTest {
-				case wizparser.IntegerTestEqual:
-					success = targetValue == uint64(ik.Value)
-				case wizparser.IntegerTestNotEqual:
-					success = targetValue != uint64(ik.Value)
-				case wizparser.IntegerTestLessThan:
-					if ik.Signed {
-						switch ik.ByteWidth {
-						case 1:
-							success = int8(targetValue) < int8(ik.Value)
-						case 2:
-							success = int16(targetValue) < int16(ik.Value)
-						case 4:
-							success = int32(targetValue) < int32(ik.Value)
-						case 8:
-							success = int64(targetValue) < int64(ik.Value)
-						}
-					} else {
-						success = targetValue < uint64(ik.Value)
-					}
-				case wizparser.IntegerTestGreaterThan:
-					if ik.Signed {
-						switch ik.ByteWidth {
-						case 1:
-							success = int8(targetValue) > int8(ik.Value)
-						case 2:
-							success = int16(targetValue) > int16(ik.Value)
-						case 4:
-							success = int32(targetValue) > int32(ik.Value)
-						case 8:
-							success = int64(targetValue) > int64(ik.Value)
-						}
-					} else {
-						success = targetValue > uint64(ik.Value)
+		case wizparser.KindFamilyOffset:
+			ik, _ := rule.Kind.Data.(*wizparser.IntegerKind)
+
+			targetValue := adjustInteger(ik, uint64(lookupOffset))
+			success = ik.MatchAny || integerTestMatches(ik, targetValue)
+			value = targetValue
+
+		case wizparser.KindFamilyString:
+			sk, _ := rule.Kind.Data.(*wizparser.StringKind)
+
+			if !sk.Flags.AppliesTo(st.isText(sr)) {
+				ctx.Logf("string test doesn't apply to this kind of target")
+				break
+			}
+
+			stringValue := wizardry.ReadString(sr, lookupOffset, wizardry.MaxStringLen)
+			value = sk.Flags.Printable(stringValue)
+
+			matchLen := int64(len(stringValue))
+			if sk.MatchAny {
+				success = true
+			} else {
+				testLen := wizardry.StringTestWith(sr, lookupOffset, string(sk.Value), sk.Flags, sk.Operator)
+				success = testLen >= 0
+
+				if sk.Negate {
+					success = !success
+					break
+				}
+				if sk.Operator == wizardry.StringEqual {
+					matchLen = testLen
+					if !success && stringTruncated(sr, lookupOffset, sk) {
+						st.truncated(rule, sr, lookupOffset+int64(len(sk.Value)))
 					}
 				}
+				verified = testLen
+			}
 
-				if success {
-					globalOffset = lookupOffset + int64(ik.ByteWidth)
+			if success {
+				globalOffset = lookupOffset
+				if sk.Flags&wizardry.NoOffsetUpdate == 0 {
+					globalOffset += matchLen
 				}
 			}
 
-		case wizparser.KindFamilyString:
-			sk, _ := rule.Kind.Data.(*wizparser.StringKind)
+		case wizparser.KindFamilyString16:
+			sk, _ := rule.Kind.Data.(*wizparser.String16Kind)
+			bigEndian := sk.Endianness.MaybeSwapped(swapEndian) == wizparser.BigEndian
 
-			matchLen := wizardry.StringTest(sr, lookupOffset, string(sk.Value), sk.Flags)
-			success = matchLen >= 0
+			stringValue, stringLen := wizardry.ReadString16(sr, lookupOffset, wizardry.MaxStringLen, bigEndian)
+			value = stringValue
 
-			if sk.Negate {
-				success = !success
+			if sk.MatchAny {
+				success = true
+				globalOffset = lookupOffset + stringLen
 			} else {
-				if success {
-					globalOffset = lookupOffset + int64(matchLen)
+				matchLen := wizardry.String16Test(sr, lookupOffset, string(sk.Value), bigEndian)
+				success = matchLen >= 0
+				if !success && !sk.Negate && string16Truncated(sr, lookupOffset, sk, bigEndian) {
+					st.truncated(rule, sr, lookupOffset+int64(len(utf16.Encode([]rune(string(sk.Value))))*2))
+				}
+
+				if sk.Negate {
+					success = !success
+				} else {
+					if success {
+						globalOffset = lookupOffset + matchLen
+						verified = matchLen
+					}
 				}
 			}
 
 		case wizparser.KindFamilySearch:
 			sk, _ := rule.Kind.Data.(*wizparser.SearchKind)
 
-			matchPos := wizardry.SearchTest(sr, lookupOffset, sk.MaxLen, string(sk.Value))
+			if !sk.Flags.AppliesTo(st.isText(sr)) {
+				ctx.Logf("search test doesn't apply to this kind of target")
+				break
+			}
+
+			matchPos, matchLen := wizardry.SearchTestWith(sr, lookupOffset, sk.MaxLen, string(sk.Value), sk.Flags)
 			success = matchPos >= 0
+			if !success {
+				if end, ok := searchTruncated(sr, lookupOffset, sk); ok {
+					st.truncated(rule, sr, end)
+				}
+			}
+
+			if success {
+				globalOffset = lookupOffset + matchPos
+				verified = matchLen
+				if sk.Flags&wizardry.NoOffsetUpdate == 0 {
+					globalOffset += matchLen
+				}
+			}
+
+		case wizparser.KindFamilyGUID:
+			gk, _ := rule.Kind.Data.(*wizparser.GUIDKind)
+
+			guidValue, ok := wizardry.ReadGUID(sr, lookupOffset)
+			if !ok {
+				st.truncated(rule, sr, lookupOffset+wizardry.GUIDSize)
+				break
+			}
+			value = guidValue
+
+			success = gk.MatchAny || guidValue == wizardry.FormatGUID(gk.Value)
+			if success {
+				globalOffset = lookupOffset + wizardry.GUIDSize
+				if !gk.MatchAny {
+					verified = wizardry.GUIDSize
+				}
+			}
+
+		case wizparser.KindFamilyDER:
+			dk, _ := rule.Kind.Data.(*wizparser.DERKind)
+
+			// relative rules that follow look inside the element
+			contentOffset, derValue := wizardry.DERTest(sr, lookupOffset, dk.Test)
+			success = contentOffset >= 0
+			if success {
+				value = derValue
+				globalOffset = lookupOffset + contentOffset
+				// the element's header
+				verified = contentOffset
+			}
+
+		case wizparser.KindFamilyCustom:
+			ck, _ := rule.Kind.Data.(*wizparser.CustomKind)
 
+			result := ck.Definition.Evaluate(sr, lookupOffset, ck.Data)
+			success = result.Matched
 			if success {
-				globalOffset = lookupOffset + matchPos + int64(len(sk.Value))
+				value = result.Value
+				globalOffset = lookupOffset + result.Length
+				verified = result.Length
 			}
 
 		case wizparser.KindFamilyDefault:
@@ -235,36 +563,288 @@ func (ctx *InterpretContext) identifyInternal(sr *wizutil.SliceReader, pageOffse
 
 			ctx.Logf("|====> using %s", uk.Page)
 
-			subStrings, err := ctx.identifyInternal(sr, lookupOffset, uk.Page, uk.SwapEndian)
+			// the page's rules are nested under this one
+			levelBase := st.levelBase
+			st.levelBase += rule.Level
+
+			if !st.guard.EnterUse() {
+				return nil, st.guard.Err()
+			}
+
+			// swapping twice gets us back to the original endianness
+			subStrings, err := ctx.identifyInternal(st, sr, lookupOffset, uk.Page, uk.SwapEndian != swapEndian)
+			st.guard.ExitUse()
+			st.levelBase = levelBase
 			if err != nil {
 				return nil, err
 			}
+
+			// like in libmagic, a use only matches if its page did - and the
+			// page's output comes before the description
+			success = len(subStrings) > 0
 			outStrings = append(outStrings, subStrings...)
 
+		case wizparser.KindFamilyName:
+			success = true
+
+		case wizparser.KindFamilyIndirect:
+			ik, _ := rule.Kind.Data.(*wizparser.IndirectKind)
+
+			if st.indirection >= wizardry.MaxIndirections {
+				ctx.Logf("|====> too many indirections, skipping")
+				break
+			}
+
+			indirectOffset := lookupOffset
+			if ik.Relative && rule.Offset.OffsetType == wizparser.OffsetTypeIndirect {
+				// direct offsets already include the page offset
+				indirectOffset += pageOffset
+			}
+			if indirectOffset <= 0 || indirectOffset >= sr.Size() {
+				// identifying from the very same offset would never end
+				break
+			}
+
+			ctx.Logf("|====> indirect at %d", indirectOffset)
+
+			subState := &identifyState{
+				indirection: st.indirection + 1,
+				fields:      st.fields,
+				guard:       st.guard,
+			}
+			subStrings, err := ctx.identifyInternal(subState, sr.Slice(indirectOffset), 0, "", false)
+			if err != nil {
+				return nil, err
+			}
+			verified = subState.bytes
+			st.truncations = append(st.truncations, subState.truncations...)
+
+			// the description is printed before what was found
+			success = len(subStrings) > 0
+			value = indirectOffset
+			trailingStrings = subStrings
+
 		case wizparser.KindFamilyClear:
 			everMatchedLevels[rule.Level] = false
 		}
 
 		if success {
 			descString := string(rule.Description)
+			if value != nil {
+				descString = wizardry.FormatDescription(descString, value)
+			}
 
 			ctx.Logf("|==========> rule matched!")
 
 			if descString != "" {
 				outStrings = append(outStrings, descString)
 			}
+			for _, capture := range rule.Fields {
+				st.capture(capture, descString, value)
+			}
+			st.captureMIME(rule)
+			outStrings = append(outStrings, trailingStrings...)
+			st.matched(rule.Level, verified)
 			matchedLevels[rule.Level] = true
 			everMatchedLevels[rule.Level] = true
+			levelOffsets[rule.Level] = globalOffset
 		} else {
 			matchedLevels[rule.Level] = false
 		}
 	}
 
-	ctx.Logf("|====> done identifying at %d using page %s (%d rules)", pageOffset, page, len(ctx.Book[page]))
+	if ranking {
+		endCandidate()
+	}
 
 	return outStrings, nil
 }
 
+// readSize returns how many bytes a rule reads at its offset, at least
+func readSize(rule wizparser.Rule) int64 {
+	switch rule.Kind.Family {
+	case wizparser.KindFamilyDefault, wizparser.KindFamilyClear, wizparser.KindFamilyName, wizparser.KindFamilyOffset:
+		// those don't read anything
+		return 0
+	case wizparser.KindFamilyInteger:
+		ik, _ := rule.Kind.Data.(*wizparser.IntegerKind)
+		return int64(ik.ByteWidth)
+	case wizparser.KindFamilyString:
+		sk, _ := rule.Kind.Data.(*wizparser.StringKind)
+		if !sk.MatchAny && len(sk.Value) > 0 {
+			return int64(len(sk.Value))
+		}
+	case wizparser.KindFamilyGUID:
+		return wizardry.GUIDSize
+	}
+	return 1
+}
+
+// indirectWidth returns how many bytes an indirect offset reads
+func indirectWidth(indirect *wizparser.IndirectOffset) int64 {
+	switch indirect.Format {
+	case wizparser.IndirectFormatID3, wizparser.IndirectFormatMiddleEndian:
+		return 4
+	}
+	return int64(indirect.ByteWidth)
+}
+
+// stringTruncated returns true if a string pattern goes past the end of the
+// target, and what the target has of it matches
+func stringTruncated(sr *wizutil.SliceReader, lookupOffset int64, sk *wizparser.StringKind) bool {
+	available := sr.Size() - lookupOffset
+	if int64(len(sk.Value)) <= available {
+		return false
+	}
+	return wizardry.StringTestWith(sr, lookupOffset, string(sk.Value[:available]), sk.Flags, wizardry.StringEqual) >= 0
+}
+
+// string16Truncated is like stringTruncated, for UTF-16 patterns
+func string16Truncated(sr *wizutil.SliceReader, lookupOffset int64, sk *wizparser.String16Kind, bigEndian bool) bool {
+	pattern := []rune(string(sk.Value))
+	available := (sr.Size() - lookupOffset) / 2
+	if int64(len(pattern)) <= available {
+		return false
+	}
+	if available == 0 {
+		// there's nothing to compare yet
+		return true
+	}
+	return wizardry.String16Test(sr, lookupOffset, string(pattern[:available]), bigEndian) >= 0
+}
+
+// searchTruncated returns true if a search window runs past the end of the
+// target, and the target ends with a prefix of the pattern - along with where
+// the shortest such match would end
+func searchTruncated(sr *wizutil.SliceReader, lookupOffset int64, sk *wizparser.SearchKind) (int64, bool) {
+	windowEnd := lookupOffset + sk.MaxLen
+	if windowEnd <= sr.Size() {
+		return 0, false
+	}
+
+	for prefixLen := int64(len(sk.Value)) - 1; prefixLen > 0; prefixLen-- {
+		pos := sr.Size() - prefixLen
+		if pos < lookupOffset {
+			continue
+		}
+		end := pos + int64(len(sk.Value))
+		if end > windowEnd {
+			break
+		}
+		if wizardry.StringTestWith(sr, pos, string(sk.Value[:prefixLen]), sk.Flags, wizardry.StringEqual) >= 0 {
+			return end, true
+		}
+	}
+	return 0, false
+}
+
+// adjustInteger applies an integer kind's mask and arithmetic to a value read from the target
+func adjustInteger(ik *wizparser.IntegerKind, targetValue uint64) uint64 {
+	if ik.DoAnd {
+		targetValue &= ik.AndValue
+	}
+
+	targetValue = uint64(ik.AdjustmentType.Apply(int64(targetValue), ik.AdjustmentValue))
+
+	if ik.Invert {
+		targetValue = ^targetValue
+		if ik.ByteWidth < 8 {
+			targetValue &= (1 << uint(ik.ByteWidth*8)) - 1
+		}
+	}
+
+	return targetValue
+}
+
+// integerTestMatches compares an (adjusted) value against an integer kind's magic value
+func integerTestMatches(ik *wizparser.IntegerKind, targetValue uint64) bool {
+	switch ik.IntegerTest {
+	case wizparser.IntegerTestEqual:
+		return targetValue == uint64(ik.Value)
+	case wizparser.IntegerTestNotEqual:
+		return targetValue != uint64(ik.Value)
+	case wizparser.IntegerTestLessThan:
+		if ik.Signed {
+			switch ik.ByteWidth {
+			case 1:
+				return int8(targetValue) < int8(ik.Value)
+			case 2:
+				return int16(targetValue) < int16(ik.Value)
+			case 4:
+				return int32(targetValue) < int32(ik.Value)
+			case 8:
+				return int64(targetValue) < int64(ik.Value)
+			}
+		}
+		return targetValue < uint64(ik.Value)
+	case wizparser.IntegerTestGreaterThan:
+		if ik.Signed {
+			switch ik.ByteWidth {
+			case 1:
+				return int8(targetValue) > int8(ik.Value)
+			case 2:
+				return int16(targetValue) > int16(ik.Value)
+			case 4:
+				return int32(targetValue) > int32(ik.Value)
+			case 8:
+				return int64(targetValue) > int64(ik.Value)
+			}
+		}
+		return targetValue > uint64(ik.Value)
+	case wizparser.IntegerTestAnd:
+		return targetValue&uint64(ik.Value) == uint64(ik.Value)
+	case wizparser.IntegerTestBitsClear:
+		return targetValue&uint64(ik.Value) != uint64(ik.Value)
+	}
+	return false
+}
+
+// integerValue returns a value suitable for printing, sign-extended if needed
+func integerValue(ik *wizparser.IntegerKind, targetValue uint64) interface{} {
+	if !ik.Signed {
+		return targetValue
+	}
+
+	switch ik.ByteWidth {
+	case 1:
+		return int64(int8(targetValue))
+	case 2:
+		return int64(int16(targetValue))
+	case 4:
+		return int64(int32(targetValue))
+	}
+	return int64(targetValue)
+}
+
+// readIndirect reads the integer an indirect offset points to, decoding it
+// according to the offset's format
+func readIndirect(sr *wizutil.SliceReader, j int, indirect *wizparser.IndirectOffset, endianness wizparser.Endianness) (uint64, error) {
+	switch indirect.Format {
+	case wizparser.IndirectFormatID3:
+		ret, err := readAnyUint(sr, j, 4, endianness)
+		if err != nil {
+			return 0, err
+		}
+		return wizardry.DecodeID3(ret), nil
+	case wizparser.IndirectFormatMiddleEndian:
+		if int64(j+4) > sr.Size() {
+			return 0, io.EOF
+		}
+
+		intBytes := make([]byte, 4)
+		n, err := sr.ReadAt(intBytes, int64(j))
+		if n < 4 {
+			if err != nil && err != io.EOF {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		return uint64(wizardry.MiddleEndianUint32(intBytes)), nil
+	default:
+		return readAnyUint(sr, j, indirect.ByteWidth, endianness)
+	}
+}
+
 func readAnyUint(sr *wizutil.SliceReader, j int, byteWidth int, endianness wizparser.Endianness) (uint64, error) {
 	if int64(j+byteWidth) > sr.Size() {
 		return 0, io.EOF
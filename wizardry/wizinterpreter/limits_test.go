@@ -0,0 +1,100 @@
+package wizinterpreter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/itchio/wizardry/wizardry"
+	"github.com/stretchr/testify/assert"
+)
+
+func identifyLimited(t *testing.T, cctx context.Context, magic string, target []byte, limits wizardry.Limits) (wizardry.Result, error) {
+	return contextWith(t, magic).IdentifyContext(cctx, targetReader(target), limits)
+}
+
+func Test_UseRecursionLimit(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	name	forever",
+		">0	byte	x	again",
+		">0	use	forever",
+		"",
+		"0	string	LOOP	loop",
+		">4	use	forever",
+	}, "\n")
+	target := []byte("LOOP\x01")
+
+	_, err := identifyLimited(t, context.Background(), magic, target, wizardry.Limits{})
+	if assert.IsType(t, &wizardry.LimitError{}, err) {
+		assert.EqualValues(t, "MaxUseDepth", err.(*wizardry.LimitError).Limit)
+		assert.EqualValues(t, wizardry.MaxUseDepth, err.(*wizardry.LimitError).Value)
+	}
+
+	_, err = identifyLimited(t, context.Background(), magic, target, wizardry.Limits{MaxUseDepth: 3})
+	if assert.IsType(t, &wizardry.LimitError{}, err) {
+		assert.EqualValues(t, 3, err.(*wizardry.LimitError).Value)
+	}
+}
+
+func Test_ReadLimit(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	ABCD	abcd",
+		">4	lelong	x	\\b, value %d",
+	}, "\n")
+	target := []byte("ABCD\x01\x00\x00\x00")
+
+	result, err := identifyLimited(t, context.Background(), magic, target, wizardry.Limits{MaxBytesRead: 64})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"abcd", "\\b, value 1"}, result.Strings)
+
+	_, err = identifyLimited(t, context.Background(), magic, target, wizardry.Limits{MaxBytesRead: 5})
+	if assert.IsType(t, &wizardry.LimitError{}, err) {
+		assert.EqualValues(t, "MaxBytesRead", err.(*wizardry.LimitError).Limit)
+	}
+}
+
+func Test_ReadLimitText(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string/t	all	text starting with all",
+		"0	string	\\x00\\x01	binary",
+	}, "\n")
+	target := bytes.Repeat([]byte("all work and no play\n"), 6*1024)
+	limits := wizardry.Limits{MaxBytesRead: 64 * 1024}
+
+	// classifying text doesn't count against the limit
+	result, err := identifyLimited(t, context.Background(), magic, target, limits)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"text starting with all"}, result.Strings)
+
+	target[0] = 'b'
+	result, err = identifyLimited(t, context.Background(), magic, target, limits)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"ASCII text"}, result.Strings)
+}
+
+func Test_Canceled(t *testing.T) {
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := identifyLimited(t, cctx, "0	string	ABCD	abcd", []byte("ABCD"), wizardry.Limits{})
+	assert.Equal(t, context.Canceled, err)
+}
+
+func Test_DivisionByZero(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	DIV	div",
+		">(4.l/(4))	byte	x	\\b, at %d",
+		">(4.l/0)	byte	x	never parsed",
+		">4	lelong/0	x	never parsed",
+	}, "\n")
+
+	// the divisor read from the target is zero
+	result, err := identifyLimited(t, context.Background(), magic, []byte("DIV\x00\x08\x00\x00\x00\x00\x00\x00\x00"), wizardry.Limits{})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"div"}, result.Strings)
+
+	result, err = identifyLimited(t, context.Background(), magic, []byte("DIV\x00\x08\x00\x00\x00\x02\x00\x00\x00"), wizardry.Limits{})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"div", "\\b, at 8"}, result.Strings)
+}
@@ -0,0 +1,41 @@
+package wizinterpreter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NegativeOffset(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	HEAD	archive",
+		">-4	string	TAIL	\\b, with a trailer",
+		">>-5	byte	x	\\b, version %d",
+		">(-9.l)	string	DIR	\\b, with a directory",
+		">-100	byte	x	\\b, never",
+	}, "\n")
+
+	target := []byte("HEAD..DIR...\x06\x00\x00\x00\x01TAIL")
+	assert.EqualValues(t, "archive, with a trailer, version 1, with a directory", identifyWith(t, magic, target))
+
+	// offsets from the end follow the size of the target
+	target = []byte("HEAD.DIR\x05\x00\x00\x00\x02TAIL")
+	assert.EqualValues(t, "archive, with a trailer, version 2, with a directory", identifyWith(t, magic, target))
+
+	target = []byte("HEAD.TAIL.")
+	assert.EqualValues(t, "archive", identifyWith(t, magic, target))
+}
+
+func Test_OffsetKind(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	ABC	abc",
+		">&0	offset	x	\\b, then at %d",
+		">-1	offset	>8	\\b, ends after 8",
+		">-1	offset	<9	\\b, ends by 8",
+		">(3.b)	offset	x	\\b, points at %d",
+	}, "\n")
+
+	assert.EqualValues(t, "abc, then at 3, ends by 8, points at 5", identifyWith(t, magic, []byte("ABC\x05.....")))
+	assert.EqualValues(t, "abc, then at 3, ends after 8, points at 7", identifyWith(t, magic, []byte("ABC\x07.......")))
+}
@@ -0,0 +1,77 @@
+package wizinterpreter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StringOperators(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	VER	version",
+		">3	string	x	%s",
+		">3	string	<2	\\b, old",
+		">3	string	>2	\\b, new",
+		">3	string	=2.0	\\b, exactly 2.0",
+		">3	string	!2.0	\\b, not 2.0",
+	}, "\n")
+
+	// values are printed up to their terminator
+	assert.EqualValues(t, "version 1.5, old, not 2.0", identifyWith(t, magic, []byte("VER1.5\x00junk")))
+	// only as many bytes as the pattern has are compared
+	assert.EqualValues(t, "version 2.0, exactly 2.0", identifyWith(t, magic, []byte("VER2.0\x00")))
+	// like file(1), a trailing newline isn't printed
+	assert.EqualValues(t, "version 10, old, not 2.0", identifyWith(t, magic, []byte("VER10\n")))
+	assert.EqualValues(t, "version 3, new, not 2.0", identifyWith(t, magic, []byte("VER3")))
+}
+
+func Test_StringMatchAny(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	NAME	named",
+		">4	string	x	%s",
+		">>&1	byte	x	\\b, then %c",
+	}, "\n")
+
+	// x matches whatever is there, and its match ends at the terminator
+	assert.EqualValues(t, "named bob, then !", identifyWith(t, magic, []byte("NAMEbob\x00!")))
+	assert.EqualValues(t, "named", identifyWith(t, magic, []byte("NAME")))
+}
+
+func Test_StringFlags(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	HDR	hdr",
+		">3	string/f	NAME	\\b, full word",
+		">3	string/s	NAME	\\b, name",
+		">>&0	string	NAME	\\b, at the start",
+		">3	string	NAME	\\b, name again",
+		">>&0	string	x	\\b, then %s",
+		"0	string	T:	trimmed",
+		">2	string/T	x	[%s]",
+	}, "\n")
+
+	// /s leaves the offset at the start of the match
+	assert.EqualValues(t, "hdr, full word, name, at the start, name again, then !", identifyWith(t, magic, []byte("HDRNAME!")))
+	assert.EqualValues(t, "hdr, name, at the start, name again, then S", identifyWith(t, magic, []byte("HDRNAMES")))
+	assert.EqualValues(t, "hdr, full word, name, at the start, name again", identifyWith(t, magic, []byte("HDRNAME")))
+	assert.EqualValues(t, "trimmed [a b]", identifyWith(t, magic, []byte("T:  a b\t\x00")))
+}
+
+func Test_SearchFlags(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	S	s",
+		">1	search/32/c	hello	\\b, lower matches both",
+		">>&0	byte	x	\\b, then %c",
+		">1	search/32/C	WORLD	\\b, upper matches both",
+		">1	search/32/w	a\\ b	\\b, optional blanks",
+		">1	search/32/W	c\\ d	\\b, compact whitespace",
+		">1	search/32/f	wor	\\b, full word",
+		">1	search/32/s	wor	\\b, wor",
+		">>&0	byte	x	\\b, then %c",
+	}, "\n")
+
+	assert.EqualValues(t, "s, lower matches both, then !, upper matches both, wor, then w",
+		identifyWith(t, magic, []byte("S..HeLLo! world")))
+	assert.EqualValues(t, "s, lower matches both, then ., optional blanks, compact whitespace, full word, wor, then w",
+		identifyWith(t, magic, []byte("SHELLO.ab c   d wor")))
+}
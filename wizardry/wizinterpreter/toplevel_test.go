@@ -0,0 +1,28 @@
+package wizinterpreter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FirstTopLevelWins(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	AB	ab",
+		">2	byte	1	\\b, one",
+		">>3	byte	2	\\b, two",
+		">3	byte	2	\\b, also two",
+		"0	string	X",
+		"0	byte	x	any byte",
+	}, "\n")
+
+	// siblings still run after a nested match
+	assert.EqualValues(t, "ab, one, two, also two", identifyWith(t, magic, []byte("AB\x01\x02")))
+
+	// later top-level rules don't, once one printed something
+	assert.EqualValues(t, "ab", identifyWith(t, magic, []byte("ABZZ")))
+
+	// a top-level rule that matched silently doesn't stop the search
+	assert.EqualValues(t, "any byte", identifyWith(t, magic, []byte("X")))
+}
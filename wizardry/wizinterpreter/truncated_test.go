@@ -0,0 +1,95 @@
+package wizinterpreter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Truncated(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	MZ	MS-DOS executable",
+		">0x3c	lelong	>0x40	with a new header",
+		">>(0x3c.l)	string	PE\\0\\0	PE",
+		"0	string	ELF64	some ELF",
+		"0	string	nope	never",
+	}, "\n")
+
+	// the new header offset is cut short
+	result := resultWith(t, magic, []byte("MZ\x00\x00"))
+	assert.EqualValues(t, []string{"MS-DOS executable"}, result.Strings)
+	assert.True(t, result.Truncated())
+	assert.EqualValues(t, 0x40-4, result.NeedMoreBytes)
+
+	// the new header is past the end
+	target := make([]byte, 0x40)
+	copy(target, "MZ")
+	target[0x3c] = 0x80
+	result = resultWith(t, magic, target)
+	assert.EqualValues(t, []string{"MS-DOS executable", "with a new header"}, result.Strings)
+	assert.True(t, result.Truncated())
+	assert.EqualValues(t, 0x80+4-0x40, result.NeedMoreBytes)
+
+	// a prefix of a pattern is truncated, a mismatch isn't
+	result = resultWith(t, magic, []byte("ELF"))
+	assert.True(t, result.Truncated())
+	assert.EqualValues(t, 2, result.NeedMoreBytes)
+	assert.Len(t, result.Truncations, 1)
+
+	result = resultWith(t, magic, []byte("XYZ"))
+	assert.False(t, result.Truncated())
+	assert.EqualValues(t, 0, result.NeedMoreBytes)
+}
+
+func Test_TruncatedString16(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	AB	ab",
+		">2	lestring16	hi	\\b, hi",
+	}, "\n")
+
+	// not even one code unit to compare
+	result := resultWith(t, magic, []byte("ABh"))
+	assert.True(t, result.Truncated())
+	assert.EqualValues(t, 3, result.NeedMoreBytes)
+
+	result = resultWith(t, magic, []byte("ABh\x00"))
+	assert.True(t, result.Truncated())
+	assert.EqualValues(t, 2, result.NeedMoreBytes)
+
+	result = resultWith(t, magic, []byte("ABx\x00"))
+	assert.False(t, result.Truncated())
+}
+
+func Test_TruncatedSearch(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	#!	script",
+		">2	search/4096	python	\\b, python",
+	}, "\n")
+
+	// a complete small file doesn't need more bytes
+	result := resultWith(t, magic, []byte("#!/bin/sh\necho hi\n"))
+	assert.EqualValues(t, []string{"script"}, result.Strings)
+	assert.False(t, result.Truncated())
+
+	// unless it ends with the start of the pattern
+	result = resultWith(t, magic, []byte("#!/usr/bin/pyt"))
+	assert.True(t, result.Truncated())
+	assert.EqualValues(t, 3, result.NeedMoreBytes)
+}
+
+func Test_SkippedParent(t *testing.T) {
+	magic := strings.Join([]string{
+		"0	string	AB	ab",
+		">2	byte	x	\\b, then %d",
+		">100	byte	1	\\b, far",
+		">>0	byte	x	\\b, never",
+		">(2.l)	byte	1	\\b, indirect",
+		">>0	byte	x	\\b, never either",
+		">(2.b/0)	byte	1	\\b, divided",
+		">>0	byte	x	\\b, never at all",
+	}, "\n")
+
+	// skipped rules don't keep the level matched by the rule before them
+	assert.EqualValues(t, "ab, then 3", identifyWith(t, magic, []byte("AB\x03")))
+}
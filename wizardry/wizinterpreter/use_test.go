@@ -0,0 +1,115 @@
+package wizinterpreter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pages shared by the use tests: "tag" reads a little-endian short and
+// prints it, "swapper" does the same then uses "tag" swapped again
+var useTestPages = strings.Join([]string{
+	"0	name	tag",
+	">0	leshort	x	tag=%x",
+	">2	string	END	\\b, end",
+	"",
+	"0	name	swapper",
+	">0	leshort	x	swapper=%x",
+	">2	use	\\^tag",
+	"",
+}, "\n")
+
+func Test_UseSwapped(t *testing.T) {
+	magic := useTestPages + strings.Join([]string{
+		"0	string	SWAP	swap",
+		">4	use	\\^tag",
+	}, "\n")
+
+	target := []byte("SWAP\x12\x34END")
+	assert.EqualValues(t, "swap tag=1234, end", identifyWith(t, magic, target))
+}
+
+func Test_UseNestedSwapped(t *testing.T) {
+	magic := useTestPages + strings.Join([]string{
+		"0	string	NEST	nest",
+		">4	use	swapper",
+		">4	use	\\^swapper",
+	}, "\n")
+
+	// swapping twice gets us back to little-endian
+	target := []byte("NEST\x12\x34\x56\x78END")
+	assert.EqualValues(t,
+		"nest swapper=3412 tag=5678, end swapper=1234 tag=7856, end",
+		identifyWith(t, magic, target))
+}
+
+func Test_UseRelative(t *testing.T) {
+	magic := useTestPages + strings.Join([]string{
+		"0	string	REL	relative",
+		">&1	use	tag",
+	}, "\n")
+
+	target := []byte("REL_\x01\x00END")
+	assert.EqualValues(t, "relative tag=1, end", identifyWith(t, magic, target))
+}
+
+func Test_UseIndirect(t *testing.T) {
+	magic := useTestPages + strings.Join([]string{
+		"0	string	IND	indirect",
+		">(3.b)	use	tag",
+	}, "\n")
+
+	target := []byte("IND\x08____\x02\x00END")
+	assert.EqualValues(t, "indirect tag=2, end", identifyWith(t, magic, target))
+}
+
+func Test_UseRelativeInPage(t *testing.T) {
+	// relative offsets in a page count from the parent's match,
+	// without adding the page offset again
+	magic := strings.Join([]string{
+		"0	name	chain",
+		">0	string	AB	ab",
+		">>&0	string	C	\\b, c",
+		">>>&0	string	D	\\b, d",
+		">&0	string	A	\\b, a again",
+		"",
+		"0	string	ZZ	zz",
+		">2	use	chain",
+	}, "\n")
+
+	target := []byte("ZZABCD")
+	assert.EqualValues(t, "zz ab, c, d, a again", identifyWith(t, magic, target))
+}
+
+func Test_UseDescription(t *testing.T) {
+	magic := useTestPages + strings.Join([]string{
+		"0	string	DESC	desc",
+		">4	use	tag	\\b, tagged",
+		">>6	string	END	\\b, and the end",
+		">4	use	missing	\\b, never printed",
+		">>6	string	END	\\b, never printed either",
+	}, "\n")
+
+	// the description of a use comes after what the page printed, and a
+	// use that prints nothing doesn't match
+	target := []byte("DESC\x01\x00END")
+	assert.EqualValues(t, "desc tag=1, end, tagged, and the end", identifyWith(t, magic, target))
+}
+
+func Test_UseNoMatch(t *testing.T) {
+	magic := useTestPages + strings.Join([]string{
+		"0	string	NONE	none",
+		">4	use	tag	\\b, tagged",
+		">>0	byte	x	\\b, never printed",
+		">4	byte	x	\\b, then %d",
+	}, "\n")
+
+	// like in libmagic, a use only matches if its page did, so neither its
+	// description nor its children show up when the page has nothing to say
+	target := []byte("NONE")
+	assert.EqualValues(t, "none", identifyWith(t, magic, target))
+
+	target = []byte("NONE\x05")
+	assert.EqualValues(t, "none, then 5", identifyWith(t, magic, target))
+}
@@ -1,8 +1,13 @@
 package wizparser
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -17,6 +22,78 @@ func (sb Spellbook) AddRule(page string, rule Rule) {
 	sb[page] = append(sb[page], rule)
 }
 
+// Fingerprint returns a hash of the spellbook's rules, which changes
+// whenever they do - when the magic files they're parsed from are edited,
+// for example, or when the Version of a custom kind they use changes.
+func (sb Spellbook) Fingerprint() string {
+	var pages []string
+	for page := range sb {
+		pages = append(pages, page)
+	}
+	sort.Strings(pages)
+
+	h := sha256.New()
+	for _, page := range pages {
+		fmt.Fprintf(h, "page %q\n", page)
+		for _, rule := range sb[page] {
+			// rule.String() leaves out details, rules made with the builder
+			// have nothing else in their Line
+			fmt.Fprintf(h, "rule %q %d %q\n", rule.Line, rule.Level, rule.Description)
+			fmt.Fprintf(h, "offset %s\n", canonicalOffset(rule.Offset))
+			fmt.Fprintf(h, "kind %d %s\n", rule.Kind.Family, canonicalKindData(rule.Kind.Data))
+			for _, capture := range rule.Fields {
+				fmt.Fprintf(h, "field %q %q\n", capture.Name, capture.Format)
+			}
+			if rule.StrengthAdjustment != nil {
+				fmt.Fprintf(h, "strength %s\n", rule.StrengthAdjustment)
+			}
+			fmt.Fprintf(h, "mime %q %q\n", rule.MIME, rule.Extensions)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// canonicalOffset formats all of an offset's fields, for Fingerprint
+func canonicalOffset(o Offset) string {
+	if o.Indirect == nil {
+		return fmt.Sprintf("%#v", o)
+	}
+	indirect := *o.Indirect
+	o.Indirect = nil
+	return fmt.Sprintf("%#v %#v", o, indirect)
+}
+
+// canonicalKindData formats all of a kind's data, for Fingerprint. Pointers
+// are followed, since their addresses change from one parse to the next.
+func canonicalKindData(data interface{}) string {
+	switch data := data.(type) {
+	case nil:
+		return "nil"
+	case *CustomKind:
+		// the data is whatever Parse made of the suffix and test, and that
+		// only changes along with the Version
+		return fmt.Sprintf("custom %q %q %q %q", data.Definition.Name, data.Definition.Version, data.Suffix, data.Test)
+	case *SwitchKind:
+		s := fmt.Sprintf("switch %d %d %t", data.ByteWidth, data.Endianness, data.Signed)
+		for _, c := range data.Cases {
+			s += fmt.Sprintf(" %#v", *c)
+		}
+		return s
+	case *StringSwitchKind:
+		s := "stringswitch"
+		for _, c := range data.Cases {
+			s += fmt.Sprintf(" %#v", *c)
+		}
+		return s
+	}
+
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	return fmt.Sprintf("%#v", v.Interface())
+}
+
 // Rule is a single magic rule
 type Rule struct {
 	Line        string
@@ -24,6 +101,42 @@ type Rule struct {
 	Offset      Offset
 	Kind        Kind
 	Description []byte
+	Fields      []FieldCapture
+	// StrengthAdjustment comes from a "!:strength" annotation, see Strength
+	StrengthAdjustment *StrengthAdjustment
+	// MIME comes from a "!:mime" annotation, and is captured in the
+	// wizardry.MIMEField field when the rule matches
+	MIME string
+	// Extensions come from a "!:ext" annotation, and are captured in the
+	// wizardry.ExtensionField field when the rule matches
+	Extensions []string
+}
+
+// FieldCapture stores a value in the result under Name when its rule
+// matches, from a "!:field name [format]" annotation
+type FieldCapture struct {
+	Name string
+	// Format is expanded with the value the rule read, like a description.
+	// If empty, the rule's description is captured instead, or the value
+	// itself for rules without a description.
+	Format []byte
+}
+
+// NeedsValue returns true if printing the rule's description, or capturing
+// its fields, requires the value the rule read from the target
+func (r Rule) NeedsValue() bool {
+	if bytes.IndexByte(r.Description, '%') >= 0 {
+		return true
+	}
+	for _, capture := range r.Fields {
+		if bytes.IndexByte(capture.Format, '%') >= 0 {
+			return true
+		}
+		if len(capture.Format) == 0 && len(r.Description) == 0 {
+			return true
+		}
+	}
+	return false
 }
 
 func (r Rule) String() string {
@@ -48,32 +161,32 @@ func (o Offset) String() string {
 		s += fmt.Sprintf("0x%x", indirect.OffsetAddress)
 		s += "."
 
-		switch indirect.ByteWidth {
-		case 1:
-			s += "byte"
-		case 2:
-			s += "short"
-		case 4:
+		switch indirect.Format {
+		case IndirectFormatID3:
+			s += "id3"
+		case IndirectFormatMiddleEndian:
 			s += "long"
-		case 8:
-			s += "quad"
+		default:
+			switch indirect.ByteWidth {
+			case 1:
+				s += "byte"
+			case 2:
+				s += "short"
+			case 4:
+				s += "long"
+			case 8:
+				s += "quad"
+			}
 		}
-		if indirect.Endianness == LittleEndian {
+		if indirect.Format == IndirectFormatMiddleEndian {
+			s += "me"
+		} else if indirect.Endianness == LittleEndian {
 			s += "le"
 		} else {
 			s += "be"
 		}
 
-		switch indirect.OffsetAdjustmentType {
-		case AdjustmentAdd:
-			s += "+"
-		case AdjustmentSub:
-			s += "-"
-		case AdjustmentMul:
-			s += "*"
-		case AdjustmentDiv:
-			s += "/"
-		}
+		s += indirect.OffsetAdjustmentType.String()
 
 		if indirect.OffsetAdjustmentType != AdjustmentNone {
 			if indirect.OffsetAdjustmentIsRelative {
@@ -141,11 +254,21 @@ func (o Offset) Equals(b Offset) bool {
 		return false
 	}
 
+	if ai.Format != bi.Format {
+		return false
+	}
+
 	return true
 }
 
 func (k Kind) String() string {
 	switch k.Family {
+	case KindFamilyOffset:
+		ik, _ := k.Data.(*IntegerKind)
+		if ik.MatchAny {
+			return "offset    x"
+		}
+		return fmt.Sprintf("offset    %x", ik.Value)
 	case KindFamilyInteger:
 		ik, _ := k.Data.(*IntegerKind)
 		s := ""
@@ -175,7 +298,42 @@ func (k Kind) String() string {
 		return s
 	case KindFamilyString:
 		sk, _ := k.Data.(*StringKind)
-		return fmt.Sprintf("string    %s", strconv.Quote(string(sk.Value)))
+		if sk.MatchAny {
+			return "string    x"
+		}
+		s := "string    "
+		if sk.Negate {
+			s += "!"
+		}
+		switch sk.Operator {
+		case wizardry.StringLessThan:
+			s += "<"
+		case wizardry.StringGreaterThan:
+			s += ">"
+		}
+		return s + strconv.Quote(string(sk.Value))
+	case KindFamilyString16:
+		sk, _ := k.Data.(*String16Kind)
+		s := "lestring16    "
+		if sk.Endianness == BigEndian {
+			s = "bestring16    "
+		}
+		if sk.MatchAny {
+			return s + "x"
+		}
+		if sk.Negate {
+			s += "!"
+		}
+		return s + strconv.Quote(string(sk.Value))
+	case KindFamilyGUID:
+		gk, _ := k.Data.(*GUIDKind)
+		if gk.MatchAny {
+			return "guid    x"
+		}
+		return fmt.Sprintf("guid    %s", wizardry.FormatGUID(gk.Value))
+	case KindFamilyDER:
+		dk, _ := k.Data.(*DERKind)
+		return fmt.Sprintf("der    %s", dk.Test)
 	case KindFamilySearch:
 		sk, _ := k.Data.(*SearchKind)
 		return fmt.Sprintf("search/0x%x    %s", sk.MaxLen, strconv.Quote(string(sk.Value)))
@@ -191,9 +349,21 @@ func (k Kind) String() string {
 		}
 		s += uk.Page
 		return s
+	case KindFamilyIndirect:
+		ik, _ := k.Data.(*IndirectKind)
+		if ik.Relative {
+			return "indirect/r"
+		}
+		return "indirect"
+	case KindFamilyCustom:
+		ck, _ := k.Data.(*CustomKind)
+		return fmt.Sprintf("%s%s    %s", ck.Definition.Name, ck.Suffix, ck.Test)
 	case KindFamilySwitch:
 		sk, _ := k.Data.(*SwitchKind)
 		return fmt.Sprintf("switch with %d cases", len(sk.Cases))
+	case KindFamilyStringSwitch:
+		sk, _ := k.Data.(*StringSwitchKind)
+		return fmt.Sprintf("string switch with %d cases", len(sk.Cases))
 	default:
 		return fmt.Sprintf("kind family %d", k.Family)
 	}
@@ -258,6 +428,8 @@ type IntegerKind struct {
 	MatchAny        bool
 	AdjustmentType  Adjustment
 	AdjustmentValue int64
+	// Invert is true if the value read is bit-negated ("~") before testing
+	Invert bool
 }
 
 type SwitchKind struct {
@@ -272,6 +444,21 @@ type SwitchCase struct {
 	Description []byte
 }
 
+// StringSwitchKind describes the patterns of merged string tests. Every
+// case is still tested on its own, but only if the target starts with
+// one of its prefixes.
+type StringSwitchKind struct {
+	Cases []*StringSwitchCase
+}
+
+type StringSwitchCase struct {
+	Value []byte
+	Flags wizardry.StringTestFlags
+	// Prefixes are what the target must start with for the pattern to match,
+	// given the flags - see wizardry.StringTestWith
+	Prefixes []string
+}
+
 // IntegerTest describes which comparison to perform on an integer
 type IntegerTest int
 
@@ -286,19 +473,44 @@ const (
 	IntegerTestGreaterThan
 	// IntegerTestAnd tests that all the bits in the pattern are set
 	IntegerTestAnd
+	// IntegerTestBitsClear tests that not all the bits in the pattern are set
+	IntegerTestBitsClear
 )
 
 // StringKind describes how to match a string pattern
 type StringKind struct {
-	Value  []byte
-	Negate bool
-	Flags  wizardry.StringTestFlags
+	Value    []byte
+	Negate   bool
+	Flags    wizardry.StringTestFlags
+	Operator wizardry.StringOperator
+	MatchAny bool
+}
+
+// String16Kind describes how to match a pattern against UTF-16 text
+type String16Kind struct {
+	Value      []byte
+	Endianness Endianness
+	Negate     bool
+	MatchAny   bool
+}
+
+// GUIDKind describes which GUID to look for, in binary form
+type GUIDKind struct {
+	Value    []byte
+	MatchAny bool
+}
+
+// DERKind describes which ASN.1 element to look for, with a test like
+// "seq", "int1" or "obj_id9=2a864886f70d010701" - see wizardry.DERTest
+type DERKind struct {
+	Test string
 }
 
 // SearchKind describes how to look for a fixed pattern
 type SearchKind struct {
 	Value  []byte
 	MaxLen int64
+	Flags  wizardry.StringTestFlags
 }
 
 // KindFamily groups tests in families (all integer tests, for example)
@@ -319,14 +531,30 @@ const (
 	KindFamilyName
 	// KindFamilyUse acts like a subroutine call, to peruse another page of rules
 	KindFamilyUse
+	// KindFamilyString16 matches a pattern against UTF-16 text
+	KindFamilyString16
+	// KindFamilyIndirect identifies the target again, from the "" page, at a given offset
+	KindFamilyIndirect
+	// KindFamilyOffset performs an integer test on the offset itself
+	KindFamilyOffset
+	// KindFamilyGUID matches a 16-byte binary GUID
+	KindFamilyGUID
+	// KindFamilyDER matches a DER-encoded ASN.1 element
+	KindFamilyDER
+	// KindFamilyCustom is implemented outside of wizardry, see RegisterCustomKind
+	KindFamilyCustom
 
 	// Compiler additions begin
 
 	// KindFamilySwitch is a series of merged KindFamilyInteger
 	KindFamilySwitch
+	// KindFamilyStringSwitch is a series of KindFamilyString at the same
+	// offset, sharing a single read of the target
+	KindFamilyStringSwitch
 )
 
-// Offset describes where to look to compare something
+// Offset describes where to look to compare something. Direct offsets
+// that are negative, and not relative, are counted from the end of the target.
 type Offset struct {
 	OffsetType OffsetType
 	IsRelative bool
@@ -344,17 +572,32 @@ const (
 	OffsetTypeDirect
 )
 
-// IndirectOffset indicates where to look in a file to find the real offset
+// IndirectOffset indicates where to look in a file to find the real offset.
+// If OffsetAddress is negative, and not relative, it's counted from the end
+// of the target.
 type IndirectOffset struct {
 	IsRelative                 bool
 	ByteWidth                  int
 	Endianness                 Endianness
+	Format                     IndirectFormat
 	OffsetAddress              int64
 	OffsetAdjustmentType       Adjustment
 	OffsetAdjustmentIsRelative bool
 	OffsetAdjustmentValue      int64
 }
 
+// IndirectFormat describes how the integer at an indirect offset is encoded
+type IndirectFormat int
+
+const (
+	// IndirectFormatPlain is a regular little-endian or big-endian integer
+	IndirectFormatPlain IndirectFormat = iota
+	// IndirectFormatID3 is a 32-bit synchsafe integer, as found in ID3 tags
+	IndirectFormatID3
+	// IndirectFormatMiddleEndian is a 32-bit PDP-11 style integer
+	IndirectFormatMiddleEndian
+)
+
 // Adjustment describes which operation to apply to an offset
 type Adjustment int
 
@@ -369,10 +612,78 @@ const (
 	AdjustmentMul
 	// AdjustmentDiv divides by a value
 	AdjustmentDiv
+	// AdjustmentMod takes the remainder of a division by a value
+	AdjustmentMod
+	// AdjustmentAnd does a bitwise AND with a value
+	AdjustmentAnd
+	// AdjustmentOr does a bitwise OR with a value
+	AdjustmentOr
+	// AdjustmentXor does a bitwise XOR with a value
+	AdjustmentXor
 )
 
-// UseKind describes which page of the spellbook to use, and whether or not to swap endianness
+// CanApply returns false if the adjustment is a division, or a modulo,
+// by zero - which Apply can't perform
+func (adj Adjustment) CanApply(rhs int64) bool {
+	return rhs != 0 || (adj != AdjustmentDiv && adj != AdjustmentMod)
+}
+
+// Apply performs the adjustment on lhs
+func (adj Adjustment) Apply(lhs int64, rhs int64) int64 {
+	switch adj {
+	case AdjustmentAdd:
+		return lhs + rhs
+	case AdjustmentSub:
+		return lhs - rhs
+	case AdjustmentMul:
+		return lhs * rhs
+	case AdjustmentDiv:
+		return lhs / rhs
+	case AdjustmentMod:
+		return lhs % rhs
+	case AdjustmentAnd:
+		return lhs & rhs
+	case AdjustmentOr:
+		return lhs | rhs
+	case AdjustmentXor:
+		return lhs ^ rhs
+	}
+	return lhs
+}
+
+func (adj Adjustment) String() string {
+	switch adj {
+	case AdjustmentAdd:
+		return "+"
+	case AdjustmentSub:
+		return "-"
+	case AdjustmentMul:
+		return "*"
+	case AdjustmentDiv:
+		return "/"
+	case AdjustmentMod:
+		return "%"
+	case AdjustmentAnd:
+		return "&"
+	case AdjustmentOr:
+		return "|"
+	case AdjustmentXor:
+		return "^"
+	}
+	return ""
+}
+
+// UseKind describes which page of the spellbook to use, and whether or not to swap endianness.
+// Like in libmagic, pages take no other parameters: the offset of the use rule
+// becomes the page offset, which the page's relative offsets count from.
 type UseKind struct {
 	SwapEndian bool
 	Page       string
 }
+
+// IndirectKind describes how to identify the target again at another offset
+type IndirectKind struct {
+	// Relative is true if the offset is relative to the start of the current
+	// page rather than the start of the target
+	Relative bool
+}
@@ -0,0 +1,306 @@
+package wizparser
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/itchio/wizardry/wizardry"
+)
+
+// PageBuilder assembles the rules of one page of a spellbook, as an
+// alternative to parsing them from magic files. Levels are never given
+// explicitly: they follow from how rules are nested with Then.
+type PageBuilder struct {
+	name  string
+	rules []*RuleBuilder
+}
+
+// RuleBuilder describes a single rule and its children. Errors (like setting
+// a kind twice) are recorded and reported when the page is built.
+type RuleBuilder struct {
+	offset      Offset
+	kind        Kind
+	hasKind     bool
+	description []byte
+	fields      []FieldCapture
+	strength    *StrengthAdjustment
+	children    []*RuleBuilder
+	err         error
+}
+
+// Page starts building the page with the given name, or the root page if
+// name is empty. Named pages get their name rule added automatically.
+func Page(name string) *PageBuilder {
+	return &PageBuilder{name: name}
+}
+
+// At adds a top-level rule testing at the given offset, and returns it
+func (pb *PageBuilder) At(offset int64) *RuleBuilder {
+	rb := At(offset)
+	pb.rules = append(pb.rules, rb)
+	return rb
+}
+
+// Add appends top-level rules to the page
+func (pb *PageBuilder) Add(rules ...*RuleBuilder) *PageBuilder {
+	pb.rules = append(pb.rules, rules...)
+	return pb
+}
+
+// Rules flattens the page into levelled rules, and validates them
+func (pb *PageBuilder) Rules() ([]Rule, error) {
+	var rules []Rule
+	baseLevel := 0
+
+	if pb.name != "" {
+		rules = append(rules, Rule{
+			Offset: Offset{OffsetType: OffsetTypeDirect},
+			Kind:   Kind{Family: KindFamilyName},
+		})
+		rules[0].Line = fmt.Sprintf("0 name %s", pb.name)
+		baseLevel = 1
+	}
+
+	var walk func(rb *RuleBuilder, level int) error
+	walk = func(rb *RuleBuilder, level int) error {
+		if rb == nil {
+			return errors.New("nil rule in builder")
+		}
+		if rb.err != nil {
+			return rb.err
+		}
+		if !rb.hasKind {
+			return fmt.Errorf("rule at %s has no kind", rb.offset)
+		}
+
+		rule := Rule{
+			Level:              level,
+			Offset:             rb.offset,
+			Kind:               rb.kind,
+			Description:        rb.description,
+			Fields:             rb.fields,
+			StrengthAdjustment: rb.strength,
+		}
+		rule.Line = rule.String()
+		rules = append(rules, rule)
+
+		for _, child := range rb.children {
+			err := walk(child, level+1)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, rb := range pb.rules {
+		err := walk(rb, baseLevel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := ValidateRules(pb.name, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// AddTo builds the page and appends its rules to the spellbook
+func (pb *PageBuilder) AddTo(book Spellbook) error {
+	rules, err := pb.Rules()
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		book.AddRule(pb.name, rule)
+	}
+	return nil
+}
+
+// At starts a rule testing at an absolute offset, to be passed to
+// PageBuilder.Add or RuleBuilder.Then
+func At(offset int64) *RuleBuilder {
+	return AtOffset(Offset{
+		OffsetType: OffsetTypeDirect,
+		Direct:     offset,
+	})
+}
+
+// AtRelative starts a rule testing at an offset relative to where
+// its parent's match ended
+func AtRelative(offset int64) *RuleBuilder {
+	return AtOffset(Offset{
+		OffsetType: OffsetTypeDirect,
+		IsRelative: true,
+		Direct:     offset,
+	})
+}
+
+// AtIndirect starts a rule testing at an offset read from the target
+func AtIndirect(indirect IndirectOffset) *RuleBuilder {
+	return AtOffset(Offset{
+		OffsetType: OffsetTypeIndirect,
+		Indirect:   &indirect,
+	})
+}
+
+// AtOffset starts a rule testing at an arbitrary offset
+func AtOffset(offset Offset) *RuleBuilder {
+	return &RuleBuilder{offset: offset}
+}
+
+// Then nests rules under this one, they're only tried if it matches
+func (rb *RuleBuilder) Then(children ...*RuleBuilder) *RuleBuilder {
+	rb.children = append(rb.children, children...)
+	return rb
+}
+
+// Describe sets what is printed when the rule matches, which may contain
+// a printf-style verb for the value read
+func (rb *RuleBuilder) Describe(description string) *RuleBuilder {
+	rb.description = []byte(description)
+	return rb
+}
+
+// Field captures a value under name when the rule matches, like a
+// "!:field name format" annotation. format may be empty.
+func (rb *RuleBuilder) Field(name string, format string) *RuleBuilder {
+	rb.fields = append(rb.fields, FieldCapture{Name: name, Format: []byte(format)})
+	return rb
+}
+
+// Strength adjusts the rule's strength, like a "!:strength" annotation,
+// with operator one of '+', '-', '*' or '/'
+func (rb *RuleBuilder) Strength(operator byte, value int) *RuleBuilder {
+	rb.strength = &StrengthAdjustment{Operator: operator, Value: value}
+	return rb
+}
+
+// Kind sets the test performed by the rule
+func (rb *RuleBuilder) Kind(kind Kind) *RuleBuilder {
+	if rb.hasKind {
+		rb.fail(fmt.Errorf("rule at %s: kind set twice (%s, then %s)", rb.offset, rb.kind, kind))
+		return rb
+	}
+	rb.kind = kind
+	rb.hasKind = true
+	return rb
+}
+
+func (rb *RuleBuilder) fail(err error) {
+	if rb.err == nil {
+		rb.err = err
+	}
+}
+
+// Integer tests an integer as described by ik
+func (rb *RuleBuilder) Integer(ik IntegerKind) *RuleBuilder {
+	return rb.Kind(Kind{Family: KindFamilyInteger, Data: &ik})
+}
+
+func (rb *RuleBuilder) integerEquals(byteWidth int, value int64, endianness Endianness) *RuleBuilder {
+	return rb.Integer(IntegerKind{
+		ByteWidth:   byteWidth,
+		Endianness:  endianness,
+		Signed:      true,
+		IntegerTest: IntegerTestEqual,
+		Value:       value,
+	})
+}
+
+// Byte tests that a byte equals value
+func (rb *RuleBuilder) Byte(value int64) *RuleBuilder {
+	return rb.integerEquals(1, value, LittleEndian)
+}
+
+// Short tests that a 16-bit integer equals value
+func (rb *RuleBuilder) Short(value int64, endianness Endianness) *RuleBuilder {
+	return rb.integerEquals(2, value, endianness)
+}
+
+// Long tests that a 32-bit integer equals value
+func (rb *RuleBuilder) Long(value int64, endianness Endianness) *RuleBuilder {
+	return rb.integerEquals(4, value, endianness)
+}
+
+// Quad tests that a 64-bit integer equals value
+func (rb *RuleBuilder) Quad(value int64, endianness Endianness) *RuleBuilder {
+	return rb.integerEquals(8, value, endianness)
+}
+
+// String tests that the target contains value
+func (rb *RuleBuilder) String(value string) *RuleBuilder {
+	return rb.StringWith(StringKind{Value: []byte(value)})
+}
+
+// StringWith performs a string test as described by sk
+func (rb *RuleBuilder) StringWith(sk StringKind) *RuleBuilder {
+	return rb.Kind(Kind{Family: KindFamilyString, Data: &sk})
+}
+
+// Search looks for value within maxLen bytes
+func (rb *RuleBuilder) Search(value string, maxLen int64) *RuleBuilder {
+	return rb.Kind(Kind{
+		Family: KindFamilySearch,
+		Data: &SearchKind{
+			Value:  []byte(value),
+			MaxLen: maxLen,
+		},
+	})
+}
+
+// GUID tests for a GUID written as XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX
+func (rb *RuleBuilder) GUID(value string) *RuleBuilder {
+	guid, err := wizardry.ParseGUID(value)
+	if err != nil {
+		rb.fail(err)
+		return rb
+	}
+	return rb.Kind(Kind{Family: KindFamilyGUID, Data: &GUIDKind{Value: guid}})
+}
+
+// DER tests for a DER element, see wizardry.DERTest
+func (rb *RuleBuilder) DER(test string) *RuleBuilder {
+	return rb.Kind(Kind{Family: KindFamilyDER, Data: &DERKind{Test: test}})
+}
+
+// Custom tests the target with a registered custom kind, test being
+// what would follow the kind in a magic file
+func (rb *RuleBuilder) Custom(name string, test string) *RuleBuilder {
+	def := LookupCustomKind(name)
+	if def == nil {
+		rb.fail(fmt.Errorf("unknown custom kind %s", name))
+		return rb
+	}
+
+	kind, err := NewCustomKind(def, "", []byte(test))
+	if err != nil {
+		rb.fail(err)
+		return rb
+	}
+	return rb.Kind(kind)
+}
+
+// Use identifies the target with another page, at the rule's offset
+func (rb *RuleBuilder) Use(page string) *RuleBuilder {
+	return rb.Kind(Kind{Family: KindFamilyUse, Data: &UseKind{Page: page}})
+}
+
+// UseSwapped is like Use, but with the endianness of integers swapped
+func (rb *RuleBuilder) UseSwapped(page string) *RuleBuilder {
+	return rb.Kind(Kind{Family: KindFamilyUse, Data: &UseKind{Page: page, SwapEndian: true}})
+}
+
+// Default matches if none of its siblings matched
+func (rb *RuleBuilder) Default() *RuleBuilder {
+	return rb.Kind(Kind{Family: KindFamilyDefault})
+}
+
+// Clear resets the matched state of its siblings, for later defaults
+func (rb *RuleBuilder) Clear() *RuleBuilder {
+	return rb.Kind(Kind{Family: KindFamilyClear})
+}
@@ -0,0 +1,122 @@
+package wizparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BuilderLevels(t *testing.T) {
+	book := make(Spellbook)
+
+	err := Page("").Add(
+		At(0).String("UnityFS").Describe("Unity asset bundle").Then(
+			At(8).Long(6, BigEndian).Describe("version 6"),
+			At(8).Default().Describe("unknown version").Then(
+				AtRelative(4).Use("unity-header"),
+			),
+		),
+	).AddTo(book)
+	assert.NoError(t, err)
+
+	err = Page("unity-header").Add(
+		At(0).Byte(0).Describe("(empty header)"),
+	).AddTo(book)
+	assert.NoError(t, err)
+
+	root := book[""]
+	assert.Len(t, root, 4)
+
+	levels := []int{}
+	for _, rule := range root {
+		levels = append(levels, rule.Level)
+	}
+	assert.EqualValues(t, []int{0, 1, 1, 2}, levels)
+	assert.EqualValues(t, KindFamilyUse, root[3].Kind.Family)
+	assert.True(t, root[3].Offset.IsRelative)
+
+	page := book["unity-header"]
+	assert.Len(t, page, 2)
+	assert.EqualValues(t, KindFamilyName, page[0].Kind.Family)
+	assert.EqualValues(t, 1, page[1].Level)
+
+	assert.NoError(t, book.Validate())
+}
+
+func Test_BuilderErrors(t *testing.T) {
+	_, err := Page("").Add(At(0).String("a").Byte(1)).Rules()
+	assert.Error(t, err, "kind set twice")
+
+	_, err = Page("").Add(At(0).Describe("no kind")).Rules()
+	assert.Error(t, err, "missing kind")
+
+	_, err = Page("").Add(At(0).GUID("not-a-guid")).Rules()
+	assert.Error(t, err, "invalid guid")
+
+	_, err = Page("").Add(At(0).String("")).Rules()
+	assert.Error(t, err, "empty string")
+
+	_, err = Page("").Add(AtIndirect(IndirectOffset{ByteWidth: 3}).Byte(0)).Rules()
+	assert.Error(t, err, "indirect byte width")
+}
+
+func Test_ValidateRules(t *testing.T) {
+	byteRule := func(level int) Rule {
+		return Rule{
+			Level:  level,
+			Offset: Offset{OffsetType: OffsetTypeDirect},
+			Kind: Kind{
+				Family: KindFamilyInteger,
+				Data:   &IntegerKind{ByteWidth: 1},
+			},
+		}
+	}
+
+	assert.NoError(t, ValidateRules("", []Rule{byteRule(0), byteRule(1), byteRule(0)}))
+
+	err := ValidateRules("", []Rule{byteRule(0), byteRule(2)})
+	if assert.Error(t, err) {
+		ve, ok := err.(*ValidationError)
+		assert.True(t, ok)
+		assert.EqualValues(t, 1, ve.Index)
+	}
+
+	assert.Error(t, ValidateRules("", []Rule{byteRule(1)}), "first rule not at level 0")
+	assert.Error(t, ValidateRules("page", []Rule{byteRule(0)}), "page without name rule")
+
+	noData := byteRule(0)
+	noData.Kind.Data = nil
+	assert.Error(t, ValidateRules("", []Rule{noData}), "integer without data")
+
+	tooDeep := byteRule(0)
+	tooDeep.Level = 64
+	assert.Error(t, ValidateRules("", []Rule{byteRule(0), tooDeep}), "level out of range")
+
+	emptyString16 := byteRule(0)
+	emptyString16.Kind = Kind{Family: KindFamilyString16, Data: &String16Kind{}}
+	assert.Error(t, ValidateRules("", []Rule{emptyString16}), "empty string16 pattern")
+
+	divByZero := byteRule(0)
+	divByZero.Kind.Data = &IntegerKind{ByteWidth: 1, AdjustmentType: AdjustmentDiv}
+	assert.Error(t, ValidateRules("", []Rule{divByZero}), "division by zero")
+}
+
+func Test_BuilderFingerprint(t *testing.T) {
+	fingerprint := func(rb *RuleBuilder) string {
+		book := make(Spellbook)
+		assert.NoError(t, Page("").Add(rb).AddTo(book))
+		return book.Fingerprint()
+	}
+
+	less := func() *RuleBuilder {
+		return At(0).Integer(IntegerKind{ByteWidth: 1, IntegerTest: IntegerTestLessThan, Value: 5}).Describe("small")
+	}
+	more := At(0).Integer(IntegerKind{ByteWidth: 1, IntegerTest: IntegerTestGreaterThan, Value: 5}).Describe("small")
+
+	// the same rules built twice have the same fingerprint
+	assert.EqualValues(t, fingerprint(less()), fingerprint(less()))
+
+	// details that don't show up in the rule's line still count
+	assert.NotEqual(t, fingerprint(less()), fingerprint(more))
+	assert.NotEqual(t, fingerprint(At(4).Byte(1)), fingerprint(AtRelative(4).Byte(1)))
+}
@@ -0,0 +1,123 @@
+package wizparser
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/itchio/wizardry/wizardry"
+	"github.com/itchio/wizardry/wizardry/wizutil"
+)
+
+// CustomKindDefinition describes a kind implemented outside of wizardry,
+// like "crc32" or "entropy", so that magic files can use it as
+// "0 crc32 =0x1234 something". Register it with RegisterCustomKind
+// before parsing.
+type CustomKindDefinition struct {
+	// Name is used in place of the kind in magic files. It must be made
+	// of lowercase letters and digits, and can't shadow a built-in kind.
+	Name string
+
+	// Parse turns what follows the name in the kind (like "/16" in
+	// "crc32/16") and the test operand (with escapes left as-is) into data
+	// passed to Evaluate and Compile. If nil, the test operand is kept as a string.
+	Parse func(suffix string, test []byte) (interface{}, error)
+
+	// Evaluate is called by the interpreter to test the target at offset
+	Evaluate func(sr *wizutil.SliceReader, offset int64, data interface{}) wizardry.CustomResult
+
+	// Compile is optional, and used by the compiler. It returns a go
+	// expression of type wizardry.CustomResult, given the data returned by
+	// Parse, and expressions for the *wizutil.SliceReader and the int64 offset.
+	// Books using a kind without Compile can only be interpreted.
+	Compile func(data interface{}, reader string, offset string) string
+
+	// Imports lists packages the expressions returned by Compile use. Each of
+	// them must actually be used, or the generated code won't build.
+	Imports []string
+
+	// Version should change whenever Evaluate or Compile start returning
+	// different results. It's part of the fingerprint of spellbooks using
+	// the kind, so that results cached by wizcache aren't used anymore.
+	Version string
+}
+
+// CustomKind is a rule's use of a custom kind
+type CustomKind struct {
+	Definition *CustomKindDefinition
+	Suffix     string
+	Test       string
+	Data       interface{}
+}
+
+// names the parser handles itself, which custom kinds can't take over
+var builtinKinds = map[string]bool{
+	"byte": true, "short": true, "long": true, "quad": true, "offset": true,
+	"ubyte": true, "ushort": true, "ulong": true, "uquad": true,
+	"beshort": true, "belong": true, "bequad": true,
+	"leshort": true, "lelong": true, "lequad": true,
+	"ubeshort": true, "ubelong": true, "ubequad": true,
+	"uleshort": true, "ulelong": true, "ulequad": true,
+	"string": true, "lestring16": true, "bestring16": true, "search": true,
+	"guid": true, "der": true, "indirect": true,
+	"default": true, "clear": true, "name": true, "use": true,
+}
+
+var customKinds = make(map[string]*CustomKindDefinition)
+var customKindsMutex sync.RWMutex
+
+// RegisterCustomKind makes a custom kind available to the parser
+func RegisterCustomKind(def *CustomKindDefinition) error {
+	if def.Name == "" {
+		return fmt.Errorf("custom kind without a name")
+	}
+	for i := 0; i < len(def.Name); i++ {
+		c := def.Name[i]
+		if !wizutil.IsLowerLetter(c) && !wizutil.IsNumber(c) {
+			return fmt.Errorf("custom kind %s: names may only contain lowercase letters and digits", def.Name)
+		}
+	}
+	if builtinKinds[def.Name] {
+		return fmt.Errorf("custom kind %s would shadow a built-in kind", def.Name)
+	}
+	if def.Evaluate == nil {
+		return fmt.Errorf("custom kind %s has no Evaluate function", def.Name)
+	}
+
+	customKindsMutex.Lock()
+	defer customKindsMutex.Unlock()
+
+	if _, ok := customKinds[def.Name]; ok {
+		return fmt.Errorf("custom kind %s registered twice", def.Name)
+	}
+	customKinds[def.Name] = def
+	return nil
+}
+
+// LookupCustomKind returns the custom kind registered with that name, or nil
+func LookupCustomKind(name string) *CustomKindDefinition {
+	customKindsMutex.RLock()
+	defer customKindsMutex.RUnlock()
+
+	return customKinds[name]
+}
+
+// NewCustomKind parses the test operand of a custom kind into a Kind
+func NewCustomKind(def *CustomKindDefinition, suffix string, test []byte) (Kind, error) {
+	ck := &CustomKind{
+		Definition: def,
+		Suffix:     suffix,
+		Test:       string(test),
+	}
+
+	if def.Parse != nil {
+		data, err := def.Parse(suffix, test)
+		if err != nil {
+			return Kind{}, fmt.Errorf("%s: %s", def.Name, err.Error())
+		}
+		ck.Data = data
+	} else {
+		ck.Data = ck.Test
+	}
+
+	return Kind{Family: KindFamilyCustom, Data: ck}, nil
+}
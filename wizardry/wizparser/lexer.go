@@ -213,6 +213,30 @@ func parseString(input []byte, j int) (*parsedString, error) {
 	}, nil
 }
 
+// parseAdjustment returns the adjustment for an operator character,
+// or AdjustmentNone if it isn't one
+func parseAdjustment(c byte) Adjustment {
+	switch c {
+	case '+':
+		return AdjustmentAdd
+	case '-':
+		return AdjustmentSub
+	case '*':
+		return AdjustmentMul
+	case '/':
+		return AdjustmentDiv
+	case '%':
+		return AdjustmentMod
+	case '&':
+		return AdjustmentAnd
+	case '|':
+		return AdjustmentOr
+	case '^':
+		return AdjustmentXor
+	}
+	return AdjustmentNone
+}
+
 type parsedStringTestFlags struct {
 	Flags    wizardry.StringTestFlags
 	NewIndex int
@@ -223,7 +247,7 @@ func parseStringTestFlags(input []byte, j int) *parsedStringTestFlags {
 
 	result := &parsedStringTestFlags{}
 
-	for j < inputSize {
+	for j < inputSize && input[j] != '/' {
 		switch input[j] {
 		case 'W':
 			result.Flags |= wizardry.CompactWhitespace
@@ -237,6 +261,12 @@ func parseStringTestFlags(input []byte, j int) *parsedStringTestFlags {
 			result.Flags |= wizardry.ForceText
 		case 'b':
 			result.Flags |= wizardry.ForceBinary
+		case 'T':
+			result.Flags |= wizardry.TrimWhitespace
+		case 'f':
+			result.Flags |= wizardry.FullWord
+		case 's':
+			result.Flags |= wizardry.NoOffsetUpdate
 		default:
 			break
 		}
@@ -7,8 +7,10 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/itchio/wizardry/wizardry"
 	"github.com/itchio/wizardry/wizardry/wizutil"
 	"github.com/pkg/errors"
 )
@@ -59,6 +61,10 @@ func (ctx *ParseContext) Parse(magicReader io.Reader, book Spellbook) error {
 
 	page := ""
 
+	// when a rule is skipped, its children are skipped too, so
+	// they don't end up attached to the wrong parent
+	skipLevel := -1
+
 	for scanner.Scan() {
 		line := scanner.Text()
 		lineBytes := []byte(line)
@@ -77,6 +83,10 @@ func (ctx *ParseContext) Parse(magicReader io.Reader, book Spellbook) error {
 		}
 
 		if lineBytes[i] == '!' {
+			// annotations apply to the rule right above them, unless it was skipped
+			if skipLevel < 0 && len(book[page]) > 0 {
+				ctx.parseAnnotation(line, &book[page][len(book[page])-1])
+			}
 			continue
 		}
 
@@ -100,6 +110,13 @@ func (ctx *ParseContext) Parse(magicReader io.Reader, book Spellbook) error {
 
 		ctx.Logf("| %s", line)
 
+		if skipLevel >= 0 && rule.Level > skipLevel {
+			ctx.Logf("parent was skipped, skipping rule %s", line)
+			continue
+		}
+		// reset below once the rule is actually added
+		skipLevel = rule.Level
+
 		// read offset
 		offsetStart := i
 		for i < numBytes && !wizutil.IsWhitespace(lineBytes[i]) {
@@ -198,29 +215,23 @@ func (ctx *ParseContext) Parse(magicReader io.Reader, book Spellbook) error {
 				case 'b':
 					indirect.ByteWidth = 1
 				case 'i':
-					ctx.Logf("id3 format not supported, skipping %s", line)
-					continue
+					indirect.ByteWidth = 4
+					indirect.Format = IndirectFormatID3
 				case 's':
 					indirect.ByteWidth = 2
 				case 'l':
 					indirect.ByteWidth = 4
+				case 'q':
+					indirect.ByteWidth = 8
 				case 'm':
-					ctx.Logf("middle-endian format not supported, skipping %s", line)
-					continue
+					indirect.ByteWidth = 4
+					indirect.Format = IndirectFormatMiddleEndian
 				default:
 					ctx.Logf("unsupported indirect addr format %c, skipping %s", indirectAddrFormat, line)
 					continue
 				}
 
-				if offsetBytes[j] == '+' {
-					indirect.OffsetAdjustmentType = AdjustmentAdd
-				} else if offsetBytes[j] == '-' {
-					indirect.OffsetAdjustmentType = AdjustmentSub
-				} else if offsetBytes[j] == '*' {
-					indirect.OffsetAdjustmentType = AdjustmentMul
-				} else if offsetBytes[j] == '/' {
-					indirect.OffsetAdjustmentType = AdjustmentDiv
-				}
+				indirect.OffsetAdjustmentType = parseAdjustment(offsetBytes[j])
 
 				if indirect.OffsetAdjustmentType != AdjustmentNone {
 					j++
@@ -239,6 +250,11 @@ func (ctx *ParseContext) Parse(magicReader io.Reader, book Spellbook) error {
 					indirect.OffsetAdjustmentValue = parsedRHS.Value
 					j = parsedRHS.NewIndex
 
+					if !indirect.OffsetAdjustmentIsRelative && !indirect.OffsetAdjustmentType.CanApply(indirect.OffsetAdjustmentValue) {
+						ctx.Logf("indirect offset divides by zero, skipping rule %s", line)
+						continue
+					}
+
 					if indirect.OffsetAdjustmentIsRelative {
 						if offsetBytes[j] != ')' {
 							ctx.Logf("malformed relative offset adjustment, missing closing ')' - in %s", line)
@@ -280,7 +296,8 @@ func (ctx *ParseContext) Parse(magicReader io.Reader, book Spellbook) error {
 				"uleshort", "ulelong", "ulequad",
 				"byte", "short", "long", "quad",
 				"beshort", "belong", "bequad",
-				"leshort", "lelong", "lequad":
+				"leshort", "lelong", "lequad",
+				"offset":
 
 				ik := &IntegerKind{}
 				rule.Kind.Family = KindFamilyInteger
@@ -311,6 +328,11 @@ func (ctx *ParseContext) Parse(magicReader io.Reader, book Spellbook) error {
 					ik.ByteWidth = 4
 				case "quad":
 					ik.ByteWidth = 8
+				case "offset":
+					// tests the offset itself rather than what's there
+					rule.Kind.Family = KindFamilyOffset
+					ik.ByteWidth = 8
+					ik.Signed = false
 				default:
 					ctx.Logf("unrecognized integer kind %s, skipping rule %s", simpleKind, line)
 					continue
@@ -318,19 +340,10 @@ func (ctx *ParseContext) Parse(magicReader io.Reader, book Spellbook) error {
 
 				ik.DoAnd = false
 
-				if j < len(kind) {
-					switch kind[j] {
-					case '+':
-						ik.AdjustmentType = AdjustmentAdd
-						j++
-					case '-':
-						ik.AdjustmentType = AdjustmentSub
-						j++
-					case '*':
-						ik.AdjustmentType = AdjustmentMul
-						j++
-					case '/':
-						ik.AdjustmentType = AdjustmentDiv
+				// '&' is handled separately below, as a mask
+				if j < len(kind) && kind[j] != '&' {
+					ik.AdjustmentType = parseAdjustment(kind[j])
+					if ik.AdjustmentType != AdjustmentNone {
 						j++
 					}
 
@@ -342,6 +355,11 @@ func (ctx *ParseContext) Parse(magicReader io.Reader, book Spellbook) error {
 						}
 						ik.AdjustmentValue = pi.Value
 						j = pi.NewIndex
+
+						if !ik.AdjustmentType.CanApply(ik.AdjustmentValue) {
+							ctx.Logf("integer kind adjustment divides by zero, skipping rule %s", line)
+							continue
+						}
 					}
 				}
 
@@ -361,6 +379,11 @@ func (ctx *ParseContext) Parse(magicReader io.Reader, book Spellbook) error {
 
 				k := 0
 
+				if test[k] == '~' {
+					ik.Invert = true
+					k++
+				}
+
 				switch test[k] {
 				case 'x':
 					ik.MatchAny = true
@@ -380,6 +403,9 @@ func (ctx *ParseContext) Parse(magicReader io.Reader, book Spellbook) error {
 				case '&':
 					ik.IntegerTest = IntegerTestAnd
 					k++
+				case '^':
+					ik.IntegerTest = IntegerTestBitsClear
+					k++
 				}
 
 				if !ik.MatchAny {
@@ -399,24 +425,77 @@ func (ctx *ParseContext) Parse(magicReader io.Reader, book Spellbook) error {
 				rule.Kind.Data = sk
 
 				k := 0
-				sk.Negate = false
-				if test[k] == '!' {
-					sk.Negate = true
-					k++
-				}
+				if len(test) == 1 && test[k] == 'x' {
+					sk.MatchAny = true
+				} else {
+					if test[k] == '!' {
+						sk.Negate = true
+						k++
+					}
 
-				parsedRHS, err := parseString(test, k)
-				if err != nil {
-					ctx.Logf("in string test, couldn't parse rhs: %s - skipping", err.Error())
-					continue
+					if k < len(test) {
+						switch test[k] {
+						case '=':
+							k++
+						case '<':
+							sk.Operator = wizardry.StringLessThan
+							k++
+						case '>':
+							sk.Operator = wizardry.StringGreaterThan
+							k++
+						}
+					}
+
+					parsedRHS, err := parseString(test, k)
+					if err != nil {
+						ctx.Logf("in string test, couldn't parse rhs: %s - skipping", err.Error())
+						continue
+					}
+					sk.Value = parsedRHS.Value
+
+					if len(sk.Value) == 0 {
+						ctx.Logf("in string test, empty pattern - skipping %s", line)
+						continue
+					}
 				}
-				sk.Value = parsedRHS.Value
 
-				if j < len(kind) && kind[j] == '/' {
+				for j < len(kind) && kind[j] == '/' {
 					j++
 					parsedFlags := parseStringTestFlags(kind, j)
 					j = parsedFlags.NewIndex
-					sk.Flags = parsedFlags.Flags
+					sk.Flags |= parsedFlags.Flags
+				}
+
+			case "lestring16", "bestring16":
+				sk := &String16Kind{}
+				rule.Kind.Family = KindFamilyString16
+				rule.Kind.Data = sk
+
+				sk.Endianness = LittleEndian
+				if parsedKind.Value == "bestring16" {
+					sk.Endianness = BigEndian
+				}
+
+				k := 0
+				if len(test) == 1 && test[k] == 'x' {
+					sk.MatchAny = true
+				} else {
+					if test[k] == '!' {
+						sk.Negate = true
+						k++
+					}
+
+					parsedRHS, err := parseString(test, k)
+					if err != nil {
+						ctx.Logf("in string16 test, couldn't parse rhs: %s - skipping", err.Error())
+						continue
+					}
+					sk.Value = parsedRHS.Value
+
+					if len(sk.Value) == 0 {
+						ctx.Logf("in string16 test, empty pattern - skipping %s", line)
+						continue
+					}
 				}
 
 			case "search":
@@ -425,16 +504,29 @@ func (ctx *ParseContext) Parse(magicReader io.Reader, book Spellbook) error {
 				rule.Kind.Data = sk
 
 				sk.MaxLen = 8192
-				if j < len(kind) && kind[j] == '/' {
+				validKind := true
+				for j < len(kind) && kind[j] == '/' {
 					j++
-					parsedLen, err := parseUint(kind, j)
-					if err != nil {
-						ctx.Logf("in search test, couldn't parse max len in %s: %s - skipping\n", kind[j:], err.Error())
-						continue
+					if j < len(kind) && wizutil.IsNumber(kind[j]) {
+						parsedLen, err := parseUint(kind, j)
+						if err != nil {
+							ctx.Logf("in search test, couldn't parse max len in %s: %s - skipping\n", kind[j:], err.Error())
+							validKind = false
+							break
+						}
+
+						j = parsedLen.NewIndex
+						sk.MaxLen = int64(parsedLen.Value)
+					} else {
+						// search flags and max len can come in any order
+						parsedFlags := parseStringTestFlags(kind, j)
+						j = parsedFlags.NewIndex
+						sk.Flags |= parsedFlags.Flags
 					}
+				}
 
-					j = parsedLen.NewIndex
-					sk.MaxLen = int64(parsedLen.Value)
+				if !validKind {
+					continue
 				}
 
 				k := 0
@@ -447,6 +539,37 @@ func (ctx *ParseContext) Parse(magicReader io.Reader, book Spellbook) error {
 				k = parsedRHS.NewIndex
 				sk.Value = parsedRHS.Value
 
+			case "guid":
+				gk := &GUIDKind{}
+				rule.Kind.Family = KindFamilyGUID
+				rule.Kind.Data = gk
+
+				if string(test) == "x" {
+					gk.MatchAny = true
+				} else {
+					guid, err := wizardry.ParseGUID(string(test))
+					if err != nil {
+						ctx.Logf("in guid test: %s - skipping", err.Error())
+						continue
+					}
+					gk.Value = guid
+				}
+
+			case "der":
+				rule.Kind.Family = KindFamilyDER
+				rule.Kind.Data = &DERKind{
+					Test: string(test),
+				}
+
+			case "indirect":
+				ik := &IndirectKind{}
+				rule.Kind.Family = KindFamilyIndirect
+				rule.Kind.Data = ik
+
+				if j+1 < len(kind) && kind[j] == '/' && kind[j+1] == 'r' {
+					ik.Relative = true
+				}
+
 			case "default":
 				rule.Kind.Family = KindFamilyDefault
 			case "clear":
@@ -470,14 +593,88 @@ func (ctx *ParseContext) Parse(magicReader io.Reader, book Spellbook) error {
 
 				uk.Page = string(test[k:])
 			default:
-				ctx.Logf("unhandled kind (%s)\n", parsedKind.Value)
-				continue
+				def := LookupCustomKind(parsedKind.Value)
+				if def == nil {
+					ctx.Logf("unhandled kind (%s)\n", parsedKind.Value)
+					continue
+				}
+
+				customKind, err := NewCustomKind(def, string(kind[j:]), test)
+				if err != nil {
+					ctx.Logf("invalid custom kind test: %s, skipping rule %s", err.Error(), line)
+					continue
+				}
+				rule.Kind = customKind
 			}
 
 			rule.Description = descriptionBytes
 			book.AddRule(page, rule)
+			skipLevel = -1
 		}
 	}
 
 	return nil
 }
+
+// parseAnnotation handles "!:" lines that follow a rule. Only "!:field",
+// "!:strength", "!:mime" and "!:ext" are understood, others (like
+// "!:apple") are ignored.
+func (ctx *ParseContext) parseAnnotation(line string, rule *Rule) {
+	if rest, ok := annotationArgs(line, "field"); ok {
+		ctx.parseFieldAnnotation(line, rest, rule)
+	} else if rest, ok := annotationArgs(line, "strength"); ok {
+		ctx.parseStrengthAnnotation(line, rest, rule)
+	} else if rest, ok := annotationArgs(line, "mime"); ok && rest != "" {
+		rule.MIME = rest
+	} else if rest, ok := annotationArgs(line, "ext"); ok && rest != "" {
+		// like "!:ext jpeg/jpg/jpe/jfif"
+		rule.Extensions = strings.Split(rest, "/")
+	}
+}
+
+// annotationArgs returns what follows "!:name" in line, if line is such an annotation
+func annotationArgs(line string, name string) (string, bool) {
+	rest := strings.TrimPrefix(line, "!:"+name)
+	if rest == line || (rest != "" && !wizutil.IsWhitespace(rest[0])) {
+		return "", false
+	}
+	return strings.TrimSpace(rest), true
+}
+
+func (ctx *ParseContext) parseFieldAnnotation(line string, rest string, rule *Rule) {
+	if rest == "" {
+		ctx.Logf("field annotation without a name, ignoring %s", line)
+		return
+	}
+
+	capture := FieldCapture{Name: rest}
+	if i := strings.IndexAny(rest, " \t"); i >= 0 {
+		capture.Name = rest[:i]
+		capture.Format = []byte(strings.TrimSpace(rest[i:]))
+	}
+
+	rule.Fields = append(rule.Fields, capture)
+}
+
+func (ctx *ParseContext) parseStrengthAnnotation(line string, rest string, rule *Rule) {
+	if rest == "" || !strings.ContainsRune("+-*/", rune(rest[0])) {
+		ctx.Logf("strength annotation without an operator, ignoring %s", line)
+		return
+	}
+
+	value, err := strconv.Atoi(strings.TrimSpace(rest[1:]))
+	if err != nil {
+		ctx.Logf("invalid strength annotation, ignoring %s: %s", line, err.Error())
+		return
+	}
+
+	if rest[0] == '/' && value == 0 {
+		ctx.Logf("strength annotation divides by zero, ignoring %s", line)
+		return
+	}
+
+	rule.StrengthAdjustment = &StrengthAdjustment{
+		Operator: rest[0],
+		Value:    value,
+	}
+}
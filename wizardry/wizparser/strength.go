@@ -0,0 +1,128 @@
+package wizparser
+
+import (
+	"fmt"
+
+	"github.com/itchio/wizardry/wizardry"
+)
+
+// StrengthMultiplier is the unit rule strengths are counted in, as in file(1)
+const StrengthMultiplier = 10
+
+// StrengthAdjustment changes the strength computed for a rule, from a
+// "!:strength" annotation like "!:strength *2" or "!:strength + 10"
+type StrengthAdjustment struct {
+	// Operator is one of '+', '-', '*' or '/'
+	Operator byte
+	Value    int
+}
+
+func (sa StrengthAdjustment) String() string {
+	return fmt.Sprintf("%c%d", sa.Operator, sa.Value)
+}
+
+// Apply returns the adjusted strength. Invalid adjustments (unknown
+// operators, division by zero) leave the strength unchanged.
+func (sa StrengthAdjustment) Apply(strength int) int {
+	switch sa.Operator {
+	case '+':
+		return strength + sa.Value
+	case '-':
+		return strength - sa.Value
+	case '*':
+		return strength * sa.Value
+	case '/':
+		if sa.Value != 0 {
+			return strength / sa.Value
+		}
+	}
+	return strength
+}
+
+// Strength tells how specific a rule is, the way file(1) computes it: rules
+// that compare more bytes, and compare them for equality, are stronger.
+// Default rules have a strength of 0, all others at least 1.
+func (r Rule) Strength() int {
+	const mult = StrengthMultiplier
+
+	if r.Kind.Family == KindFamilyDefault || r.Kind.Family == KindFamilyClear {
+		return 0
+	}
+
+	// baseline
+	strength := 2 * mult
+
+	switch r.Kind.Family {
+	case KindFamilyInteger:
+		ik, _ := r.Kind.Data.(*IntegerKind)
+		strength += ik.ByteWidth * mult
+
+		switch {
+		case ik.MatchAny:
+			strength = 0
+		case ik.IntegerTest == IntegerTestEqual:
+			strength += mult
+		case ik.IntegerTest == IntegerTestNotEqual:
+			strength = 0
+		case ik.IntegerTest == IntegerTestLessThan, ik.IntegerTest == IntegerTestGreaterThan:
+			strength -= 2 * mult
+		case ik.IntegerTest == IntegerTestAnd, ik.IntegerTest == IntegerTestBitsClear:
+			strength -= mult
+		}
+
+	case KindFamilyString:
+		sk, _ := r.Kind.Data.(*StringKind)
+		strength += len(sk.Value) * mult
+
+		switch {
+		case sk.MatchAny, sk.Negate:
+			strength = 0
+		case sk.Operator == wizardry.StringEqual:
+			strength += mult
+		default:
+			strength -= 2 * mult
+		}
+
+	case KindFamilyString16:
+		sk, _ := r.Kind.Data.(*String16Kind)
+		strength += len(sk.Value) * mult / 2
+
+		if sk.MatchAny || sk.Negate {
+			strength = 0
+		} else {
+			strength += mult
+		}
+
+	case KindFamilySearch:
+		sk, _ := r.Kind.Data.(*SearchKind)
+		// the further a pattern may be, the less it says about the target
+		if n := len(sk.Value); n > 0 {
+			step := mult / n
+			if step < 1 {
+				step = 1
+			}
+			strength += n*step + mult
+		}
+
+	case KindFamilyGUID:
+		gk, _ := r.Kind.Data.(*GUIDKind)
+		if gk.MatchAny {
+			strength = 0
+		} else {
+			strength += 16*mult + mult
+		}
+
+	case KindFamilyDER:
+		strength += mult
+	}
+
+	if r.StrengthAdjustment != nil {
+		strength = r.StrengthAdjustment.Apply(strength)
+	}
+
+	if strength <= 0 {
+		// only default rules are that weak
+		strength = 1
+	}
+	return strength
+}
@@ -0,0 +1,217 @@
+package wizparser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/itchio/wizardry/wizardry"
+)
+
+// ValidationError describes why a rule of a spellbook is malformed
+type ValidationError struct {
+	Page   string
+	Index  int
+	Rule   Rule
+	Reason string
+}
+
+func (ve *ValidationError) Error() string {
+	page := ve.Page
+	if page == "" {
+		page = "(root)"
+	}
+	return fmt.Sprintf("invalid rule %d of page %s (%s): %s", ve.Index, page, ve.Rule, ve.Reason)
+}
+
+// Validate checks every page of the spellbook, and returns the first
+// malformed rule found as a *ValidationError, or nil.
+func (sb Spellbook) Validate() error {
+	for page, rules := range sb {
+		err := ValidateRules(page, rules)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateRules checks that rules form a well-formed tree for the given page:
+// levels only grow one at a time, every kind carries the data its family
+// needs, and offsets are readable. Named pages must start with their name rule.
+func ValidateRules(page string, rules []Rule) error {
+	for i, rule := range rules {
+		fail := func(format string, args ...interface{}) error {
+			return &ValidationError{
+				Page:   page,
+				Index:  i,
+				Rule:   rule,
+				Reason: fmt.Sprintf(format, args...),
+			}
+		}
+
+		if rule.Level < 0 || rule.Level >= wizardry.MaxLevels {
+			return fail("level %d out of range [0, %d)", rule.Level, wizardry.MaxLevels)
+		}
+
+		if i == 0 {
+			if rule.Level != 0 {
+				return fail("first rule must be at level 0, not %d", rule.Level)
+			}
+		} else if rule.Level > rules[i-1].Level+1 {
+			return fail("level jumps from %d to %d", rules[i-1].Level, rule.Level)
+		}
+
+		if rule.Kind.Family == KindFamilyName {
+			if page == "" {
+				return fail("name rules are not allowed in the root page")
+			}
+			if i != 0 {
+				return fail("name rule must be the first rule of page %s", page)
+			}
+		} else if i == 0 && page != "" {
+			return fail("named page must start with a name rule")
+		}
+
+		if err := validateOffset(rule.Offset); err != "" {
+			return fail("%s", err)
+		}
+
+		if err := validateKind(rule.Kind); err != "" {
+			return fail("%s", err)
+		}
+
+		for _, capture := range rule.Fields {
+			if capture.Name == "" {
+				return fail("field capture without a name")
+			}
+		}
+
+		if sa := rule.StrengthAdjustment; sa != nil {
+			switch {
+			case !strings.ContainsRune("+-*/", rune(sa.Operator)):
+				return fail("unknown strength operator %q", sa.Operator)
+			case sa.Operator == '/' && sa.Value == 0:
+				return fail("strength adjustment divides by zero")
+			}
+		}
+	}
+
+	return nil
+}
+
+func validByteWidth(width int) bool {
+	switch width {
+	case 1, 2, 4, 8:
+		return true
+	}
+	return false
+}
+
+func validateOffset(o Offset) string {
+	switch o.OffsetType {
+	case OffsetTypeDirect:
+		return ""
+	case OffsetTypeIndirect:
+		ind := o.Indirect
+		if ind == nil {
+			return "indirect offset without indirection"
+		}
+		if !validByteWidth(ind.ByteWidth) {
+			return fmt.Sprintf("indirect offset of invalid byte width %d", ind.ByteWidth)
+		}
+		if ind.Format != IndirectFormatPlain && ind.ByteWidth != 4 {
+			return "id3 and middle-endian indirect offsets must be 4 bytes wide"
+		}
+		if !ind.OffsetAdjustmentIsRelative && !ind.OffsetAdjustmentType.CanApply(ind.OffsetAdjustmentValue) {
+			return "indirect offset divides by zero"
+		}
+		return ""
+	}
+	return fmt.Sprintf("unknown offset type %d", o.OffsetType)
+}
+
+func validateKind(k Kind) string {
+	switch k.Family {
+	case KindFamilyInteger, KindFamilyOffset:
+		ik, ok := k.Data.(*IntegerKind)
+		if !ok || ik == nil {
+			return "integer kind without integer data"
+		}
+		if !validByteWidth(ik.ByteWidth) {
+			return fmt.Sprintf("integer of invalid byte width %d", ik.ByteWidth)
+		}
+		if ik.IntegerTest < IntegerTestEqual || ik.IntegerTest > IntegerTestBitsClear {
+			return fmt.Sprintf("unknown integer test %d", ik.IntegerTest)
+		}
+		if !ik.AdjustmentType.CanApply(ik.AdjustmentValue) {
+			return "integer adjustment divides by zero"
+		}
+	case KindFamilyString:
+		sk, ok := k.Data.(*StringKind)
+		if !ok || sk == nil {
+			return "string kind without string data"
+		}
+		if len(sk.Value) == 0 && !sk.MatchAny {
+			return "empty string pattern"
+		}
+	case KindFamilySearch:
+		sk, ok := k.Data.(*SearchKind)
+		if !ok || sk == nil {
+			return "search kind without search data"
+		}
+		if len(sk.Value) == 0 {
+			return "empty search pattern"
+		}
+		if sk.MaxLen < 0 {
+			return fmt.Sprintf("negative search range %d", sk.MaxLen)
+		}
+	case KindFamilyString16:
+		sk, ok := k.Data.(*String16Kind)
+		if !ok || sk == nil {
+			return "string16 kind without string16 data"
+		}
+		if len(sk.Value) == 0 && !sk.MatchAny {
+			return "empty string16 pattern"
+		}
+	case KindFamilyGUID:
+		gk, ok := k.Data.(*GUIDKind)
+		if !ok || gk == nil {
+			return "guid kind without guid data"
+		}
+		if !gk.MatchAny && len(gk.Value) != wizardry.GUIDSize {
+			return fmt.Sprintf("guid of %d bytes, expected %d", len(gk.Value), wizardry.GUIDSize)
+		}
+	case KindFamilyDER:
+		dk, ok := k.Data.(*DERKind)
+		if !ok || dk == nil {
+			return "der kind without der data"
+		}
+	case KindFamilyUse:
+		uk, ok := k.Data.(*UseKind)
+		if !ok || uk == nil {
+			return "use kind without use data"
+		}
+		if uk.Page == "" {
+			return "use of an empty page name"
+		}
+	case KindFamilyIndirect:
+		ik, ok := k.Data.(*IndirectKind)
+		if !ok || ik == nil {
+			return "indirect kind without indirect data"
+		}
+	case KindFamilyCustom:
+		ck, ok := k.Data.(*CustomKind)
+		if !ok || ck == nil || ck.Definition == nil {
+			return "custom kind without definition"
+		}
+		if ck.Definition.Evaluate == nil {
+			return fmt.Sprintf("custom kind %s has no Evaluate function", ck.Definition.Name)
+		}
+	case KindFamilyDefault, KindFamilyClear, KindFamilyName:
+		// nothing to check
+	default:
+		// switches are only ever produced by the compiler
+		return fmt.Sprintf("unsupported kind family %d", k.Family)
+	}
+	return ""
+}
@@ -1,6 +1,9 @@
 package wizutil
 
-const maxBufLen = 128 * 1024 // 128KB buffer
+const (
+	minBufLen = 64         // first read, most tests only look at a few bytes
+	maxBufLen = 128 * 1024 // 128KB buffer
+)
 
 // ByteView allows treating an io.ReaderAt as a byte
 // array.
@@ -21,24 +24,26 @@ func (bv *ByteView) Get(i int64) int {
 		return 1
 	}
 
-	if bv.buf == nil {
-		bv.buf = make([]byte, maxBufLen)
-	}
-
 	// already got it in buf?
 	posInBuffer := i - bv.bufOffset
 	if posInBuffer >= 0 && posInBuffer < bv.bufLen {
 		return int(bv.buf[posInBuffer])
 	}
 
+	// reads grow as the view is read further
+	readLen := min(max(minBufLen, bv.bufLen*2), maxBufLen)
+
 	newOffset := max(0, i-bv.LookBack)
-	newEnd := min(newOffset+maxBufLen-1, bv.Input.Size()-1)
+	newEnd := min(newOffset+readLen-1, bv.Input.Size()-1)
 	newBufLen := (newEnd - newOffset) + 1
 	if newBufLen <= 0 {
 		// input isn't big enough
 		return -1
 	}
 
+	if int64(len(bv.buf)) < newBufLen {
+		bv.buf = make([]byte, readLen)
+	}
 	bv.bufOffset = newOffset
 	bv.bufLen = newBufLen
 
@@ -35,6 +35,11 @@ func IsUpperLetter(b byte) bool {
 	return 'A' <= b && b <= 'Z'
 }
 
+// IsWordCharacter tests if a byte is in [0-9A-Za-z_]
+func IsWordCharacter(b byte) bool {
+	return IsNumber(b) || IsLowerLetter(b) || IsUpperLetter(b) || b == '_'
+}
+
 // ToLower transliterates from [A-Z] to [a-z], other bytes are unchanged
 func ToLower(b byte) byte {
 	if IsUpperLetter(b) {